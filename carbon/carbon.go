@@ -0,0 +1,117 @@
+package carbon
+
+import (
+	"errors"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// TransportMode adalah moda transportasi yang dipilih user menuju tempat wisata
+type TransportMode string
+
+const (
+	ModeCar       TransportMode = "car"
+	ModeMotorbike TransportMode = "motorbike"
+	ModeBus       TransportMode = "bus"
+	ModeTrain     TransportMode = "train"
+	ModeFlight    TransportMode = "flight"
+	ModeFerry     TransportMode = "ferry"
+
+	DefaultMode = ModeCar
+)
+
+// Radius rata-rata bumi dalam kilometer
+const earthRadiusKm = 6371.0088
+
+var ErrUnknownMode = errors.New("Unknown transport mode")
+
+// EmissionFactors memetakan moda transportasi ke faktor emisi dalam gram CO2 per penumpang per kilometer
+type EmissionFactors map[TransportMode]float64
+
+// DefaultEmissionFactors adalah faktor emisi bawaan per penumpang-km
+var DefaultEmissionFactors = EmissionFactors{
+	ModeCar:       171,
+	ModeMotorbike: 113,
+	ModeBus:       97,
+	ModeTrain:     35,
+	ModeFlight:    246,
+	ModeFerry:     19,
+}
+
+// Factor mengembalikan faktor emisi untuk moda tertentu
+func (f EmissionFactors) Factor(mode TransportMode) (float64, error) {
+	factor, ok := f[mode]
+	if !ok {
+		return 0, ErrUnknownMode
+	}
+	return factor, nil
+}
+
+// FactorsFromEnv menyalin faktor bawaan lalu menimpanya dengan variabel lingkungan CARBON_FACTOR_<MODE> jika ada
+func FactorsFromEnv() EmissionFactors {
+	factors := EmissionFactors{}
+	for mode, factor := range DefaultEmissionFactors {
+		factors[mode] = factor
+		value := os.Getenv("CARBON_FACTOR_" + strings.ToUpper(string(mode)))
+		if override, err := strconv.ParseFloat(value, 64); err == nil && override >= 0 {
+			factors[mode] = override
+		}
+	}
+	return factors
+}
+
+// ParseMode mengubah input user menjadi TransportMode, string kosong menghasilkan DefaultMode
+func ParseMode(value string) (TransportMode, error) {
+	if value == "" {
+		return DefaultMode, nil
+	}
+	mode := TransportMode(strings.ToLower(strings.TrimSpace(value)))
+	if _, ok := DefaultEmissionFactors[mode]; !ok {
+		return "", ErrUnknownMode
+	}
+	return mode, nil
+}
+
+// Distance menghitung jarak geodesik (haversine) dalam kilometer antara dua koordinat bertanda
+func Distance(lat1, long1, lat2, long2 float64) float64 {
+	lat1Rad := lat1 * math.Pi / 180
+	lat2Rad := lat2 * math.Pi / 180
+	dLat := (lat2 - lat1) * math.Pi / 180
+	dLong := (long2 - long1) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1Rad)*math.Cos(lat2Rad)*math.Sin(dLong/2)*math.Sin(dLong/2)
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+	return earthRadiusKm * c
+}
+
+// Footprint adalah hasil perhitungan emisi beserta faktor yang digunakan
+type Footprint struct {
+	Mode           TransportMode `json:"transport_mode"`
+	DistanceKm     float64       `json:"distance_km"`
+	EmissionFactor float64       `json:"emission_factor"` // gram CO2 per km
+	CarbonGrams    float64       `json:"carbon_footprint"`
+}
+
+// Calculate menghitung emisi perjalanan berdasarkan jarak dan moda transportasi
+func (f EmissionFactors) Calculate(distanceKm float64, mode TransportMode) (Footprint, error) {
+	factor, err := f.Factor(mode)
+	if err != nil {
+		return Footprint{}, err
+	}
+	return Footprint{
+		Mode:           mode,
+		DistanceKm:     distanceKm,
+		EmissionFactor: factor,
+		CarbonGrams:    distanceKm * factor,
+	}, nil
+}
+
+// OffsetCost mengonversi gram CO2 menjadi biaya offset dalam rupiah berdasarkan harga per ton, dibulatkan ke atas
+func OffsetCost(grams float64, pricePerTonne int) int {
+	if grams <= 0 || pricePerTonne <= 0 {
+		return 0
+	}
+	return int(math.Ceil(grams / 1000000 * float64(pricePerTonne)))
+}
@@ -1,12 +1,18 @@
 package config
 
 import (
+	"encoding/json"
 	"github.com/joho/godotenv" // Import godotenv
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"log"
+	"myproject/geo"
+	"myproject/helper"
 	"myproject/model"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type DatabaseConfig struct {
@@ -36,6 +42,7 @@ func InitializeDatabase() (*gorm.DB, error) {
 	}
 
 	db.AutoMigrate(&model.User{})
+	db.AutoMigrate(&model.Facility{})
 	db.AutoMigrate(&model.Wisata{})
 	db.AutoMigrate(&model.Ticket{})
 	db.AutoMigrate(&model.Promo{})
@@ -43,6 +50,132 @@ func InitializeDatabase() (*gorm.DB, error) {
 	db.AutoMigrate(&model.TermCondition{})
 	db.AutoMigrate(&model.CooperationMessage{})
 	db.AutoMigrate(&model.Notification{})
+	db.AutoMigrate(&model.Referral{})
+	db.AutoMigrate(&model.Setting{})
+	db.AutoMigrate(&model.CarbonOffset{})
+	db.AutoMigrate(&model.UserBadge{})
+	db.AutoMigrate(&model.Review{})
+	db.AutoMigrate(&model.OpeningHour{})
+	db.AutoMigrate(&model.WisataClosure{})
+	db.AutoMigrate(&model.Media{})
+	db.AutoMigrate(&model.Favorite{})
+	db.AutoMigrate(&model.CoBooking{})
+	db.AutoMigrate(&model.Holiday{})
+	db.AutoMigrate(&model.Itinerary{})
+	db.AutoMigrate(&model.ItineraryStop{})
+	db.AutoMigrate(&model.WisataTranslation{})
+	db.AutoMigrate(&model.PromoTranslation{})
+	db.AutoMigrate(&model.CategoryTranslation{})
+	db.AutoMigrate(&model.TermConditionTranslation{})
+	db.AutoMigrate(&model.UserCategoryPreference{})
+	db.AutoMigrate(&model.UserPreferenceQuiz{})
+	db.AutoMigrate(&model.DataMigration{})
+
+	// Mengisi geohash untuk tempat wisata yang dibuat sebelum pencarian lokasi tersedia
+	runDataMigration(db, "backfill_wisata_geohash", func(tx *gorm.DB) error {
+		var wisatas []model.Wisata
+		if err := tx.Unscoped().Where("geohash = ? OR geohash IS NULL", "").Find(&wisatas).Error; err != nil {
+			return err
+		}
+		for _, wisata := range wisatas {
+			if err := tx.Unscoped().Model(&model.Wisata{}).Where("id = ?", wisata.ID).Update("geohash", geo.Encode(wisata.Lat, wisata.Long, geo.GeohashPrecision)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	// Memindahkan PhotoWisata1..3 dan VideoLink ke tabel media untuk wisata yang belum memiliki galeri
+	runDataMigration(db, "backfill_wisata_media", func(tx *gorm.DB) error {
+		var wisatasWithoutMedia []model.Wisata
+		if err := tx.Unscoped().Where("NOT EXISTS (SELECT 1 FROM media WHERE media.wisata_id = wisata.id)").Find(&wisatasWithoutMedia).Error; err != nil {
+			return err
+		}
+		for _, wisata := range wisatasWithoutMedia {
+			var media []model.Media
+			for _, photo := range []string{wisata.PhotoWisata1, wisata.PhotoWisata2, wisata.PhotoWisata3} {
+				if photo != "" {
+					media = append(media, model.Media{WisataID: wisata.ID, Type: "photo", URL: photo, ObjectName: helper.GCSObjectName(photo), IsCover: len(media) == 0, SortOrder: len(media)})
+				}
+			}
+			if wisata.VideoLink != "" {
+				media = append(media, model.Media{WisataID: wisata.ID, Type: "video", URL: wisata.VideoLink, SortOrder: len(media)})
+			}
+			if len(media) == 0 {
+				continue
+			}
+			if err := tx.Create(&media).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	// Memindahkan isi kolom Fasilitas (JSON) ke katalog fasilitas untuk wisata yang belum memiliki relasi fasilitas
+	runDataMigration(db, "backfill_wisata_facilities", func(tx *gorm.DB) error {
+		var wisatasWithoutFacilities []model.Wisata
+		if err := tx.Unscoped().Where("fasilitas <> ? AND NOT EXISTS (SELECT 1 FROM wisata_facilities WHERE wisata_facilities.wisata_id = wisata.id)", "").Find(&wisatasWithoutFacilities).Error; err != nil {
+			return err
+		}
+		for _, wisata := range wisatasWithoutFacilities {
+			var names []string
+			if err := json.Unmarshal([]byte(wisata.Fasilitas), &names); err != nil {
+				continue
+			}
+			var facilities []model.Facility
+			for _, name := range names {
+				name = strings.TrimSpace(strings.Trim(name, "\""))
+				if name == "" {
+					continue
+				}
+				var facility model.Facility
+				if err := tx.Where("LOWER(name) = ?", strings.ToLower(name)).FirstOrCreate(&facility, model.Facility{Name: name}).Error; err != nil {
+					return err
+				}
+				facilities = append(facilities, facility)
+			}
+			if len(facilities) == 0 {
+				continue
+			}
+			if err := tx.Model(&wisata).Association("Facilities").Append(facilities); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	// Memindahkan kategori kesukaan tunggal ke tabel preferensi kategori untuk user yang belum memiliki preferensi
+	runDataMigration(db, "backfill_user_category_preferences", func(tx *gorm.DB) error {
+		var usersWithoutPreferences []model.User
+		if err := tx.Unscoped().Where("category_id <> 0 AND NOT EXISTS (SELECT 1 FROM user_category_preferences WHERE user_category_preferences.user_id = users.id)").Find(&usersWithoutPreferences).Error; err != nil {
+			return err
+		}
+		for _, user := range usersWithoutPreferences {
+			if err := tx.Create(&model.UserCategoryPreference{UserID: user.ID, CategoryID: user.CategoryID, Weight: 1, Source: "profile"}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 
 	return db, nil
 }
+
+// runDataMigration menjalankan backfill data sekali saja dan menandainya di tabel data_migrations.
+// Penanda dibuat di awal transaksi, sehingga instance lain yang mulai bersamaan tertahan lalu gagal pada primary key yang sama.
+func runDataMigration(db *gorm.DB, name string, backfill func(tx *gorm.DB) error) {
+	var count int64
+	if db.Model(&model.DataMigration{}).Where("name = ?", name).Count(&count); count > 0 {
+		return
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&model.DataMigration{Name: name, AppliedAt: time.Now()}).Error; err != nil {
+			return err
+		}
+		return backfill(tx)
+	})
+	if err != nil {
+		log.Println("Gagal menjalankan migrasi data", name+":", err)
+	}
+}
@@ -0,0 +1,205 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"log"
+	"myproject/model"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Lama data terarsip disimpan sebelum dihapus permanen, dapat diubah lewat env ARCHIVE_RETENTION_DAYS
+const defaultArchiveRetentionDays = 30
+
+// Interval job penghapusan permanen data terarsip
+const archivePurgeInterval = 24 * time.Hour
+
+// archivedFilter membatasi daftar admin sesuai parameter archived: true hanya data terarsip, all seluruh data, selain itu hanya data aktif
+func archivedFilter(c echo.Context, query *gorm.DB, table string) *gorm.DB {
+	switch c.QueryParam("archived") {
+	case "true":
+		return query.Unscoped().Where(table + ".deleted_at IS NOT NULL")
+	case "all":
+		return query.Unscoped()
+	}
+	return query
+}
+
+// withArchived dipakai pada Preload agar tampilan riwayat tetap memuat data yang sudah diarsipkan
+func withArchived(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+// archivedAt mengembalikan waktu pengarsipan atau nil jika data masih aktif
+func archivedAt(deletedAt gorm.DeletedAt) *time.Time {
+	if !deletedAt.Valid {
+		return nil
+	}
+	return &deletedAt.Time
+}
+
+func archiveRetention() time.Duration {
+	days, err := strconv.Atoi(os.Getenv("ARCHIVE_RETENTION_DAYS"))
+	if err != nil || days <= 0 {
+		days = defaultArchiveRetentionDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// PurgeArchived menghapus permanen tiket, promo, tempat wisata dan user yang diarsipkan sebelum batas waktu.
+// Tiket yang masih dirujuk review atau offset karbon, serta tempat wisata dan user yang masih dirujuk tiket, review,
+// offset karbon atau referral tetap disimpan agar riwayat dan laporan tetap utuh.
+func PurgeArchived(db *gorm.DB, before time.Time) error {
+	var ticketIDs []uint
+	if err := db.Unscoped().Model(&model.Ticket{}).
+		Where("deleted_at < ?", before).
+		Where("NOT EXISTS (SELECT 1 FROM reviews WHERE reviews.ticket_id = tickets.id)").
+		Where("NOT EXISTS (SELECT 1 FROM carbon_offsets WHERE carbon_offsets.ticket_id = tickets.id)").
+		Pluck("id", &ticketIDs).Error; err != nil {
+		return err
+	}
+	if len(ticketIDs) > 0 {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			// Kunjungan itinerary tetap ada, hanya tautan ke tiketnya yang dilepas
+			if err := tx.Model(&model.ItineraryStop{}).Where("ticket_id IN ?", ticketIDs).Update("ticket_id", nil).Error; err != nil {
+				return err
+			}
+			return tx.Unscoped().Delete(&model.Ticket{}, ticketIDs).Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	var promoIDs []uint
+	if err := db.Unscoped().Model(&model.Promo{}).Where("deleted_at < ?", before).Pluck("id", &promoIDs).Error; err != nil {
+		return err
+	}
+	if len(promoIDs) > 0 {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("promo_id IN ?", promoIDs).Delete(&model.PromoTranslation{}).Error; err != nil {
+				return err
+			}
+			return tx.Unscoped().Delete(&model.Promo{}, promoIDs).Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	var wisatas []model.Wisata
+	if err := db.Unscoped().
+		Where("deleted_at < ?", before).
+		Where("NOT EXISTS (SELECT 1 FROM tickets WHERE tickets.wisata_id = wisata.id)").
+		Where("NOT EXISTS (SELECT 1 FROM reviews WHERE reviews.wisata_id = wisata.id)").
+		Where("NOT EXISTS (SELECT 1 FROM carbon_offsets WHERE carbon_offsets.wisata_id = wisata.id)").
+		Find(&wisatas).Error; err != nil {
+		return err
+	}
+	for _, wisata := range wisatas {
+		if err := purgeWisata(db, wisata); err != nil {
+			return err
+		}
+	}
+
+	var userIDs []uint
+	if err := db.Unscoped().Model(&model.User{}).
+		Where("deleted_at < ?", before).
+		Where("NOT EXISTS (SELECT 1 FROM tickets WHERE tickets.user_id = users.id)").
+		Where("NOT EXISTS (SELECT 1 FROM reviews WHERE reviews.user_id = users.id)").
+		Where("NOT EXISTS (SELECT 1 FROM carbon_offsets WHERE carbon_offsets.user_id = users.id)").
+		Where("NOT EXISTS (SELECT 1 FROM referrals WHERE referrals.referrer_id = users.id OR referrals.referee_id = users.id)").
+		Pluck("id", &userIDs).Error; err != nil {
+		return err
+	}
+	for _, userID := range userIDs {
+		if err := purgeUser(db, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// purgeWisata menghapus permanen tempat wisata beserta galeri (termasuk file di GCS), fasilitas, favorit, jadwal dan terjemahannya
+func purgeWisata(db *gorm.DB, wisata model.Wisata) error {
+	var media []model.Media
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("wisata_id = ?", wisata.ID).Find(&media).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("wisata_id = ?", wisata.ID).Delete(&model.Media{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM wisata_facilities WHERE wisata_id = ?", wisata.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("wisata_id = ?", wisata.ID).Delete(&model.Favorite{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("wisata_id = ?", wisata.ID).Delete(&model.WisataTranslation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("wisata_id = ?", wisata.ID).Delete(&model.OpeningHour{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("wisata_id = ?", wisata.ID).Delete(&model.WisataClosure{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("wisata_id = ? OR related_wisata_id = ?", wisata.ID, wisata.ID).Delete(&model.CoBooking{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("wisata_id = ?", wisata.ID).Delete(&model.ItineraryStop{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&wisata).Error
+	})
+	if err != nil {
+		return err
+	}
+	deleteMediaObjects(media)
+	return nil
+}
+
+// purgeUser menghapus permanen user beserta favorit, notifikasi, badge, itinerary dan preferensinya
+func purgeUser(db *gorm.DB, userID uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&model.Favorite{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&model.Notification{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&model.UserBadge{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("itinerary_id IN (SELECT id FROM itineraries WHERE user_id = ?)", userID).Delete(&model.ItineraryStop{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&model.Itinerary{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&model.UserCategoryPreference{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&model.UserPreferenceQuiz{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&model.User{}, userID).Error
+	})
+}
+
+// StartArchivePurgeJob menghapus permanen data terarsip yang melewati masa retensi saat server mulai lalu setiap hari
+func StartArchivePurgeJob(db *gorm.DB) {
+	go func() {
+		ticker := time.NewTicker(archivePurgeInterval)
+		defer ticker.Stop()
+		for {
+			if err := PurgeArchived(db, time.Now().Add(-archiveRetention())); err != nil {
+				log.Println("Gagal menghapus permanen data terarsip:", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
@@ -1,10 +1,11 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
 	"github.com/labstack/echo/v4"
 	"gorm.io/gorm"
-	"math"
+	"myproject/carbon"
 	"myproject/helper"
 	"myproject/middleware"
 	"myproject/model"
@@ -12,39 +13,13 @@ import (
 	"time"
 )
 
-func CalculateCarbonFootprint(user model.User, wisata model.Wisata) float64 {
-	earthRadius := 6371.0
-	userLat := user.Lat
-	userLong := user.Long
-	wisataLat := wisata.Lat
-	wisataLong := wisata.Long
+// errVoucherAlreadyUsed dikembalikan saat voucher sekali pakai sudah dipakai oleh transaksi lain
+var errVoucherAlreadyUsed = helper.NewMessageError(helper.MsgVoucherAlreadyUsed)
 
-	if wisataLat < 0 {
-		wisataLat = -wisataLat
-	}
-	if wisataLong < 0 {
-		wisataLong = -wisataLong
-	}
-	if userLat < 0 {
-		userLat = -userLat
-	}
-	if userLong < 0 {
-		userLong = -userLong
-	}
-
-	userLatRad := userLat * (math.Pi / 180.0)
-	userLongRad := userLong * (math.Pi / 180.0)
-	wisataLatRad := wisataLat * (math.Pi / 180.0)
-	wisataLongRad := wisataLong * (math.Pi / 180.0)
-
-	dLat := wisataLatRad - userLatRad
-	dLong := wisataLongRad - userLongRad
-	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(userLatRad)*math.Cos(wisataLatRad)*math.Sin(dLong/2)*math.Sin(dLong/2)
-	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
-	distance := earthRadius * c
-
-	carbonFootprint := distance * 14.8
-	return carbonFootprint
+// CalculateCarbonFootprint menghitung emisi perjalanan user menuju wisata sesuai moda transportasi yang dipilih
+func CalculateCarbonFootprint(user model.User, wisata model.Wisata, mode carbon.TransportMode) (carbon.Footprint, error) {
+	distance := carbon.Distance(user.Lat, user.Long, wisata.Lat, wisata.Long)
+	return carbon.FactorsFromEnv().Calculate(distance, mode)
 }
 
 func BuyTicket(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
@@ -53,7 +28,7 @@ func BuyTicket(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var user model.User
 		result := db.Where("username = ?", username).First(&user)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch user data"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -64,6 +39,8 @@ func BuyTicket(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			UsedPoints     int    `json:"used_points"`
 			Quantity       int    `json:"quantity"`
 			CheckinBooking string `json:"checkin_booking"`
+			TransportMode  string `json:"transport_mode"`
+			OffsetCarbon   bool   `json:"offset_carbon"`
 		}
 
 		if err := c.Bind(&ticketPurchase); err != nil {
@@ -74,23 +51,34 @@ func BuyTicket(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var wisata model.Wisata
 		wisataResult := db.First(&wisata, ticketPurchase.WisataID)
 		if wisataResult.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Wisata not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgWisataNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
 		if ticketPurchase.Quantity <= 0 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Quantity must be greater than 0"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgQuantityPositive}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		transportMode, err := carbon.ParseMode(ticketPurchase.TransportMode)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidTransportMode}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		checkinBookingTime, err := time.Parse("2006-01-02", ticketPurchase.CheckinBooking)
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid checkin_booking date format"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidCheckinDate}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if checkinBookingTime.Before(time.Now().Truncate(24 * time.Hour)) {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Checkin date must be today or later"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgCheckinDatePast}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if loadOpeningSchedule(db, wisata).IsClosedOn(checkinBookingTime) {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgWisataClosedOnDate}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
@@ -103,9 +91,15 @@ func BuyTicket(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var hargaSebelumDiskon int
 		hargaSebelumDiskon = totalCost
 
+		var singleUsePromoID uint
 		if ticketPurchase.KodeVoucher != "" {
 			var promo model.Promo
-			promoResult := db.Where("kode_voucher = ?", ticketPurchase.KodeVoucher).First(&promo)
+			// Voucher pribadi milik user lain dianggap tidak valid
+			promoResult := db.Where("kode_voucher = ? AND (owner_user_id IS NULL OR owner_user_id = ?)", ticketPurchase.KodeVoucher, user.ID).First(&promo)
+			if promoResult.Error == nil && promo.SingleUse && promo.UsedAt != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgVoucherAlreadyUsed}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
 			if promoResult.Error == nil {
 				currentTime := time.Now()
 				if promo.StatusAktif && currentTime.Before(promo.TanggalKadaluarsa) {
@@ -116,20 +110,23 @@ func BuyTicket(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 						totalPotonganKodeVoucher += discount
 					}
 					pointsEarned = 0
+					if promo.SingleUse {
+						singleUsePromoID = promo.ID
+					}
 				} else {
 					if !promo.StatusAktif {
-						errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Voucher belum aktif"}
+						errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgVoucherInactive}
 						return c.JSON(http.StatusBadRequest, errorResponse)
 					} else if currentTime.After(promo.TanggalKadaluarsa) {
-						errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Voucher sudah expired"}
+						errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgVoucherExpired}
 						return c.JSON(http.StatusBadRequest, errorResponse)
 					} else {
-						errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid kode voucher"}
+						errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidVoucherCode}
 						return c.JSON(http.StatusBadRequest, errorResponse)
 					}
 				}
 			} else {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid kode voucher"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidVoucherCode}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 		}
@@ -152,7 +149,7 @@ func BuyTicket(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			user.Points -= usedPoints
 
 			if err := db.Save(&user).Error; err != nil {
-				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update user points"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserPointsUpdateFailed}
 				return c.JSON(http.StatusInternalServerError, errorResponse)
 			}
 		} else {
@@ -162,23 +159,36 @@ func BuyTicket(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 				totalCost -= additionalDiscount
 				totalPotonganPoints += additionalDiscount
 			} else {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Not enough points to use"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgPointsNotEnough}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 		}
 
 		if wisata.AvailableTickets < ticketPurchase.Quantity {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Not enough available tickets"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgTicketsNotEnough}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		wisata.AvailableTickets -= ticketPurchase.Quantity
 		if err := db.Save(&wisata).Error; err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update available tickets"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgAvailableTicketsUpdateFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		footprint, err := CalculateCarbonFootprint(user, wisata, transportMode)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCarbonFootprintFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
+		carbonFootprint := footprint.CarbonGrams
 
-		carbonFootprint := CalculateCarbonFootprint(user, wisata)
+		// Menambahkan biaya offset karbon ke total jika user memilih add-on offset
+		var carbonOffsetAmount int
+		pricePerTonne := getCarbonOffsetPrice(db)
+		if ticketPurchase.OffsetCarbon {
+			carbonOffsetAmount = carbon.OffsetCost(carbonFootprint, pricePerTonne)
+			totalCost += carbonOffsetAmount
+		}
 
 		tenggatPembayaran := checkinBookingTime
 
@@ -194,6 +204,10 @@ func BuyTicket(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			PaidStatus:               false,
 			PointsEarned:             pointsEarned,
 			CarbonFootprint:          carbonFootprint,
+			TransportMode:            string(footprint.Mode),
+			EmissionFactor:           footprint.EmissionFactor,
+			TravelDistance:           footprint.DistanceKm,
+			CarbonOffsetAmount:       carbonOffsetAmount,
 			StatusOrder:              "pending", // Set nilai default
 			TenggatPembayaran:        &tenggatPembayaran,
 			TotalPotonganKodeVoucher: totalPotonganKodeVoucher,
@@ -203,14 +217,52 @@ func BuyTicket(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			UseAllPoints:             ticketPurchase.UseAllPoints,
 		}
 
-		if err := db.Create(&ticket).Error; err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to create ticket"}
+		// Tiket dan catatan offset karbonnya dibuat dalam satu transaksi agar tidak ada tiket tanpa offset yang sudah ditagihkan
+		errorCode := helper.MsgTicketCreateFailed
+		err = db.Transaction(func(tx *gorm.DB) error {
+			// Voucher sekali pakai ditandai terpakai secara atomik agar tidak dapat dipakai dua kali bersamaan
+			if singleUsePromoID != 0 {
+				result := tx.Model(&model.Promo{}).Where("id = ? AND used_at IS NULL", singleUsePromoID).Update("used_at", time.Now())
+				if result.Error != nil {
+					return result.Error
+				}
+				if result.RowsAffected == 0 {
+					return errVoucherAlreadyUsed
+				}
+			}
+
+			if err := tx.Create(&ticket).Error; err != nil {
+				return err
+			}
+			if !ticketPurchase.OffsetCarbon {
+				return nil
+			}
+
+			errorCode = helper.MsgCarbonOffsetCreateFailed
+			carbonOffset := model.CarbonOffset{
+				UserID:        user.ID,
+				WisataID:      wisata.ID,
+				TicketID:      ticket.ID,
+				InvoiceNumber: ticket.InvoiceNumber,
+				OffsetGrams:   carbonFootprint,
+				PricePerTonne: pricePerTonne,
+				Amount:        carbonOffsetAmount,
+				Status:        "pending",
+			}
+			return tx.Create(&carbonOffset).Error
+		})
+		if errors.Is(err, errVoucherAlreadyUsed) {
+			return c.JSON(http.StatusBadRequest, helper.NewErrorResponse(http.StatusBadRequest, err))
+		}
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: errorCode}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
-		emailSubject := helper.GetEmailSubject(ticket)
+		locales := helper.RequestLocales(c)
+		emailSubject := helper.GetEmailSubject(locales, ticket)
 		wisataName := wisata.Title
-		emailBody := helper.GetEmailBody(ticket, totalCost, wisataName, ticketPurchase.KodeVoucher, pointsEarned, usedPoints, carbonFootprint)
+		emailBody := helper.GetEmailBody(locales, ticket, totalCost, wisataName, ticketPurchase.KodeVoucher, pointsEarned, usedPoints, carbonFootprint)
 
 		go func(email, subject, body string) {
 			if err := helper.SendEmailToUser(email, subject, body); err != nil {
@@ -236,7 +288,7 @@ func BuyTicket(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			user.Points += pointsEarned
 
 			if err := db.Save(&user).Error; err != nil {
-				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update user points"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserPointsUpdateFailed}
 				return c.JSON(http.StatusInternalServerError, errorResponse)
 			}
 		}
@@ -262,6 +314,10 @@ func BuyTicket(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			"points_earned":               pointsEarned,
 			"used_points":                 usedPoints,
 			"carbon_footprint":            carbonFootprint,
+			"transport_mode":              footprint.Mode,
+			"emission_factor":             footprint.EmissionFactor,
+			"travel_distance":             footprint.DistanceKm,
+			"carbon_offset_amount":        carbonOffsetAmount,
 			"point_message":               pointMessage,
 			"user":                        userData,
 			"wisata":                      wisataData,
@@ -293,7 +349,7 @@ func CancelTicket(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var user model.User
 		result := db.Where("username = ?", username).First(&user)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch user data"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -302,27 +358,37 @@ func CancelTicket(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var ticket model.Ticket
 		ticketResult := db.Where("user_id = ? AND invoice_number = ?", user.ID, invoiceNumber).First(&ticket)
 		if ticketResult.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Ticket not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgTicketNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
 		if ticket.StatusOrder == "dibatalkan" {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Ticket has already been canceled"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgTicketAlreadyCanceled}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if ticket.StatusOrder != "pending" {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Cannot cancel ticket with current status"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgTicketNotCancellable}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		user.Points += ticket.UsedPointsOnPurchase
 		db.Save(&user)
 
+		// Voucher sekali pakai dapat dipakai kembali setelah tiketnya dibatalkan
+		if ticket.KodeVoucher != "" {
+			db.Model(&model.Promo{}).Where("kode_voucher = ? AND single_use = ?", ticket.KodeVoucher, true).Update("used_at", nil)
+		}
+
 		ticket.StatusOrder = "dibatalkan"
 
 		if err := db.Save(&ticket).Error; err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to cancel ticket"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgTicketCancelFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		if err := updateCarbonOffsetStatus(db, ticket.ID, "dibatalkan"); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCarbonOffsetCancelFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -341,7 +407,7 @@ func CheckTicketPrice(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var user model.User
 		result := db.Where("username = ?", username).First(&user)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch user data"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -352,6 +418,8 @@ func CheckTicketPrice(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			UsedPoints     int    `json:"used_points"`
 			Quantity       int    `json:"quantity"`
 			CheckinBooking string `json:"checkin_booking"`
+			TransportMode  string `json:"transport_mode"`
+			OffsetCarbon   bool   `json:"offset_carbon"`
 		}
 
 		if err := c.Bind(&ticketPurchase); err != nil {
@@ -362,23 +430,34 @@ func CheckTicketPrice(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var wisata model.Wisata
 		wisataResult := db.First(&wisata, ticketPurchase.WisataID)
 		if wisataResult.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Wisata not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgWisataNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
 		if ticketPurchase.Quantity <= 0 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Quantity must be greater than 0"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgQuantityPositive}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		transportMode, err := carbon.ParseMode(ticketPurchase.TransportMode)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidTransportMode}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		checkinBookingTime, err := time.Parse("2006-01-02", ticketPurchase.CheckinBooking)
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid checkin_booking date format"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidCheckinDate}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if checkinBookingTime.Before(time.Now().Truncate(24 * time.Hour)) {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Checkin date must be today or later"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgCheckinDatePast}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if loadOpeningSchedule(db, wisata).IsClosedOn(checkinBookingTime) {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgWisataClosedOnDate}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
@@ -392,7 +471,12 @@ func CheckTicketPrice(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		if ticketPurchase.KodeVoucher != "" {
 			var promo model.Promo
-			promoResult := db.Where("kode_voucher = ?", ticketPurchase.KodeVoucher).First(&promo)
+			// Voucher pribadi milik user lain dianggap tidak valid
+			promoResult := db.Where("kode_voucher = ? AND (owner_user_id IS NULL OR owner_user_id = ?)", ticketPurchase.KodeVoucher, user.ID).First(&promo)
+			if promoResult.Error == nil && promo.SingleUse && promo.UsedAt != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgVoucherAlreadyUsed}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
 			if promoResult.Error == nil {
 				currentTime := time.Now()
 				if promo.StatusAktif && currentTime.Before(promo.TanggalKadaluarsa) {
@@ -405,18 +489,18 @@ func CheckTicketPrice(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 					pointsEarned = 0
 				} else {
 					if !promo.StatusAktif {
-						errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Voucher belum aktif"}
+						errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgVoucherInactive}
 						return c.JSON(http.StatusBadRequest, errorResponse)
 					} else if currentTime.After(promo.TanggalKadaluarsa) {
-						errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Voucher sudah expired"}
+						errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgVoucherExpired}
 						return c.JSON(http.StatusBadRequest, errorResponse)
 					} else {
-						errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid kode voucher"}
+						errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidVoucherCode}
 						return c.JSON(http.StatusBadRequest, errorResponse)
 					}
 				}
 			} else {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid kode voucher"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidVoucherCode}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 		}
@@ -441,17 +525,37 @@ func CheckTicketPrice(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 				totalCost -= additionalDiscount
 				totalPotonganPoints += additionalDiscount
 			} else {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Not enough points to use"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgPointsNotEnough}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 		}
 
 		if wisata.AvailableTickets < ticketPurchase.Quantity {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Not enough available tickets"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgTicketsNotEnough}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
-		carbonFootprint := CalculateCarbonFootprint(user, wisata)
+		footprint, err := CalculateCarbonFootprint(user, wisata, transportMode)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCarbonFootprintFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		carbonFootprint := footprint.CarbonGrams
+
+		// Menambahkan biaya offset karbon ke total jika user memilih add-on offset
+		var carbonOffsetAmount int
+		pricePerTonne := getCarbonOffsetPrice(db)
+		if ticketPurchase.OffsetCarbon {
+			carbonOffsetAmount = carbon.OffsetCost(carbonFootprint, pricePerTonne)
+			totalCost += carbonOffsetAmount
+		}
+
+		crowdForecasts, err := forecastWisataCrowd(db, wisata, []time.Time{checkinBookingTime})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCrowdForecastFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		crowdForecast := crowdForecasts[checkinBookingTime.Format("2006-01-02")]
 
 		pointMessage := "Points earned"
 		if pointsEarned == 0 && ticketPurchase.KodeVoucher != "" {
@@ -467,10 +571,16 @@ func CheckTicketPrice(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			"points_earned":               pointsEarned,
 			"used_points":                 usedPoints,
 			"carbon_footprint":            carbonFootprint,
+			"transport_mode":              footprint.Mode,
+			"emission_factor":             footprint.EmissionFactor,
+			"travel_distance":             footprint.DistanceKm,
+			"carbon_offset_amount":        carbonOffsetAmount,
 			"point_message":               pointMessage,
 			"quantity":                    ticketPurchase.Quantity,
 			"total_potongan_kode_voucher": totalPotonganKodeVoucher,
 			"total_potongan_points":       totalPotonganPoints,
+			"crowd_level":                 crowdForecast.Level,
+			"crowd_forecast":              crowdForecast,
 		}
 
 		response := map[string]interface{}{
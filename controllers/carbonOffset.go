@@ -0,0 +1,215 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"myproject/helper"
+	"myproject/middleware"
+	"myproject/model"
+	"net/http"
+	"strconv"
+)
+
+const carbonOffsetPriceKey = "carbon_offset_price_per_tonne"
+
+// getCarbonOffsetPrice mengambil harga offset per ton CO2 dari pengaturan admin, atau dari env jika belum diatur
+func getCarbonOffsetPrice(db *gorm.DB) int {
+	var setting model.Setting
+	if err := db.Where("`key` = ?", carbonOffsetPriceKey).First(&setting).Error; err == nil {
+		if price, err := strconv.Atoi(setting.Value); err == nil {
+			return price
+		}
+	}
+	return helper.GetEnvInt("CARBON_OFFSET_PRICE_PER_TONNE", 60000)
+}
+
+// updateCarbonOffsetStatus menyamakan status ledger offset dengan status pembayaran tiket
+func updateCarbonOffsetStatus(db *gorm.DB, ticketID uint, status string) error {
+	return db.Model(&model.CarbonOffset{}).Where("ticket_id = ?", ticketID).Update("status", status).Error
+}
+
+func GetCarbonOffsetSettingByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":            http.StatusOK,
+			"error":           false,
+			"price_per_tonne": getCarbonOffsetPrice(db),
+		})
+	}
+}
+
+func UpdateCarbonOffsetSettingByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		var requestBody struct {
+			PricePerTonne int `json:"price_per_tonne"`
+		}
+		if err := c.Bind(&requestBody); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidRequestBody}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if requestBody.PricePerTonne <= 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgPricePerTonnePositive}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		setting := model.Setting{Key: carbonOffsetPriceKey}
+		db.Where("`key` = ?", carbonOffsetPriceKey).First(&setting)
+		setting.Value = strconv.Itoa(requestBody.PricePerTonne)
+		if err := db.Save(&setting).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCarbonOffsetPriceUpdateFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":            http.StatusOK,
+			"error":           false,
+			"message":         "Carbon offset price updated successfully",
+			"price_per_tonne": requestBody.PricePerTonne,
+		})
+	}
+}
+
+func GetUserCarbonOffsets(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var offsets []model.CarbonOffset
+		if err := db.Where("user_id = ?", user.ID).Order("created_at desc").Find(&offsets).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCarbonOffsetsFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var offsetDetails []map[string]interface{}
+		var totalOffsetGrams float64
+		var totalAmount int
+		for _, offset := range offsets {
+			var wisata model.Wisata
+			db.Unscoped().First(&wisata, offset.WisataID)
+
+			if offset.Status == "paid" {
+				totalOffsetGrams += offset.OffsetGrams
+				totalAmount += offset.Amount
+			}
+
+			offsetDetails = append(offsetDetails, map[string]interface{}{
+				"id":              offset.ID,
+				"wisata_id":       offset.WisataID,
+				"wisata_name":     wisata.Title,
+				"invoice_number":  offset.InvoiceNumber,
+				"offset_grams":    offset.OffsetGrams,
+				"price_per_tonne": offset.PricePerTonne,
+				"amount":          offset.Amount,
+				"status":          offset.Status,
+				"created_at":      offset.CreatedAt,
+			})
+		}
+
+		if offsetDetails == nil {
+			offsetDetails = []map[string]interface{}{}
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":               http.StatusOK,
+			"error":              false,
+			"message":            "User's carbon offsets retrieved successfully",
+			"total_offset_grams": totalOffsetGrams,
+			"total_amount":       totalAmount,
+			"offsets":            offsetDetails,
+		})
+	}
+}
+
+func GetCarbonOffsetsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		wisataID := c.QueryParam("wisata_id")
+		userID := c.QueryParam("user_id")
+		page, perPage := helper.GetPaginationParams(c)
+
+		query := db.Model(&model.CarbonOffset{}).Order("created_at DESC")
+		if wisataID != "" {
+			query = query.Where("wisata_id = ?", wisataID)
+		}
+		if userID != "" {
+			query = query.Where("user_id = ?", userID)
+		}
+
+		var totalOffsets int64
+		query.Count(&totalOffsets)
+
+		var totalPages int
+		if perPage > 0 {
+			totalPages = int((totalOffsets + int64(perPage) - 1) / int64(perPage))
+		} else {
+			totalPages = 0
+		}
+
+		var offsets []model.CarbonOffset
+		query.Offset((page - 1) * perPage).Limit(perPage).Find(&offsets)
+
+		if offsets == nil {
+			offsets = []model.CarbonOffset{}
+		}
+
+		type WisataOffsetSummary struct {
+			WisataID    uint    `json:"wisata_id"`
+			Title       string  `json:"wisata_title"`
+			OffsetGrams float64 `json:"offset_grams"`
+			Amount      int     `json:"amount"`
+			TotalOrders int64   `json:"total_orders"`
+		}
+		var perWisata []WisataOffsetSummary
+		if err := db.Model(&model.CarbonOffset{}).
+			Select("carbon_offsets.wisata_id AS wisata_id, wisata.title AS title, SUM(carbon_offsets.offset_grams) AS offset_grams, SUM(carbon_offsets.amount) AS amount, COUNT(carbon_offsets.id) AS total_orders").
+			Joins("JOIN wisata ON wisata.id = carbon_offsets.wisata_id").
+			Where("carbon_offsets.status = ?", "paid").
+			Group("carbon_offsets.wisata_id, wisata.title").
+			Order("offset_grams desc").
+			Scan(&perWisata).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCarbonOffsetSummaryFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		if perWisata == nil {
+			perWisata = []WisataOffsetSummary{}
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":            http.StatusOK,
+			"error":           false,
+			"offsets":         offsets,
+			"per_wisata":      perWisata,
+			"price_per_tonne": getCarbonOffsetPrice(db),
+			"pagination": map[string]interface{}{
+				"current_page": page,
+				"from":         (page-1)*perPage + 1,
+				"last_page":    totalPages,
+				"per_page":     perPage,
+				"to":           (page-1)*perPage + len(offsets),
+				"total":        totalOffsets,
+			},
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"gorm.io/gorm"
+	"myproject/model"
+	"strings"
+)
+
+// categoryOrder adalah urutan tampil kategori pada menu dan pohon kategori
+const categoryOrder = "sort_order asc, category_name asc"
+
+// CategoryNode adalah kategori beserta subkategorinya pada respons pohon kategori
+type CategoryNode struct {
+	model.Category
+	Children []CategoryNode `json:"children"`
+}
+
+// buildCategoryTree menyusun kategori (sudah terurut) menjadi pohon, subkategori yang induknya tidak ikut dimuat dibuang
+func buildCategoryTree(categories []model.Category) []CategoryNode {
+	children := make(map[uint][]model.Category)
+	var roots []model.Category
+	for _, category := range categories {
+		if category.ParentID == nil {
+			roots = append(roots, category)
+		} else {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+		}
+	}
+
+	var build func(categories []model.Category) []CategoryNode
+	build = func(categories []model.Category) []CategoryNode {
+		nodes := make([]CategoryNode, 0, len(categories))
+		for _, category := range categories {
+			nodes = append(nodes, CategoryNode{Category: category, Children: build(children[category.ID])})
+		}
+		return nodes
+	}
+	return build(roots)
+}
+
+// categoryTreeMatches memeriksa apakah nama kategori atau salah satu subkategorinya mengandung kata kunci
+func categoryTreeMatches(node CategoryNode, keyword string) bool {
+	if strings.Contains(strings.ToLower(node.CategoryName), strings.ToLower(keyword)) {
+		return true
+	}
+	for _, child := range node.Children {
+		if categoryTreeMatches(child, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// categoryDescendantIDs mengembalikan ID kategori beserta seluruh subkategorinya
+func categoryDescendantIDs(db *gorm.DB, categoryIDs []uint) []uint {
+	if len(categoryIDs) == 0 {
+		return categoryIDs
+	}
+
+	var categories []model.Category
+	db.Select("id, parent_id").Where("parent_id IS NOT NULL").Find(&categories)
+	children := make(map[uint][]uint)
+	for _, category := range categories {
+		children[*category.ParentID] = append(children[*category.ParentID], category.ID)
+	}
+
+	seen := make(map[uint]bool)
+	var result []uint
+	queue := append([]uint{}, categoryIDs...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+		queue = append(queue, children[id]...)
+	}
+	return result
+}
+
+// validCategoryParent memeriksa kategori induk ada dan bukan kategori itu sendiri atau subkategorinya
+func validCategoryParent(db *gorm.DB, categoryID, parentID uint) bool {
+	var count int64
+	if db.Model(&model.Category{}).Where("id = ?", parentID).Count(&count); count == 0 {
+		return false
+	}
+	if categoryID == 0 {
+		return true
+	}
+	for _, id := range categoryDescendantIDs(db, []uint{categoryID}) {
+		if id == parentID {
+			return false
+		}
+	}
+	return true
+}
@@ -80,7 +80,7 @@ func (uc *promoChatbotUsecase) generateNewPromoRecommendation(userInput, openAIK
 
 func (uc *promoChatbotUsecase) getPromoRecommendation(db *gorm.DB) (string, error) {
 	var promos []model.Promo
-	err := db.Where("status_aktif = ? AND tanggal_kadaluarsa > ?", true, time.Now()).Find(&promos).Error
+	err := db.Where("status_aktif = ? AND tanggal_kadaluarsa > ? AND owner_user_id IS NULL", true, time.Now()).Find(&promos).Error
 	if err != nil {
 		return "", err
 	}
@@ -128,19 +128,19 @@ func RecommendPromoChatbot(c echo.Context, promoChatbotUsecase PromoChatbotUseca
 
 	username, err := middleware.VerifyToken(tokenString, secretKey)
 	if err != nil {
-		errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Kesalahan token"}
+		errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, ErrorCode: helper.MsgInvalidToken}
 		return c.JSON(http.StatusUnauthorized, errorResponse)
 	}
 
 	var adminUser model.User
 	result := db.Where("username = ?", username).First(&adminUser)
 	if result.Error != nil {
-		errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin tidak ditemukan"}
+		errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgAdminNotFound}
 		return c.JSON(http.StatusNotFound, errorResponse)
 	}
 
 	if !adminUser.IsAdmin {
-		errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Akses tidak diberikan, anda bukan admin"}
+		errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, ErrorCode: helper.MsgNotAdmin}
 		return c.JSON(http.StatusForbidden, errorResponse)
 	}
 
@@ -0,0 +1,296 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"myproject/helper"
+	"myproject/middleware"
+	"myproject/model"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Jumlah hari ke belakang yang dipakai sebagai pola historis keramaian
+const crowdHistoryDays = 84
+
+const (
+	defaultAvailabilityDays = 14
+	maxAvailabilityDays     = 60
+)
+
+// crowdVisitorsSQL adalah kondisi tiket yang dihitung sebagai pengunjung, termasuk yang belum dibayar
+const crowdVisitorsSQL = "status_order <> 'dibatalkan'"
+
+// forecastWisataCrowd memperkirakan keramaian tempat wisata untuk setiap tanggal pada dates
+func forecastWisataCrowd(db *gorm.DB, wisata model.Wisata, dates []time.Time) (map[string]helper.CrowdForecast, error) {
+	forecasts := make(map[string]helper.CrowdForecast, len(dates))
+	if len(dates) == 0 {
+		return forecasts, nil
+	}
+
+	first, last := dates[0], dates[0]
+	for _, date := range dates {
+		if date.Before(first) {
+			first = date
+		}
+		if date.After(last) {
+			last = date
+		}
+	}
+
+	location := helper.LoadTimeZone(wisata.TimeZone)
+	today := time.Now().In(location)
+	historyTo := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	historyFrom := historyTo.AddDate(0, 0, -crowdHistoryDays)
+
+	var rows []struct {
+		Date     string
+		Visitors int
+	}
+	if err := db.Model(&model.Ticket{}).
+		Select("DATE_FORMAT(checkin_booking, '%Y-%m-%d') AS date, COALESCE(SUM(quantity), 0) AS visitors").
+		Where("wisata_id = ? AND "+crowdVisitorsSQL, wisata.ID).
+		Where("checkin_booking >= ? AND checkin_booking < ?", historyFrom, last.AddDate(0, 0, 1)).
+		Group("DATE_FORMAT(checkin_booking, '%Y-%m-%d')").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	visitors := make(map[string]int, len(rows))
+	for _, row := range rows {
+		visitors[row.Date] = row.Visitors
+	}
+
+	var holidayRows []model.Holiday
+	if err := db.Where("date >= ? AND date <= ?", historyFrom.Format("2006-01-02"), last.Format("2006-01-02")).Find(&holidayRows).Error; err != nil {
+		return nil, err
+	}
+	holidays := make(map[string]string, len(holidayRows))
+	for _, holiday := range holidayRows {
+		holidays[holiday.Date] = holiday.Name
+	}
+
+	history := helper.CrowdHistory{From: historyFrom, To: historyTo, Visitors: visitors, Holidays: holidays}
+	for _, date := range dates {
+		forecast := helper.ForecastCrowd(date, visitors[date.Format("2006-01-02")], history)
+		forecasts[forecast.Date] = forecast
+	}
+	return forecasts, nil
+}
+
+// Menampilkan perkiraan tingkat keramaian tempat wisata pada tanggal tertentu
+func GetCrowdForecast(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var wisata model.Wisata
+		if err := db.First(&wisata, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgWisataNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		date, err := time.Parse("2006-01-02", c.QueryParam("date"))
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidDateFormat}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		forecasts, err := forecastWisataCrowd(db, wisata, []time.Time{date})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCrowdForecastFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":           http.StatusOK,
+			"error":          false,
+			"crowd_forecast": forecasts[date.Format("2006-01-02")],
+		})
+	}
+}
+
+// Menampilkan ketersediaan tiket, status buka dan perkiraan keramaian tempat wisata per tanggal
+func GetWisataAvailability(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var wisata model.Wisata
+		if err := db.First(&wisata, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgWisataNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		schedule := loadOpeningSchedule(db, wisata)
+		now := time.Now().In(schedule.Location)
+		from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		if fromStr := c.QueryParam("from"); fromStr != "" {
+			parsed, err := time.Parse("2006-01-02", fromStr)
+			if err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidFromDate}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			if parsed.After(from) {
+				from = parsed
+			}
+		}
+
+		days := defaultAvailabilityDays
+		if daysStr := c.QueryParam("days"); daysStr != "" {
+			parsed, err := strconv.Atoi(daysStr)
+			if err != nil || parsed <= 0 {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidDays}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			days = min(parsed, maxAvailabilityDays)
+		}
+
+		var dates []time.Time
+		for i := 0; i < days; i++ {
+			dates = append(dates, from.AddDate(0, 0, i))
+		}
+
+		forecasts, err := forecastWisataCrowd(db, wisata, dates)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCrowdForecastFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		availability := []map[string]interface{}{}
+		for _, date := range dates {
+			key := date.Format("2006-01-02")
+			availability = append(availability, map[string]interface{}{
+				"date":              key,
+				"is_open":           !schedule.IsClosedOn(date),
+				"available_tickets": wisata.AvailableTickets,
+				"crowd_level":       forecasts[key].Level,
+				"crowd_forecast":    forecasts[key],
+			})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":         http.StatusOK,
+			"error":        false,
+			"wisata_id":    wisata.ID,
+			"time_zone":    schedule.Location.String(),
+			"availability": availability,
+		})
+	}
+}
+
+// Menampilkan kalender hari libur yang dipakai untuk perkiraan keramaian
+func GetHolidaysByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		query := db.Model(&model.Holiday{}).Order("date asc")
+		if year := c.QueryParam("year"); year != "" {
+			query = query.Where("date LIKE ?", year+"-%")
+		}
+
+		holidays := []model.Holiday{}
+		if err := query.Find(&holidays).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgHolidaysFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":     http.StatusOK,
+			"error":    false,
+			"holidays": holidays,
+		})
+	}
+}
+
+// Menambahkan atau mengganti hari libur pada kalender oleh admin
+func CreateHolidayByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		var requestBody struct {
+			Date string `json:"date"`
+			Name string `json:"name"`
+		}
+		if err := c.Bind(&requestBody); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidRequestBody}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if _, err := time.Parse("2006-01-02", requestBody.Date); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidDateFormat}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+		name := strings.TrimSpace(requestBody.Name)
+		if name == "" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgHolidayNameRequired}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		// Satu tanggal hanya memiliki satu hari libur, yang baru menggantikan yang lama
+		holiday := model.Holiday{Date: requestBody.Date, Name: name, CreatedAt: &[]time.Time{time.Now()}[0]}
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("date = ?", holiday.Date).Delete(&model.Holiday{}).Error; err != nil {
+				return err
+			}
+			return tx.Create(&holiday).Error
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgHolidaySaveFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": "Holiday saved successfully",
+			"holiday": holiday,
+		})
+	}
+}
+
+// Menghapus hari libur dari kalender oleh admin
+func DeleteHolidayByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		var holiday model.Holiday
+		if err := db.First(&holiday, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgHolidayNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if err := db.Delete(&holiday).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgHolidayDeleteFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Holiday deleted successfully",
+		})
+	}
+}
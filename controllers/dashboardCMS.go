@@ -29,11 +29,11 @@ func GetAdminDashboardData(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			endDate = currentDate
 		} else {
 			if startDate, errDate = time.Parse("2006-01-02", startDateStr); errDate != nil {
-				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Kesalahan format pada start date. Gunakan YYYY-MM-DD"})
+				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidStartDate})
 			}
 
 			if endDate, errDate = time.Parse("2006-01-02", endDateStr); errDate != nil {
-				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Kesalahan format end date. Gunakan YYYY-MM-DD"})
+				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidEndDate})
 			}
 
 			if startDateStr != "" && endDateStr != "" {
@@ -46,7 +46,7 @@ func GetAdminDashboardData(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 				startDate = currentDate.AddDate(0, -5, 0) // 5 bulan ke belakang
 			} else {
 				if startDate, errDate = time.Parse("2006-01-02", startDateStr); errDate != nil {
-					return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Kesalahan format pada start date. Gunakan YYYY-MM-DD"})
+					return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidStartDate})
 				}
 			}
 
@@ -57,22 +57,22 @@ func GetAdminDashboardData(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		var totalUserCount int64
 		if err := db.Model(&model.User{}).Where("created_at BETWEEN ? AND ?", startDate, endDate).Count(&totalUserCount).Error; err != nil {
-			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Gagal dalam menghitung jumlah total user"})
+			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgDashboardTotalUsersFailed})
 		}
 
 		var totalWisataCount int64
 		if err := db.Model(&model.Wisata{}).Where("created_at BETWEEN ? AND ?", startDate, endDate).Count(&totalWisataCount).Error; err != nil {
-			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Gagal dalam menghitung tempat wisata yang tersedia"})
+			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgDashboardWisataFailed})
 		}
 
 		var totalVisitors int
 		if err := db.Model(&model.Ticket{}).Where("paid_status = ? AND created_at BETWEEN ? AND ?", true, startDate, endDate).Select("COALESCE(SUM(quantity), 0) as total_visitors").Scan(&totalVisitors).Error; err != nil {
-			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Gagal dalam menghitung pengunjung"})
+			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgDashboardVisitorsFailed})
 		}
 
 		var totalTicketPurchaseCount int64
 		if err := db.Model(&model.Ticket{}).Where("paid_status = ? AND created_at BETWEEN ? AND ?", true, startDate, endDate).Count(&totalTicketPurchaseCount).Error; err != nil {
-			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Gagal dalam menghitung order penjualan"})
+			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgDashboardOrdersFailed})
 		}
 
 		var totalIncome int
@@ -82,7 +82,7 @@ func GetAdminDashboardData(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			Where("created_at BETWEEN ? AND ?", startDate, endDate)
 
 		if err := query.Scan(&totalIncome).Error; err != nil {
-			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Gagal dalam menghitung total pendapatan"})
+			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgDashboardTotalRevenueFailed})
 		}
 
 		startMonth := time.Now().AddDate(0, -5, 0)
@@ -106,7 +106,7 @@ func GetAdminDashboardData(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 				Where("created_at BETWEEN ? AND ?", startOfMonth, endOfMonth)
 
 			if err := monthlyQuery.Scan(&monthlyTotalIncome).Error; err != nil {
-				return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Gagal dalam menghitung pendapatan bulanan"})
+				return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgDashboardMonthlyRevenueFailed})
 			}
 
 			monthlyIncome = append(monthlyIncome, map[string]interface{}{
@@ -139,16 +139,18 @@ func GetTopEmition(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		}
 
 		type ResultQuery struct {
-			Name         string  `json:"user_name"`
-			Profile      string  `json:"user_profile"`
-			Purchassed   int     `json:"purchassed"`
-			TotalEmition float64 `json:"total_emition"`
+			Name           string  `json:"user_name"`
+			Profile        string  `json:"user_profile"`
+			Purchassed     int     `json:"purchassed"`
+			TotalEmition   float64 `json:"total_emition"`
+			EmitionPerTrip float64 `json:"emition_per_trip"`
 		}
 		var resQuery []ResultQuery
 
-		err = db.Model(model.User{}).Joins("JOIN tickets ON users.id = tickets.user_id AND tickets.paid_status = ?", true).
-			Group("users.name").Order("total_emition asc").Limit(4).
-			Select("users.name AS name, MIN(users.photo_profil) AS profile, COUNT(tickets.id) AS purchassed, SUM(tickets.carbon_footprint) AS total_emition").
+		// User terarsip tetap dihitung, tiket terarsip tidak
+		err = db.Unscoped().Model(model.User{}).Joins("JOIN tickets ON users.id = tickets.user_id AND tickets.paid_status = ? AND tickets.deleted_at IS NULL", true).
+			Group("users.id, users.name, users.photo_profil").Order("emition_per_trip asc").Limit(4).
+			Select("users.name AS name, users.photo_profil AS profile, COUNT(tickets.id) AS purchassed, SUM(tickets.carbon_footprint) AS total_emition, SUM(tickets.carbon_footprint) / COUNT(tickets.id) AS emition_per_trip").
 			Scan(&resQuery).Error
 
 		if err != nil {
@@ -182,8 +184,9 @@ func GetTopWisata(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			return c.JSON(http.StatusOK, map[string]interface{}{"code": http.StatusInternalServerError, "error": true, "data": []interface{}{}})
 		}
 
-		err = db.Model(&model.Wisata{}).Select("wisata.title AS title, wisata.kota, COUNT(tickets.id) AS total_ticket, wisata.photo_wisata1, wisata.photo_wisata2, wisata.photo_wisata3").
-			Joins("JOIN tickets ON wisata.id = tickets.wisata_id").
+		// Tempat wisata terarsip tetap dihitung, tiket terarsip tidak
+		err = db.Unscoped().Model(&model.Wisata{}).Select("wisata.title AS title, wisata.kota, COUNT(tickets.id) AS total_ticket, wisata.photo_wisata1, wisata.photo_wisata2, wisata.photo_wisata3").
+			Joins("JOIN tickets ON wisata.id = tickets.wisata_id AND tickets.deleted_at IS NULL").
 			Where("tickets.paid_status = ?", true).
 			Group("wisata.title, wisata.kota, wisata.photo_wisata1, wisata.photo_wisata2, wisata.photo_wisata3").
 			Order("total_ticket desc").Limit(3).Scan(&resQuery).Error
@@ -0,0 +1,322 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"myproject/helper"
+	"myproject/middleware"
+	"myproject/model"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	errFacilityNotFound    = errors.New("facility not found")
+	errFacilityNameInvalid = errors.New("facility name invalid")
+)
+
+// parseFacilityNames memecah input fasilitas lama yang dipisah koma dan mungkin diapit tanda kutip
+func parseFacilityNames(fasilitasStr string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(fasilitasStr, ",") {
+		name = strings.TrimSpace(strings.Trim(strings.TrimSpace(name), "\"[]"))
+		if name == "" || seen[strings.ToLower(name)] {
+			continue
+		}
+		seen[strings.ToLower(name)] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// findFacilitiesByName mencari fasilitas berdasarkan nama (tanpa membedakan huruf besar kecil) tanpa menulis ke database.
+// Nama yang belum ada di katalog dikembalikan terpisah agar dibuat di dalam transaksi penyimpanan wisata.
+func findFacilitiesByName(db *gorm.DB, names []string) ([]model.Facility, []string, error) {
+	facilities := []model.Facility{}
+	var missingNames []string
+	for _, name := range names {
+		var facility model.Facility
+		err := db.Where("LOWER(name) = ?", strings.ToLower(name)).First(&facility).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if len(name) > 100 {
+				return nil, nil, errFacilityNameInvalid
+			}
+			missingNames = append(missingNames, name)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		facilities = append(facilities, facility)
+	}
+	return facilities, missingNames, nil
+}
+
+// createFacilities membuat fasilitas baru di katalog, dipanggil dengan tx yang sama dengan penyimpanan wisata
+func createFacilities(tx *gorm.DB, names []string) ([]model.Facility, error) {
+	facilities := []model.Facility{}
+	for _, name := range names {
+		facility := model.Facility{Name: name}
+		if err := tx.Create(&facility).Error; err != nil {
+			return nil, err
+		}
+		facilities = append(facilities, facility)
+	}
+	return facilities, nil
+}
+
+// resolveWisataFacilities mengambil fasilitas dari facility_ids, atau dari nama fasilitas lama jika facility_ids kosong.
+// Nama lama yang belum ada di katalog dikembalikan sebagai missingNames dan belum dibuat.
+func resolveWisataFacilities(db *gorm.DB, facilityIDs, fasilitasStr string) (facilities []model.Facility, missingNames []string, err error) {
+	if facilityIDs == "" {
+		return findFacilitiesByName(db, parseFacilityNames(fasilitasStr))
+	}
+
+	// ID yang sama cukup dihitung sekali agar jumlahnya bisa dibandingkan dengan fasilitas yang ditemukan
+	var ids []uint
+	seen := make(map[uint]bool)
+	for _, value := range strings.Split(facilityIDs, ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return nil, nil, errFacilityNotFound
+		}
+		if seen[uint(id)] {
+			continue
+		}
+		seen[uint(id)] = true
+		ids = append(ids, uint(id))
+	}
+
+	if err := db.Where("id IN ?", ids).Find(&facilities).Error; err != nil {
+		return nil, nil, err
+	}
+	if len(facilities) != len(ids) {
+		return nil, nil, errFacilityNotFound
+	}
+	return facilities, nil, nil
+}
+
+// facilityNamesJSON membentuk nilai kolom Fasilitas lama dari daftar fasilitas, diurutkan berdasarkan nama
+// agar urutannya sama saat wisata dibuat maupun saat disinkronkan
+func facilityNamesJSON(facilities []model.Facility) string {
+	names := []string{}
+	for _, facility := range facilities {
+		names = append(names, facility.Name)
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		return strings.ToLower(names[i]) < strings.ToLower(names[j])
+	})
+	fasilitasJSON, _ := json.Marshal(names)
+	return string(fasilitasJSON)
+}
+
+// syncWisataFacilityNames memperbarui kolom Fasilitas lama setelah relasi fasilitas berubah
+func syncWisataFacilityNames(db *gorm.DB, wisataIDs []uint) error {
+	for _, wisataID := range wisataIDs {
+		var facilities []model.Facility
+		if err := db.Joins("JOIN wisata_facilities ON wisata_facilities.facility_id = facilities.id").
+			Where("wisata_facilities.wisata_id = ?", wisataID).
+			Find(&facilities).Error; err != nil {
+			return err
+		}
+		if err := db.Model(&model.Wisata{}).Where("id = ?", wisataID).Update("fasilitas", facilityNamesJSON(facilities)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func linkedWisataIDs(db *gorm.DB, facilityID uint) []uint {
+	var wisataIDs []uint
+	db.Table("wisata_facilities").Where("facility_id = ?", facilityID).Pluck("wisata_id", &wisataIDs)
+	return wisataIDs
+}
+
+// Menampilkan seluruh fasilitas beserta jumlah tempat wisata yang memilikinya
+func GetFacilities(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		type FacilityWithCount struct {
+			model.Facility
+			TotalWisata int64 `json:"total_wisata"`
+		}
+		facilities := []FacilityWithCount{}
+		query := db.Model(&model.Facility{}).
+			Select("facilities.*, (SELECT COUNT(*) FROM wisata_facilities WHERE wisata_facilities.facility_id = facilities.id) AS total_wisata").
+			Order("facilities.name asc")
+		if c.QueryParam("accessibility") == "true" {
+			query = query.Where("facilities.is_accessibility = ?", true)
+		}
+		if err := query.Scan(&facilities).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgFacilitiesFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"facilities": facilities,
+		})
+	}
+}
+
+func CreateFacilityByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		var facility model.Facility
+		if err := c.Bind(&facility); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidRequestBody}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		facility.ID = 0
+		facility.Name = strings.TrimSpace(facility.Name)
+		if facility.Name == "" || len(facility.Name) > 100 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgFacilityNameInvalid}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var existingCount int64
+		db.Model(&model.Facility{}).Where("LOWER(name) = ?", strings.ToLower(facility.Name)).Count(&existingCount)
+		if existingCount > 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, ErrorCode: helper.MsgFacilityExists}
+			return c.JSON(http.StatusConflict, errorResponse)
+		}
+
+		if err := db.Create(&facility).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgFacilityCreateFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":     http.StatusCreated,
+			"error":    false,
+			"message":  "Facility created successfully",
+			"facility": facility,
+		})
+	}
+}
+
+func UpdateFacilityByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		var facility model.Facility
+		if err := db.First(&facility, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgFacilityNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var requestBody struct {
+			Name            *string `json:"name"`
+			Icon            *string `json:"icon"`
+			IsAccessibility *bool   `json:"is_accessibility"`
+		}
+		if err := c.Bind(&requestBody); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidRequestBody}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		nameChanged := false
+		if requestBody.Name != nil {
+			name := strings.TrimSpace(*requestBody.Name)
+			if name == "" || len(name) > 100 {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgFacilityNameInvalid}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+
+			var existingCount int64
+			db.Model(&model.Facility{}).Where("LOWER(name) = ? AND id <> ?", strings.ToLower(name), facility.ID).Count(&existingCount)
+			if existingCount > 0 {
+				errorResponse := helper.ErrorResponse{Code: http.StatusConflict, ErrorCode: helper.MsgFacilityExists}
+				return c.JSON(http.StatusConflict, errorResponse)
+			}
+
+			nameChanged = name != facility.Name
+			facility.Name = name
+		}
+		if requestBody.Icon != nil {
+			facility.Icon = *requestBody.Icon
+		}
+		if requestBody.IsAccessibility != nil {
+			facility.IsAccessibility = *requestBody.IsAccessibility
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Save(&facility).Error; err != nil {
+				return err
+			}
+			if !nameChanged {
+				return nil
+			}
+			return syncWisataFacilityNames(tx, linkedWisataIDs(tx, facility.ID))
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgFacilityUpdateFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":     http.StatusOK,
+			"error":    false,
+			"message":  "Facility updated successfully",
+			"facility": facility,
+		})
+	}
+}
+
+func DeleteFacilityByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		var facility model.Facility
+		if err := db.First(&facility, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgFacilityNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			wisataIDs := linkedWisataIDs(tx, facility.ID)
+			if err := tx.Exec("DELETE FROM wisata_facilities WHERE facility_id = ?", facility.ID).Error; err != nil {
+				return err
+			}
+			if err := tx.Delete(&facility).Error; err != nil {
+				return err
+			}
+			return syncWisataFacilityNames(tx, wisataIDs)
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgFacilityDeleteFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Facility deleted successfully",
+		})
+	}
+}
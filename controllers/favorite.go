@@ -0,0 +1,243 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"myproject/helper"
+	"myproject/middleware"
+	"myproject/model"
+	"net/http"
+	"time"
+)
+
+// notifyFavoriteUsers mengirim notifikasi hanya kepada user yang menyimpan tempat wisata ke favoritnya
+func notifyFavoriteUsers(db *gorm.DB, wisataID uint, title, message helper.MessageCode, args ...interface{}) error {
+	var userIDs []uint
+	if err := db.Model(&model.Favorite{}).Where("wisata_id = ?", wisataID).Pluck("user_id", &userIDs).Error; err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		locales := userLocales(db, userID)
+		notification := model.Notification{
+			UserID:   userID,
+			Title:    helper.Translate(locales, title),
+			Message:  helper.Translate(locales, message, args...),
+			Status:   "unread",
+			WisataID: wisataID,
+			Type:     notificationTypeFavorite,
+		}
+		if err := db.Create(&notification).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notifyFavoriteWisataChanges memberi tahu user saat harga tempat wisata favoritnya turun atau tiketnya tersedia kembali
+func notifyFavoriteWisataChanges(db *gorm.DB, wisata model.Wisata, oldPrice, oldAvailableTickets int) error {
+	if wisata.Price < oldPrice {
+		if err := notifyFavoriteUsers(db, wisata.ID, helper.MsgNotificationPriceDropTitle, helper.MsgNotificationPriceDrop, wisata.Title, oldPrice, wisata.Price); err != nil {
+			return err
+		}
+	}
+	if oldAvailableTickets <= 0 && wisata.AvailableTickets > 0 {
+		if err := notifyFavoriteUsers(db, wisata.ID, helper.MsgNotificationBackInStockTitle, helper.MsgNotificationBackInStock, wisata.Title); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notifyPromoToFavoriteUsers mengirim promo aktif kepada user yang memiliki favorit, promo berlaku untuk seluruh tempat wisata.
+// Voucher pribadi milik satu user tidak dikirim.
+func notifyPromoToFavoriteUsers(db *gorm.DB, promo model.Promo) error {
+	if !promo.StatusAktif || time.Now().After(promo.TanggalKadaluarsa) || promo.OwnerUserID != nil {
+		return nil
+	}
+
+	var favorites []model.Favorite
+	// Favorit atas tempat wisata atau user yang diarsipkan dilewati
+	if err := db.Preload("Wisata").
+		Where("wisata_id IN (SELECT id FROM wisata WHERE deleted_at IS NULL)").
+		Where("user_id IN (SELECT id FROM users WHERE deleted_at IS NULL)").
+		Order("created_at desc").Find(&favorites).Error; err != nil {
+		return err
+	}
+
+	// Satu notifikasi per user, menyebut tempat wisata favorit yang paling baru disimpan
+	var latestFavorites []model.Favorite
+	notified := make(map[uint]bool)
+	for _, favorite := range favorites {
+		if notified[favorite.UserID] {
+			continue
+		}
+		notified[favorite.UserID] = true
+		latestFavorites = append(latestFavorites, favorite)
+	}
+	if len(latestFavorites) == 0 {
+		return nil
+	}
+
+	userIDs := make([]uint, 0, len(latestFavorites))
+	for _, favorite := range latestFavorites {
+		userIDs = append(userIDs, favorite.UserID)
+	}
+	var users []model.User
+	if err := db.Select("id", "locale").Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return err
+	}
+	userLocale := make(map[uint]string, len(users))
+	for _, user := range users {
+		userLocale[user.ID] = user.Locale
+	}
+
+	notifications := make([]model.Notification, 0, len(latestFavorites))
+	for _, favorite := range latestFavorites {
+		locales := helper.PreferredLocales(userLocale[favorite.UserID])
+		notifications = append(notifications, model.Notification{
+			UserID:   favorite.UserID,
+			Title:    helper.Translate(locales, helper.MsgNotificationPromoTitle, promo.Title),
+			Message:  helper.Translate(locales, helper.MsgNotificationFavoritePromo, promo.JumlahPotonganPersen, favorite.Wisata.Title),
+			Status:   "unread",
+			PromoID:  promo.ID,
+			WisataID: favorite.WisataID,
+			Type:     notificationTypePromo,
+		})
+	}
+	return db.CreateInBatches(&notifications, 500).Error
+}
+
+// Menampilkan daftar tempat wisata favorit user
+func GetFavorites(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		page, perPage := helper.GetPaginationParams(c)
+
+		// Tempat wisata yang diarsipkan tidak ditampilkan
+		query := db.Model(&model.Favorite{}).Where("user_id = ?", user.ID).
+			Where("wisata_id IN (SELECT id FROM wisata WHERE deleted_at IS NULL)")
+
+		var totalFavorites int64
+		query.Count(&totalFavorites)
+
+		var totalPages int
+		if perPage > 0 {
+			totalPages = int((totalFavorites + int64(perPage) - 1) / int64(perPage))
+		} else {
+			totalPages = 0
+		}
+
+		favorites := []model.Favorite{}
+		if err := query.Preload("Wisata.Category").Order("created_at desc").Offset((page - 1) * perPage).Limit(perPage).Find(&favorites).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgFavoritesFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		wisatas := make([]model.Wisata, len(favorites))
+		for i := range favorites {
+			wisatas[i] = favorites[i].Wisata
+		}
+		localizeWisatas(db, requestLocales(c), wisatas)
+		for i := range favorites {
+			favorites[i].Wisata = wisatas[i]
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":      http.StatusOK,
+			"error":     false,
+			"message":   "Favorites retrieved successfully",
+			"favorites": favorites,
+			"pagination": map[string]interface{}{
+				"current_page": page,
+				"from":         (page-1)*perPage + 1,
+				"last_page":    totalPages,
+				"per_page":     perPage,
+				"to":           (page-1)*perPage + len(favorites),
+				"total":        totalFavorites,
+			},
+		})
+	}
+}
+
+// Menyimpan tempat wisata ke daftar favorit user
+func AddFavorite(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var wisata model.Wisata
+		if err := db.First(&wisata, c.Param("wisata_id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgWisataNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var favorite model.Favorite
+		err := db.Where("user_id = ? AND wisata_id = ?", user.ID, wisata.ID).First(&favorite).Error
+		if err == nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, ErrorCode: helper.MsgFavoriteExists}
+			return c.JSON(http.StatusConflict, errorResponse)
+		}
+
+		favorite = model.Favorite{
+			UserID:    user.ID,
+			WisataID:  wisata.ID,
+			CreatedAt: &[]time.Time{time.Now()}[0],
+		}
+		if err := db.Create(&favorite).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgFavoriteAddFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		favorite.Wisata = wisata
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":     http.StatusCreated,
+			"error":    false,
+			"message":  "Wisata added to favorites",
+			"favorite": favorite,
+		})
+	}
+}
+
+// Menghapus tempat wisata dari daftar favorit user
+func RemoveFavorite(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		result = db.Where("user_id = ? AND wisata_id = ?", user.ID, c.Param("wisata_id")).Delete(&model.Favorite{})
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgFavoriteRemoveFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		if result.RowsAffected == 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgFavoriteNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Wisata removed from favorites",
+		})
+	}
+}
@@ -14,13 +14,13 @@ func GetCities(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		tokenString := c.Request().Header.Get("Authorization")
 		if tokenString == "" {
-			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Authorization token is missing"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, ErrorCode: helper.MsgAuthTokenMissing}
 			return c.JSON(http.StatusUnauthorized, errorResponse)
 		}
 
 		authParts := strings.SplitN(tokenString, " ", 2)
 		if len(authParts) != 2 || authParts[0] != "Bearer" {
-			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token format"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, ErrorCode: helper.MsgInvalidTokenFormat}
 			return c.JSON(http.StatusUnauthorized, errorResponse)
 		}
 
@@ -28,7 +28,7 @@ func GetCities(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		_, err := middleware.VerifyToken(tokenString, secretKey)
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid token"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, ErrorCode: helper.MsgInvalidToken}
 			return c.JSON(http.StatusUnauthorized, errorResponse)
 		}
 
@@ -36,7 +36,7 @@ func GetCities(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		// Menggunakan COALESCE untuk mengatasi nilai NULL
 		if err := db.Model(&model.Wisata{}).Distinct("COALESCE(kota, 'Unknown')").Pluck("COALESCE(kota, 'Unknown')", &cities).Error; err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch cities"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCitiesFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -51,7 +51,7 @@ func GetCategories(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var user model.User
 		result := db.Where("username = ?", username).First(&user)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch user data"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -59,42 +59,51 @@ func GetCategories(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		categoryName := c.QueryParam("category_name")
 		page, perPage := helper.GetPaginationParams(c)
 
-		// Build the base query
-		query := db.Model(&model.Category{})
-
-		if categoryName != "" {
-			query = query.Where("category_name LIKE ?", "%"+categoryName+"%")
+		// Kategori nonaktif hanya ditampilkan untuk admin
+		query := db.Model(&model.Category{}).Order(categoryOrder)
+		if !user.IsAdmin {
+			query = query.Where("is_active = ?", true)
 		}
 
-		var totalCategories int64
-		query.Count(&totalCategories)
+		var categories []model.Category
+		if err := query.Find(&categories).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCategoryFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		localizeCategories(db, requestLocales(c), categories)
+
+		// Susun pohon kategori, kategori utama ikut tampil jika salah satu subkategorinya cocok dengan pencarian
+		tree := []CategoryNode{}
+		for _, node := range buildCategoryTree(categories) {
+			if categoryName == "" || categoryTreeMatches(node, categoryName) {
+				tree = append(tree, node)
+			}
+		}
+		totalCategories := len(tree)
 
 		// Calculate pagination information
 		var totalPages int
 		if perPage > 0 {
-			totalPages = int((totalCategories + int64(perPage) - 1) / int64(perPage))
+			totalPages = (totalCategories + perPage - 1) / perPage
 		} else {
 			totalPages = 0
 		}
 
-		// Retrieve paginated categories
-		var categories []model.Category
-		query.Offset((page - 1) * perPage).Limit(perPage).Find(&categories)
-
-		if categories == nil {
-			categories = []model.Category{}
-		}
+		// Pagination dihitung per kategori utama
+		from := min((page-1)*perPage, totalCategories)
+		to := min(from+perPage, totalCategories)
+		tree = tree[from:to]
 
 		response := map[string]interface{}{
 			"code":       http.StatusOK,
 			"error":      false,
-			"categories": categories,
+			"categories": tree,
 			"pagination": map[string]interface{}{
 				"current_page": page,
 				"from":         (page-1)*perPage + 1,
 				"last_page":    totalPages,
 				"per_page":     perPage,
-				"to":           (page-1)*perPage + len(categories),
+				"to":           (page-1)*perPage + len(tree),
 				"total":        totalCategories,
 			},
 		}
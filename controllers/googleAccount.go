@@ -4,14 +4,17 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"github.com/labstack/echo/v4"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"gorm.io/gorm"
 	"math/rand"
+	"myproject/helper"
 	"myproject/middleware"
 	"myproject/model"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -24,6 +27,9 @@ var GoogleConfig = &oauth2.Config{
 	Endpoint:     google.Endpoint,
 }
 
+// errAccountArchived dikembalikan saat akun Google yang masuk sudah diarsipkan admin
+var errAccountArchived = helper.NewMessageError(helper.MsgAccountArchived)
+
 // GoogleUser adalah struktur data yang mewakili respons dari Google setelah otentikasi
 type GoogleUser struct {
 	ID    string `json:"id"`
@@ -32,9 +38,17 @@ type GoogleUser struct {
 }
 
 // Endpoint untuk menginisiasi otentikasi Google
+// Kode referral dan device id opsional dibawa melalui parameter state agar tersedia saat callback
 func GoogleAuthInitiate(c echo.Context) error {
-	url := GoogleConfig.AuthCodeURL("state")
-	return c.Redirect(http.StatusTemporaryRedirect, url)
+	state := "state"
+	referralCode := c.QueryParam("referral_code")
+	deviceID := c.QueryParam("device_id")
+	if referralCode != "" || deviceID != "" {
+		state = url.Values{"referral_code": {referralCode}, "device_id": {deviceID}}.Encode()
+	}
+
+	authURL := GoogleConfig.AuthCodeURL(state)
+	return c.Redirect(http.StatusTemporaryRedirect, authURL)
 }
 
 // Endpoint yang diarahkan oleh Google setelah otentikasi berhasil
@@ -46,25 +60,40 @@ func GoogleAuthCallback(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		// Menukarkan kode otentikasi dengan token akses dari Google
 		token, err := GoogleConfig.Exchange(c.Request().Context(), code)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Failed to exchange code for token"})
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgGoogleTokenExchangeFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
 		// Mendapatkan informasi pengguna dari Google menggunakan token akses
 		googleUser, err := getGoogleUserInfo(token)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Failed to get user info from Google"})
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgGoogleUserInfoFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
+		// Mengambil kode referral dan device id dari parameter state
+		state, _ := url.ParseQuery(c.QueryParam("state"))
+
 		// Membuat atau mengambil pengguna dari database berdasarkan informasi Google
-		user, err := createUserFromGoogle(db, googleUser)
+		user, err := createUserFromGoogle(db, googleUser, state.Get("referral_code"), state.Get("device_id"))
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Failed to create user"})
+			if errors.Is(err, errAccountArchived) {
+				errorResponse := helper.NewErrorResponse(http.StatusForbidden, err)
+				return c.JSON(http.StatusForbidden, errorResponse)
+			}
+			if isReferralError(err) {
+				errorResponse := helper.NewErrorResponse(http.StatusBadRequest, err)
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserCreateFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
 		// Generate JWT token
 		tokenString, err := middleware.GenerateToken(user.Username, secretKey)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": "Failed to generate token"})
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgTokenGenerateFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
 		// Mengirim respons sukses bersama dengan token
@@ -115,11 +144,14 @@ func generateRandomPhoneNumber(length int) string {
 }
 
 // Fungsi untuk membuat atau mengambil pengguna dari database berdasarkan informasi Google
-func createUserFromGoogle(db *gorm.DB, googleUser *GoogleUser) (*model.User, error) {
-	// Cek apakah pengguna sudah ada dalam database
+func createUserFromGoogle(db *gorm.DB, googleUser *GoogleUser, referralCode, deviceID string) (*model.User, error) {
+	// Cek apakah pengguna sudah ada dalam database, termasuk akun yang diarsipkan
 	var existingUser model.User
-	result := db.Where("email = ?", googleUser.Email).First(&existingUser)
+	result := db.Unscoped().Where("email = ?", googleUser.Email).First(&existingUser)
 	if result.Error == nil {
+		if existingUser.DeletedAt.Valid {
+			return nil, errAccountArchived
+		}
 		// Pengguna sudah ada, kembalikan pengguna yang sudah ada
 		return &existingUser, nil
 	}
@@ -133,6 +165,19 @@ func createUserFromGoogle(db *gorm.DB, googleUser *GoogleUser) (*model.User, err
 		PhoneNumber:       generateRandomPhoneNumber(10), // Atur nomor telepon sesuai kebutuhan atau gunakan nilai default
 		IsVerified:        true,                          // Atur nilai default atau sesuai kebutuhan
 		VerificationToken: "",                            // Atur sesuai kebutuhan atau gunakan nilai default
+		ReferralCode:      generateUniqueReferralCode(db, googleUser.Name),
+		DeviceID:          deviceID,
+	}
+
+	// Validasi kode referral jika diisi
+	var referrer *model.User
+	if referralCode != "" {
+		var err error
+		// Nomor telepon user Google dibuat acak sehingga tidak dipakai untuk pemeriksaan anti-fraud
+		referrer, err = validateReferralCode(db, referralCode, "", newUser.Email, deviceID)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Simpan pengguna baru ke database
@@ -141,5 +186,11 @@ func createUserFromGoogle(db *gorm.DB, googleUser *GoogleUser) (*model.User, err
 		return nil, result.Error
 	}
 
+	if referrer != nil {
+		if err := createReferral(db, referrer, &newUser, ""); err != nil {
+			return nil, err
+		}
+	}
+
 	return &newUser, nil
 }
@@ -0,0 +1,641 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"myproject/carbon"
+	"myproject/helper"
+	"myproject/middleware"
+	"myproject/model"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	maxItineraryDays           = 30
+	maxItineraryStops          = 100
+	defaultStopDurationMinutes = 120
+)
+
+type itineraryStopRequest struct {
+	Day             int    `json:"day"`
+	WisataID        uint   `json:"wisata_id"`
+	TicketID        *uint  `json:"ticket_id"`
+	StartTime       string `json:"start_time"`
+	DurationMinutes int    `json:"duration_minutes"`
+	Notes           string `json:"notes"`
+}
+
+type itineraryRequest struct {
+	Name          string                 `json:"name"`
+	StartDate     string                 `json:"start_date"`
+	Days          int                    `json:"days"`
+	TransportMode string                 `json:"transport_mode"`
+	Stops         []itineraryStopRequest `json:"stops"`
+}
+
+// ItineraryLeg adalah perjalanan dari lokasi sebelumnya menuju satu pemberhentian
+type ItineraryLeg struct {
+	StopID          uint    `json:"stop_id"`
+	Day             int     `json:"day"`
+	FromWisataID    uint    `json:"from_wisata_id,omitempty"` // Kosong jika berangkat dari lokasi user
+	ToWisataID      uint    `json:"to_wisata_id"`
+	DistanceKm      float64 `json:"distance_km"`
+	CarbonFootprint float64 `json:"carbon_footprint"`
+}
+
+// ItinerarySummary adalah estimasi jarak dan emisi karbon seluruh perjalanan
+type ItinerarySummary struct {
+	TransportMode        carbon.TransportMode `json:"transport_mode"`
+	TotalDistanceKm      float64              `json:"total_distance_km"`
+	TotalCarbonFootprint float64              `json:"total_carbon_footprint"`
+	Days                 []ItineraryDayTotal  `json:"days"`
+	Legs                 []ItineraryLeg       `json:"legs"`
+}
+
+type ItineraryDayTotal struct {
+	Day             int     `json:"day"`
+	Date            string  `json:"date"`
+	Stops           int     `json:"stops"`
+	DistanceKm      float64 `json:"distance_km"`
+	CarbonFootprint float64 `json:"carbon_footprint"`
+}
+
+// buildItinerary memvalidasi permintaan dan menyusun itinerary beserta pemberhentiannya, urutan stops dalam satu hari mengikuti urutan request
+func buildItinerary(db *gorm.DB, user model.User, request itineraryRequest) (model.Itinerary, error) {
+	itinerary := model.Itinerary{
+		UserID:    user.ID,
+		Name:      strings.TrimSpace(request.Name),
+		StartDate: request.StartDate,
+		Days:      request.Days,
+	}
+
+	if itinerary.Name == "" || len(itinerary.Name) > 100 {
+		return itinerary, helper.NewMessageError(helper.MsgItineraryNameInvalid)
+	}
+	if _, err := time.Parse("2006-01-02", itinerary.StartDate); err != nil {
+		return itinerary, helper.NewMessageError(helper.MsgInvalidStartDate)
+	}
+	if itinerary.Days <= 0 || itinerary.Days > maxItineraryDays {
+		return itinerary, helper.NewMessageError(helper.MsgItineraryDaysRange, maxItineraryDays)
+	}
+	mode, err := carbon.ParseMode(request.TransportMode)
+	if err != nil {
+		return itinerary, helper.NewMessageError(helper.MsgInvalidTransportMode)
+	}
+	itinerary.TransportMode = string(mode)
+
+	if len(request.Stops) > maxItineraryStops {
+		return itinerary, helper.NewMessageError(helper.MsgItineraryTooManyStops, maxItineraryStops)
+	}
+
+	positions := make(map[int]int)
+	for _, stopRequest := range request.Stops {
+		if stopRequest.Day <= 0 || stopRequest.Day > itinerary.Days {
+			return itinerary, helper.NewMessageError(helper.MsgItineraryStopDayRange)
+		}
+
+		var wisata model.Wisata
+		if err := db.First(&wisata, stopRequest.WisataID).Error; err != nil {
+			return itinerary, helper.NewMessageError(helper.MsgWisataNotFound)
+		}
+
+		if stopRequest.TicketID != nil {
+			var ticket model.Ticket
+			if err := db.Where("id = ? AND user_id = ? AND wisata_id = ? AND status_order <> ?", *stopRequest.TicketID, user.ID, wisata.ID, "dibatalkan").First(&ticket).Error; err != nil {
+				return itinerary, helper.NewMessageError(helper.MsgItineraryTicketNotFound)
+			}
+		}
+
+		if stopRequest.StartTime != "" {
+			if _, err := helper.ParseClock(stopRequest.StartTime); err != nil {
+				return itinerary, helper.NewMessageError(helper.MsgInvalidStartTime)
+			}
+		}
+		if stopRequest.DurationMinutes < 0 {
+			return itinerary, helper.NewMessageError(helper.MsgNegativeDuration)
+		}
+		if stopRequest.DurationMinutes == 0 {
+			stopRequest.DurationMinutes = defaultStopDurationMinutes
+		}
+
+		positions[stopRequest.Day]++
+		itinerary.Stops = append(itinerary.Stops, model.ItineraryStop{
+			Day:             stopRequest.Day,
+			Position:        positions[stopRequest.Day],
+			WisataID:        wisata.ID,
+			Wisata:          wisata,
+			TicketID:        stopRequest.TicketID,
+			StartTime:       stopRequest.StartTime,
+			DurationMinutes: stopRequest.DurationMinutes,
+			Notes:           strings.TrimSpace(stopRequest.Notes),
+		})
+	}
+
+	return itinerary, nil
+}
+
+func itineraryStopsQuery(db *gorm.DB) *gorm.DB {
+	return db.Order("day asc, position asc")
+}
+
+func findItinerary(db *gorm.DB, query string, args ...interface{}) (model.Itinerary, error) {
+	var itinerary model.Itinerary
+	err := db.Preload("Stops", itineraryStopsQuery).Preload("Stops.Wisata", withArchived).Where(query, args...).First(&itinerary).Error
+	return itinerary, err
+}
+
+// summarizeItinerary menghitung jarak antar pemberhentian dan emisi karbon setiap perjalanan.
+// Jika origin memiliki lokasi, perjalanan pertama dihitung dari lokasi tersebut.
+func summarizeItinerary(itinerary model.Itinerary, origin *model.User) (ItinerarySummary, error) {
+	mode, err := carbon.ParseMode(itinerary.TransportMode)
+	if err != nil {
+		return ItinerarySummary{}, err
+	}
+	summary := ItinerarySummary{TransportMode: mode, Days: []ItineraryDayTotal{}, Legs: []ItineraryLeg{}}
+
+	startDate, _ := time.Parse("2006-01-02", itinerary.StartDate)
+	for day := 1; day <= itinerary.Days; day++ {
+		summary.Days = append(summary.Days, ItineraryDayTotal{Day: day, Date: startDate.AddDate(0, 0, day-1).Format("2006-01-02")})
+	}
+
+	stops := append([]model.ItineraryStop{}, itinerary.Stops...)
+	sort.SliceStable(stops, func(i, j int) bool {
+		if stops[i].Day != stops[j].Day {
+			return stops[i].Day < stops[j].Day
+		}
+		return stops[i].Position < stops[j].Position
+	})
+
+	var previous *model.Wisata
+	for i := range stops {
+		stop := stops[i]
+		leg := ItineraryLeg{StopID: stop.ID, Day: stop.Day, ToWisataID: stop.WisataID}
+
+		// CalculateCarbonFootprint menghitung dari lokasi user, sehingga lokasi asal perjalanan dipakai sebagai user
+		var from *model.User
+		if previous != nil {
+			leg.FromWisataID = previous.ID
+			from = &model.User{Lat: previous.Lat, Long: previous.Long}
+		} else if origin != nil && (origin.Lat != 0 || origin.Long != 0) {
+			from = origin
+		}
+		if from != nil {
+			footprint, err := CalculateCarbonFootprint(*from, stop.Wisata, mode)
+			if err != nil {
+				return summary, err
+			}
+			leg.DistanceKm = footprint.DistanceKm
+			leg.CarbonFootprint = footprint.CarbonGrams
+		}
+
+		summary.Legs = append(summary.Legs, leg)
+		summary.TotalDistanceKm += leg.DistanceKm
+		summary.TotalCarbonFootprint += leg.CarbonFootprint
+		if stop.Day >= 1 && stop.Day <= len(summary.Days) {
+			dayTotal := &summary.Days[stop.Day-1]
+			dayTotal.Stops++
+			dayTotal.DistanceKm += leg.DistanceKm
+			dayTotal.CarbonFootprint += leg.CarbonFootprint
+		}
+		previous = &stops[i].Wisata
+	}
+
+	return summary, nil
+}
+
+// SharedItineraryStop adalah pemberhentian pada itinerary yang dibagikan, tanpa tiket dan catatan pribadi pemilik
+type SharedItineraryStop struct {
+	ID              uint         `json:"id"`
+	Day             int          `json:"day"`
+	Position        int          `json:"position"`
+	WisataID        uint         `json:"wisata_id"`
+	Wisata          model.Wisata `json:"wisata"`
+	StartTime       string       `json:"start_time"`
+	DurationMinutes int          `json:"duration_minutes"`
+}
+
+func sharedItineraryStops(stops []model.ItineraryStop) []SharedItineraryStop {
+	shared := make([]SharedItineraryStop, 0, len(stops))
+	for _, stop := range stops {
+		shared = append(shared, SharedItineraryStop{
+			ID:              stop.ID,
+			Day:             stop.Day,
+			Position:        stop.Position,
+			WisataID:        stop.WisataID,
+			Wisata:          stop.Wisata,
+			StartTime:       stop.StartTime,
+			DurationMinutes: stop.DurationMinutes,
+		})
+	}
+	return shared
+}
+
+// itineraryICS menyusun kalender dari pemberhentian itinerary, pemberhentian tanpa jam mulai menjadi acara sepanjang hari
+func itineraryICS(itinerary model.Itinerary) string {
+	startDate, _ := time.Parse("2006-01-02", itinerary.StartDate)
+
+	var events []helper.ICSEvent
+	for _, stop := range itinerary.Stops {
+		date := startDate.AddDate(0, 0, stop.Day-1)
+		event := helper.ICSEvent{
+			UID:         fmt.Sprintf("itinerary-%d-stop-%d@destimate", itinerary.ID, stop.ID),
+			Summary:     stop.Wisata.Title,
+			Description: stop.Notes,
+			Location:    stop.Wisata.Location,
+			URL:         stop.Wisata.MapsLink,
+		}
+
+		if minute, err := helper.ParseClock(stop.StartTime); err == nil {
+			location := helper.LoadTimeZone(stop.Wisata.TimeZone)
+			event.Start = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, location).Add(time.Duration(minute) * time.Minute)
+			event.End = event.Start.Add(time.Duration(stop.DurationMinutes) * time.Minute)
+		} else {
+			event.AllDay = true
+			event.Start = date
+			event.End = date.AddDate(0, 0, 1)
+		}
+		events = append(events, event)
+	}
+
+	return helper.BuildICS(itinerary.Name, events)
+}
+
+func itineraryResponse(itinerary model.Itinerary, summary ItinerarySummary) map[string]interface{} {
+	response := map[string]interface{}{
+		"itinerary": itinerary,
+		"summary":   summary,
+	}
+	if itinerary.ShareToken != nil {
+		response["share_path"] = "/shared/itineraries/" + *itinerary.ShareToken
+	}
+	return response
+}
+
+// Menampilkan seluruh itinerary milik user
+func GetItineraries(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		page, perPage := helper.GetPaginationParams(c)
+
+		query := db.Model(&model.Itinerary{}).Where("user_id = ?", user.ID)
+
+		var totalItineraries int64
+		query.Count(&totalItineraries)
+
+		var totalPages int
+		if perPage > 0 {
+			totalPages = int((totalItineraries + int64(perPage) - 1) / int64(perPage))
+		} else {
+			totalPages = 0
+		}
+
+		itineraries := []model.Itinerary{}
+		if err := query.Preload("Stops", itineraryStopsQuery).Preload("Stops.Wisata", withArchived).
+			Order("start_date desc, id desc").
+			Offset((page - 1) * perPage).Limit(perPage).
+			Find(&itineraries).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgItinerariesFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":        http.StatusOK,
+			"error":       false,
+			"itineraries": itineraries,
+			"pagination": map[string]interface{}{
+				"current_page": page,
+				"from":         (page-1)*perPage + 1,
+				"last_page":    totalPages,
+				"per_page":     perPage,
+				"to":           (page-1)*perPage + len(itineraries),
+				"total":        totalItineraries,
+			},
+		})
+	}
+}
+
+// Membuat itinerary baru beserta hari dan pemberhentiannya
+func CreateItinerary(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var request itineraryRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidRequestBody}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		itinerary, err := buildItinerary(db, user, request)
+		if err != nil {
+			errorResponse := helper.NewErrorResponse(http.StatusBadRequest, err)
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		itinerary.CreatedAt = &[]time.Time{time.Now()}[0]
+		if err := db.Omit("Stops.Wisata").Create(&itinerary).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgItineraryCreateFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		summary, err := summarizeItinerary(itinerary, &user)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCarbonFootprintFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		response := itineraryResponse(itinerary, summary)
+		response["code"] = http.StatusCreated
+		response["error"] = false
+		response["message"] = "Itinerary created successfully"
+		return c.JSON(http.StatusCreated, response)
+	}
+}
+
+// Menampilkan detail itinerary beserta estimasi jarak dan emisi karbon
+func GetItineraryByID(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		itinerary, err := findItinerary(db, "id = ? AND user_id = ?", c.Param("id"), user.ID)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgItineraryNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		summary, err := summarizeItinerary(itinerary, &user)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCarbonFootprintFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		response := itineraryResponse(itinerary, summary)
+		response["code"] = http.StatusOK
+		response["error"] = false
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+// Mengubah itinerary, seluruh pemberhentian diganti dengan daftar yang dikirim
+func UpdateItinerary(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		existingItinerary, err := findItinerary(db, "id = ? AND user_id = ?", c.Param("id"), user.ID)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgItineraryNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var request itineraryRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidRequestBody}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		itinerary, err := buildItinerary(db, user, request)
+		if err != nil {
+			errorResponse := helper.NewErrorResponse(http.StatusBadRequest, err)
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+		itinerary.ID = existingItinerary.ID
+		itinerary.ShareToken = existingItinerary.ShareToken
+		itinerary.CreatedAt = existingItinerary.CreatedAt
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("itinerary_id = ?", itinerary.ID).Delete(&model.ItineraryStop{}).Error; err != nil {
+				return err
+			}
+			return tx.Omit("Stops.Wisata").Save(&itinerary).Error
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgItineraryUpdateFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		summary, err := summarizeItinerary(itinerary, &user)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCarbonFootprintFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		response := itineraryResponse(itinerary, summary)
+		response["code"] = http.StatusOK
+		response["error"] = false
+		response["message"] = "Itinerary updated successfully"
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+// Menghapus itinerary beserta pemberhentiannya
+func DeleteItinerary(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var itinerary model.Itinerary
+		if err := db.Where("id = ? AND user_id = ?", c.Param("id"), user.ID).First(&itinerary).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgItineraryNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("itinerary_id = ?", itinerary.ID).Delete(&model.ItineraryStop{}).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&itinerary).Error
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgItineraryDeleteFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Itinerary deleted successfully",
+		})
+	}
+}
+
+// Membuat link publik read-only untuk itinerary, link yang sudah ada tetap dipakai
+func ShareItinerary(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var itinerary model.Itinerary
+		if err := db.Where("id = ? AND user_id = ?", c.Param("id"), user.ID).First(&itinerary).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgItineraryNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if itinerary.ShareToken == nil {
+			token, err := helper.GenerateSecureToken(24)
+			if err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgShareLinkFailed}
+				return c.JSON(http.StatusInternalServerError, errorResponse)
+			}
+			if err := db.Model(&itinerary).Update("share_token", token).Error; err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgShareLinkFailed}
+				return c.JSON(http.StatusInternalServerError, errorResponse)
+			}
+			itinerary.ShareToken = &token
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":        http.StatusOK,
+			"error":       false,
+			"message":     "Itinerary shared successfully",
+			"share_token": *itinerary.ShareToken,
+			"share_path":  "/shared/itineraries/" + *itinerary.ShareToken,
+		})
+	}
+}
+
+// Mencabut link publik itinerary
+func UnshareItinerary(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		result = db.Model(&model.Itinerary{}).Where("id = ? AND user_id = ?", c.Param("id"), user.ID).Update("share_token", nil)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgShareLinkRevokeFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		if result.RowsAffected == 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgItineraryNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Share link revoked successfully",
+		})
+	}
+}
+
+// Mengekspor itinerary milik user sebagai file kalender ICS
+func ExportItineraryICS(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		itinerary, err := findItinerary(db, "id = ? AND user_id = ?", c.Param("id"), user.ID)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgItineraryNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=itinerary-%d.ics", itinerary.ID))
+		return c.Blob(http.StatusOK, helper.ICSContentType, []byte(itineraryICS(itinerary)))
+	}
+}
+
+// Menampilkan itinerary yang dibagikan melalui link publik tanpa login
+func GetSharedItinerary(db *gorm.DB) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		itinerary, err := findItinerary(db, "share_token = ?", c.Param("token"))
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgItineraryNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		// Lokasi pemilik tidak dipakai agar tidak terbaca dari link publik
+		summary, err := summarizeItinerary(itinerary, nil)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCarbonFootprintFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":  http.StatusOK,
+			"error": false,
+			"itinerary": map[string]interface{}{
+				"name":           itinerary.Name,
+				"start_date":     itinerary.StartDate,
+				"days":           itinerary.Days,
+				"transport_mode": itinerary.TransportMode,
+				"stops":          sharedItineraryStops(itinerary.Stops),
+			},
+			"summary": summary,
+		})
+	}
+}
+
+// Mengekspor itinerary yang dibagikan sebagai file kalender ICS tanpa login
+func ExportSharedItineraryICS(db *gorm.DB) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		itinerary, err := findItinerary(db, "share_token = ?", c.Param("token"))
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgItineraryNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		// Catatan pribadi pemilik tidak ikut diekspor dari link publik
+		for i := range itinerary.Stops {
+			itinerary.Stops[i].Notes = ""
+		}
+
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=itinerary-%d.ics", itinerary.ID))
+		return c.Blob(http.StatusOK, helper.ICSContentType, []byte(itineraryICS(itinerary)))
+	}
+}
@@ -0,0 +1,24 @@
+package controllers
+
+import (
+	"database/sql"
+	"gorm.io/gorm"
+)
+
+// withJobLock menjalankan fn dalam satu transaksi hanya jika lock MySQL bernama name berhasil diambil.
+// Job berkala berjalan di setiap instance aplikasi, instance yang tidak mendapat lock melewatkan putaran tersebut.
+func withJobLock(db *gorm.DB, name string, fn func(tx *gorm.DB) error) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		// GET_LOCK terikat pada koneksi, sehingga harus diambil dan dilepas melalui koneksi transaksi yang sama
+		var locked sql.NullInt64
+		if err := tx.Raw("SELECT GET_LOCK(?, 0)", name).Row().Scan(&locked); err != nil {
+			return err
+		}
+		if !locked.Valid || locked.Int64 != 1 {
+			return nil
+		}
+		defer tx.Exec("SELECT RELEASE_LOCK(?)", name)
+
+		return fn(tx)
+	})
+}
@@ -17,35 +17,35 @@ func CreateCooperationMessage(db *gorm.DB) echo.HandlerFunc {
 		var cooperationMessage model.CooperationMessage
 
 		if err := c.Bind(&cooperationMessage); err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidRequestBody}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		// Validasi name
 		if len(cooperationMessage.FirstName) < 3 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "first name harus minimal 3 huruf"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgFirstNameTooShort}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		// Validasi email format
 		if !helper.IsValidEmail(cooperationMessage.Email) {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Format email tidak valid"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidEmailFormat}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if !helper.IsValidPhoneNumber(cooperationMessage.PhoneNumber) {
 			if !helper.ContainsOnlyDigits(cooperationMessage.PhoneNumber) {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Phone number harus mengandung angka semua"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgPhoneNotNumeric}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			} else if len(cooperationMessage.PhoneNumber) < 10 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Phone number kurang dari 10 digit"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgPhoneTooShort}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 		}
 
 		// Validasi message
 		if len(cooperationMessage.Message) < 10 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Message harus minimal 10 huruf"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgMessageTooShort}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
@@ -56,18 +56,20 @@ func CreateCooperationMessage(db *gorm.DB) echo.HandlerFunc {
 		db.Create(&cooperationMessage)
 
 		// Kirim email ke admin
-		adminEmailSubject := "New Cooperation Message"
-		adminEmailBody := helper.GetCooperationEmailBody(cooperationMessage)
+		adminLocales := []string{helper.DefaultLocale}
+		adminEmailSubject := helper.Translate(adminLocales, helper.MsgEmailCooperationAdminSubject)
+		adminEmailBody := helper.GetCooperationEmailBody(adminLocales, cooperationMessage)
 		if err := helper.SendEmailToUser(adminEmail, adminEmailSubject, adminEmailBody); err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to send email to admin"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgAdminEmailFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
 		// Kirim email balasan ke pengirim pesan
-		userEmailSubject := "Your Cooperation Message"
-		userEmailBody := helper.GetUserCooperationEmailBody(cooperationMessage)
+		senderLocales := helper.RequestLocales(c)
+		userEmailSubject := helper.Translate(senderLocales, helper.MsgEmailCooperationUserSubject)
+		userEmailBody := helper.GetUserCooperationEmailBody(senderLocales, cooperationMessage)
 		if err := helper.SendEmailToUser(cooperationMessage.Email, userEmailSubject, userEmailBody); err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to send email to user"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserEmailFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -85,7 +87,7 @@ func GetCooperationMessagesByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFu
 		var cooperationMessages []model.CooperationMessage
 		result := db.Find(&cooperationMessages)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Internal Server Error"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgInternalError}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
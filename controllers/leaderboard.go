@@ -0,0 +1,345 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"myproject/helper"
+	"myproject/middleware"
+	"myproject/model"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type LeaderboardEntry struct {
+	Rank            int     `json:"rank"`
+	UserID          uint    `json:"user_id"`
+	Name            string  `json:"user_name"`
+	Profile         string  `json:"user_profile"`
+	Trips           int64   `json:"trips"`
+	TotalEmission   float64 `json:"total_emission"`
+	EmissionPerTrip float64 `json:"emission_per_trip"`
+}
+
+type ecoStats struct {
+	PaidTrips            int64
+	LowCarbonTrips       int64
+	PublicTransportTrips int64
+	GrossEmission        float64
+	Offsets              int64
+	OffsetGrams          float64
+}
+
+type ecoBadge struct {
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	earned      func(stats ecoStats) bool
+}
+
+// Batas emisi perjalanan rendah karbon dalam gram CO2 (10 kg)
+const lowCarbonTripGrams = 10000
+
+var ecoBadges = []ecoBadge{
+	{
+		Code:        "first_trip",
+		Name:        "Langkah Pertama",
+		Description: "Menyelesaikan perjalanan berbayar pertama",
+		earned:      func(s ecoStats) bool { return s.PaidTrips >= 1 },
+	},
+	{
+		Code:        "low_carbon_5",
+		Name:        "Jejak Ringan",
+		Description: "5 perjalanan dengan emisi di bawah 10 kg CO2",
+		earned:      func(s ecoStats) bool { return s.LowCarbonTrips >= 5 },
+	},
+	{
+		Code:        "public_transport_3",
+		Name:        "Penumpang Hijau",
+		Description: "3 perjalanan menggunakan bus, kereta atau kapal",
+		earned:      func(s ecoStats) bool { return s.PublicTransportTrips >= 3 },
+	},
+	{
+		Code:        "first_offset",
+		Name:        "Penyeimbang Karbon",
+		Description: "Membeli offset karbon pertama",
+		earned:      func(s ecoStats) bool { return s.Offsets >= 1 },
+	},
+	{
+		Code:        "carbon_neutral",
+		Name:        "Netral Karbon",
+		Description: "Seluruh emisi perjalanan sudah di-offset",
+		earned:      func(s ecoStats) bool { return s.PaidTrips > 0 && s.OffsetGrams >= s.GrossEmission },
+	},
+}
+
+// leaderboardSince mengembalikan awal periode leaderboard (minggu atau bulan berjalan), nil untuk all-time
+func leaderboardSince(period string) (*time.Time, error) {
+	now := time.Now()
+	switch period {
+	case "", "all":
+		return nil, nil
+	case "weekly":
+		offset := (int(now.Weekday()) + 6) % 7
+		start := time.Date(now.Year(), now.Month(), now.Day()-offset, 0, 0, 0, 0, now.Location())
+		return &start, nil
+	case "monthly":
+		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		return &start, nil
+	}
+	return nil, helper.NewMessageError(helper.MsgInvalidLeaderboardPeriod)
+}
+
+// computeEmissionLeaderboard mengurutkan user berdasarkan emisi rata-rata per perjalanan, user yang opt-out tidak diikutkan
+func computeEmissionLeaderboard(db *gorm.DB, since *time.Time, city string) ([]LeaderboardEntry, error) {
+	query := db.Model(&model.Ticket{}).
+		Select("users.id AS user_id, users.name AS name, users.photo_profil AS profile, COUNT(tickets.id) AS trips, SUM(tickets.carbon_footprint) AS total_emission, SUM(tickets.carbon_footprint) / COUNT(tickets.id) AS emission_per_trip").
+		Joins("JOIN users ON users.id = tickets.user_id").
+		Where("tickets.paid_status = ? AND users.leaderboard_opt_out = ? AND users.is_admin = ?", true, false, false)
+
+	if since != nil {
+		query = query.Where("tickets.checkin_booking >= ?", *since)
+	}
+
+	if city != "" {
+		query = query.Joins("JOIN wisata ON wisata.id = tickets.wisata_id").Where("wisata.kota = ?", city)
+	}
+
+	var entries []LeaderboardEntry
+	err := query.Group("users.id, users.name, users.photo_profil").
+		Order("emission_per_trip asc, trips desc").
+		Scan(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+	return entries, nil
+}
+
+func loadEcoStats(db *gorm.DB, userID uint) (ecoStats, error) {
+	var stats ecoStats
+	paidTickets := db.Model(&model.Ticket{}).Where("user_id = ? AND paid_status = ?", userID, true).Session(&gorm.Session{})
+
+	if err := paidTickets.Count(&stats.PaidTrips).Error; err != nil {
+		return stats, err
+	}
+	if err := paidTickets.Where("carbon_footprint < ?", lowCarbonTripGrams).Count(&stats.LowCarbonTrips).Error; err != nil {
+		return stats, err
+	}
+	if err := paidTickets.Where("transport_mode IN ?", []string{"bus", "train", "ferry"}).Count(&stats.PublicTransportTrips).Error; err != nil {
+		return stats, err
+	}
+	if err := paidTickets.Select("COALESCE(SUM(carbon_footprint), 0)").Row().Scan(&stats.GrossEmission); err != nil {
+		return stats, err
+	}
+
+	paidOffsets := db.Model(&model.CarbonOffset{}).Where("user_id = ? AND status = ?", userID, "paid").Session(&gorm.Session{})
+	if err := paidOffsets.Count(&stats.Offsets).Error; err != nil {
+		return stats, err
+	}
+	if err := paidOffsets.Select("COALESCE(SUM(offset_grams), 0)").Row().Scan(&stats.OffsetGrams); err != nil {
+		return stats, err
+	}
+
+	return stats, nil
+}
+
+// awardEcoBadges menyimpan badge baru yang memenuhi syarat dan mengirim notifikasi kepada user
+func awardEcoBadges(db *gorm.DB, userID uint) error {
+	stats, err := loadEcoStats(db, userID)
+	if err != nil {
+		return err
+	}
+
+	var owned []string
+	db.Model(&model.UserBadge{}).Where("user_id = ?", userID).Pluck("badge_code", &owned)
+	ownedSet := make(map[string]bool)
+	for _, code := range owned {
+		ownedSet[code] = true
+	}
+
+	for _, badge := range ecoBadges {
+		if ownedSet[badge.Code] || !badge.earned(stats) {
+			continue
+		}
+
+		// Badge dan notifikasinya disimpan bersama agar tidak ada badge tanpa notifikasi
+		err := db.Transaction(func(tx *gorm.DB) error {
+			now := time.Now()
+			userBadge := model.UserBadge{UserID: userID, BadgeCode: badge.Code, AwardedAt: &now}
+			if err := tx.Create(&userBadge).Error; err != nil {
+				return err
+			}
+
+			locales := userLocales(tx, userID)
+			notification := model.Notification{
+				UserID:  userID,
+				Title:   helper.Translate(locales, helper.MsgNotificationBadgeTitle),
+				Message: helper.Translate(locales, helper.MsgNotificationBadge, badge.Name, badge.Description),
+				Status:  "unread",
+				Type:    notificationTypeBadge,
+			}
+			return tx.Create(&notification).Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func GetEmissionLeaderboard(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		period := c.QueryParam("period")
+		city := c.QueryParam("city")
+		limit, err := strconv.Atoi(c.QueryParam("limit"))
+		if err != nil || limit < 1 {
+			limit = 10
+		}
+
+		since, err := leaderboardSince(period)
+		if err != nil {
+			errorResponse := helper.NewErrorResponse(http.StatusBadRequest, err)
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		entries, err := computeEmissionLeaderboard(db, since, city)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgLeaderboardFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var myRank *LeaderboardEntry
+		for i := range entries {
+			if entries[i].UserID == user.ID {
+				myRank = &entries[i]
+				break
+			}
+		}
+
+		topEntries := entries
+		if len(topEntries) > limit {
+			topEntries = topEntries[:limit]
+		}
+		if topEntries == nil {
+			topEntries = []LeaderboardEntry{}
+		}
+
+		if period == "" {
+			period = "all"
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":         http.StatusOK,
+			"error":        false,
+			"period":       period,
+			"city":         city,
+			"leaderboard":  topEntries,
+			"my_rank":      myRank,
+			"total_ranked": len(entries),
+			"is_opted_out": user.LeaderboardOptOut,
+		})
+	}
+}
+
+func GetUserBadges(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var userBadges []model.UserBadge
+		db.Where("user_id = ?", user.ID).Find(&userBadges)
+		awardedAt := make(map[string]*time.Time)
+		for _, userBadge := range userBadges {
+			awardedAt[userBadge.BadgeCode] = userBadge.AwardedAt
+		}
+
+		var badges []map[string]interface{}
+		for _, badge := range ecoBadges {
+			badges = append(badges, map[string]interface{}{
+				"code":        badge.Code,
+				"name":        badge.Name,
+				"description": badge.Description,
+				"earned":      awardedAt[badge.Code] != nil,
+				"awarded_at":  awardedAt[badge.Code],
+			})
+		}
+
+		var rank *LeaderboardEntry
+		if !user.LeaderboardOptOut {
+			entries, err := computeEmissionLeaderboard(db, nil, "")
+			if err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgLeaderboardFailed}
+				return c.JSON(http.StatusInternalServerError, errorResponse)
+			}
+			for i := range entries {
+				if entries[i].UserID == user.ID {
+					rank = &entries[i]
+					break
+				}
+			}
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":         http.StatusOK,
+			"error":        false,
+			"message":      "User badges retrieved successfully",
+			"badges":       badges,
+			"total_earned": len(userBadges),
+			"rank":         rank,
+			"is_opted_out": user.LeaderboardOptOut,
+		})
+	}
+}
+
+func UpdateLeaderboardPrivacy(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var requestBody struct {
+			OptOut bool `json:"opt_out"`
+		}
+		if err := c.Bind(&requestBody); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidRequestBody}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if err := db.Model(&user).Update("leaderboard_opt_out", requestBody.OptOut).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgLeaderboardPrivacyUpdateFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":         http.StatusOK,
+			"error":        false,
+			"message":      "Leaderboard privacy updated successfully",
+			"is_opted_out": requestBody.OptOut,
+		})
+	}
+}
@@ -1,14 +1,110 @@
 package controllers
 
 import (
+	"fmt"
 	"github.com/labstack/echo/v4"
 	"gorm.io/gorm"
+	"log"
 	"myproject/helper"
 	"myproject/middleware"
 	"myproject/model"
 	"net/http"
+	"net/url"
+	"time"
 )
 
+// categoryRequest adalah body create/update kategori dalam JSON atau form, field yang tidak dikirim tidak diubah.
+// Ikon dapat dikirim sebagai URL pada field icon, atau sebagai file gambar pada form field icon.
+type categoryRequest struct {
+	CategoryName *string `json:"category_name" form:"category_name"`
+	ParentID     *uint   `json:"parent_id" form:"parent_id"` // 0 menjadikan kategori sebagai kategori utama
+	Icon         *string `json:"icon" form:"icon"`
+	SortOrder    *int    `json:"sort_order" form:"sort_order"`
+	IsActive     *bool   `json:"is_active" form:"is_active"`
+	Theme        *string `json:"theme" form:"theme"` // Kosong berarti kategori tidak dipakai kuis preferensi
+}
+
+// isHTTPURL memeriksa apakah nilai adalah URL http atau https yang lengkap
+func isHTTPURL(value string) bool {
+	parsed, err := url.ParseRequestURI(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// uploadCategoryIcon mengunggah file ikon dari form ke GCS, URL kosong berarti tidak ada file yang dikirim
+func uploadCategoryIcon(c echo.Context) (string, error) {
+	file, err := c.FormFile("icon")
+	if err != nil {
+		return "", nil
+	}
+	imageName := fmt.Sprintf("categories/icon_%d.jpg", time.Now().UnixNano())
+	return helper.UploadImageFileToGCS(file, maxMediaImageSize, imageName)
+}
+
+// deleteCategoryIcon menghapus ikon lama dari GCS jika ikon tersebut diunggah ke bucket aplikasi
+func deleteCategoryIcon(iconURL string) {
+	if objectName := helper.GCSObjectName(iconURL); objectName != "" {
+		if err := helper.DeleteObjectFromGCS(objectName); err != nil {
+			log.Println("Gagal menghapus ikon kategori dari GCS:", err)
+		}
+	}
+}
+
+// applyTo menyalin induk, ikon, urutan, status aktif dan tema ke kategori
+func (r categoryRequest) applyTo(db *gorm.DB, category *model.Category) helper.MessageCode {
+	if r.ParentID != nil {
+		if *r.ParentID == 0 {
+			category.ParentID = nil
+		} else if validCategoryParent(db, category.ID, *r.ParentID) {
+			parentID := *r.ParentID
+			category.ParentID = &parentID
+		} else {
+			return helper.MsgCategoryParentInvalid
+		}
+	}
+	if r.Icon != nil {
+		if *r.Icon != "" && !isHTTPURL(*r.Icon) {
+			return helper.MsgCategoryIconInvalid
+		}
+		category.Icon = *r.Icon
+	}
+	if r.SortOrder != nil {
+		category.SortOrder = *r.SortOrder
+	}
+	if r.IsActive != nil {
+		category.IsActive = *r.IsActive
+	}
+	if r.Theme != nil {
+		if *r.Theme != "" && !containsString(preferenceThemes, *r.Theme) {
+			return helper.MsgCategoryThemeInvalid
+		}
+		category.Theme = *r.Theme
+	}
+	return ""
+}
+
+// categoryUsage adalah jumlah data yang masih merujuk ke sebuah kategori
+type categoryUsage struct {
+	Subcategories int64 `json:"subcategories"`
+	Wisata        int64 `json:"wisata"`
+	Users         int64 `json:"users"`
+}
+
+func (u categoryUsage) total() int64 {
+	return u.Subcategories + u.Wisata + u.Users
+}
+
+// countCategoryUsage menghitung subkategori, tempat wisata dan user yang merujuk ke kategori.
+// Tempat wisata dan user terarsip ikut dihitung karena masih dapat dipulihkan.
+func countCategoryUsage(db *gorm.DB, categoryID uint) categoryUsage {
+	var usage categoryUsage
+	db.Model(&model.Category{}).Where("parent_id = ?", categoryID).Count(&usage.Subcategories)
+	db.Unscoped().Model(&model.Wisata{}).Where("category_id = ?", categoryID).Count(&usage.Wisata)
+	db.Unscoped().Model(&model.User{}).
+		Where("category_id = ? OR id IN (SELECT user_id FROM user_category_preferences WHERE category_id = ?)", categoryID, categoryID).
+		Count(&usage.Users)
+	return usage
+}
+
 func CreateCategoryByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
@@ -16,30 +112,57 @@ func CreateCategoryByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			return err
 		}
 
-		var category model.Category
-		if err := c.Bind(&category); err != nil {
-			errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, Message: "Invalid request body"}
+		var request categoryRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, ErrorCode: helper.MsgInvalidRequestBody}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
+		var category model.Category
+		if request.CategoryName != nil {
+			category.CategoryName = *request.CategoryName
+		}
+
 		if len(category.CategoryName) < 5 {
-			errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, Message: "Category name must be at least 5 characters"}
+			errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, ErrorCode: helper.MsgCategoryNameTooShort}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if len(category.CategoryName) > 30 {
-			errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, Message: "Category name maximum at least 30 characters"}
+			errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, ErrorCode: helper.MsgCategoryNameTooLong}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		existingCategory := model.Category{}
 		if err := db.Where("category_name = ?", category.CategoryName).First(&existingCategory).Error; err == nil {
 			// Category dengan nama tersebut sudah ada
-			errorResponse := helper.Response{Code: http.StatusConflict, Error: true, Message: "Category with this name already exists"}
+			errorResponse := helper.Response{Code: http.StatusConflict, Error: true, ErrorCode: helper.MsgCategoryNameExists}
 			return c.JSON(http.StatusConflict, errorResponse)
 		}
 
-		db.Create(&category)
+		category.IsActive = true
+		if errorCode := request.applyTo(db, &category); errorCode != "" {
+			errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, ErrorCode: errorCode}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		iconURL, err := uploadCategoryIcon(c)
+		if err != nil {
+			return uploadImageErrorResponse(c, err)
+		}
+		if iconURL != "" {
+			category.Icon = iconURL
+		}
+
+		if err := db.Create(&category).Error; err != nil {
+			deleteCategoryIcon(iconURL)
+			errorResponse := helper.Response{Code: http.StatusInternalServerError, Error: true, ErrorCode: helper.MsgCategoryCreateFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		if !category.IsActive {
+			// Nilai false tidak ikut tersimpan saat Create karena kolom is_active memiliki default true
+			db.Model(&category).Update("is_active", false)
+		}
 
 		successResponse := helper.Response{Code: http.StatusCreated, Error: false, Message: "Category created successfully", Category: &category}
 		return c.JSON(http.StatusCreated, successResponse)
@@ -55,44 +178,69 @@ func UpdateCategoryByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		categoryID, err := helper.ConvertParamToUint(c.Param("id"))
 		if err != nil {
-			errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, Message: "Invalid category ID"}
+			errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, ErrorCode: helper.MsgInvalidCategoryID}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		var existingCategory model.Category
 		result := db.First(&existingCategory, categoryID)
 		if result.Error != nil {
-			errorResponse := helper.Response{Code: http.StatusNotFound, Error: true, Message: "Category not found"}
+			errorResponse := helper.Response{Code: http.StatusNotFound, Error: true, ErrorCode: helper.MsgCategoryNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
-		var updatedCategory model.Category
-		if err := c.Bind(&updatedCategory); err != nil {
-			errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, Message: "Invalid request body"}
+		var request categoryRequest
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, ErrorCode: helper.MsgInvalidRequestBody}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
-		if len(updatedCategory.CategoryName) < 5 {
-			errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, Message: "Category name must be at least 5 characters"}
-			return c.JSON(http.StatusBadRequest, errorResponse)
+		if request.CategoryName != nil {
+			categoryName := *request.CategoryName
+			if len(categoryName) < 5 {
+				errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, ErrorCode: helper.MsgCategoryNameTooShort}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+
+			if len(categoryName) > 30 {
+				errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, ErrorCode: helper.MsgCategoryNameTooLong}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+
+			if categoryName != existingCategory.CategoryName {
+				var categoryWithSameName model.Category
+				if err := db.Where("category_name = ?", categoryName).First(&categoryWithSameName).Error; err == nil {
+					// Category dengan nama tersebut sudah ada
+					errorResponse := helper.Response{Code: http.StatusConflict, Error: true, ErrorCode: helper.MsgCategoryNameExists}
+					return c.JSON(http.StatusConflict, errorResponse)
+				}
+			}
+			existingCategory.CategoryName = categoryName
 		}
 
-		if len(updatedCategory.CategoryName) > 30 {
-			errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, Message: "Category maximum at least 30 characters"}
+		oldIcon := existingCategory.Icon
+		if errorCode := request.applyTo(db, &existingCategory); errorCode != "" {
+			errorResponse := helper.Response{Code: http.StatusBadRequest, Error: true, ErrorCode: errorCode}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
-		if updatedCategory.CategoryName != existingCategory.CategoryName {
-			var categoryWithSameName model.Category
-			if err := db.Where("category_name = ?", updatedCategory.CategoryName).First(&categoryWithSameName).Error; err == nil {
-				// Category dengan nama tersebut sudah ada
-				errorResponse := helper.Response{Code: http.StatusConflict, Error: true, Message: "Category with this name already exists"}
-				return c.JSON(http.StatusConflict, errorResponse)
-			}
+		iconURL, err := uploadCategoryIcon(c)
+		if err != nil {
+			return uploadImageErrorResponse(c, err)
+		}
+		if iconURL != "" {
+			existingCategory.Icon = iconURL
 		}
 
 		// Update only the specified fields
-		db.Model(&existingCategory).Updates(updatedCategory)
+		if err := db.Save(&existingCategory).Error; err != nil {
+			deleteCategoryIcon(iconURL)
+			errorResponse := helper.Response{Code: http.StatusInternalServerError, Error: true, ErrorCode: helper.MsgCategoryUpdateFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		if existingCategory.Icon != oldIcon {
+			deleteCategoryIcon(oldIcon)
+		}
 
 		successResponse := helper.Response{Code: http.StatusOK, Error: false, Message: "Category updated successfully", Category: &existingCategory}
 		return c.JSON(http.StatusOK, successResponse)
@@ -108,19 +256,143 @@ func DeleteCategoryByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		categoryID, err := helper.ConvertParamToUint(c.Param("id"))
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid category ID"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidCategoryID}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		var existingCategory model.Category
 		result := db.First(&existingCategory, categoryID)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Category not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgCategoryNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
-		db.Delete(&existingCategory)
+		// Kategori yang masih dipakai harus dipindahkan atau digabungkan terlebih dahulu agar tidak ada data yatim
+		usage := countCategoryUsage(db, existingCategory.ID)
+		if usage.total() > 0 {
+			return c.JSON(http.StatusConflict, map[string]interface{}{
+				"code":       http.StatusConflict,
+				"error":      true,
+				"error_code": helper.MsgCategoryInUse,
+				"message":    helper.Translate(requestLocales(c), helper.MsgCategoryInUse, usage.Subcategories, usage.Wisata, usage.Users),
+				"usage":      usage,
+			})
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Delete(&existingCategory).Error; err != nil {
+				return err
+			}
+			return tx.Where("category_id = ?", existingCategory.ID).Delete(&model.CategoryTranslation{}).Error
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCategoryDeleteFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		deleteCategoryIcon(existingCategory.Icon)
 
 		return c.JSON(http.StatusOK, map[string]interface{}{"code": http.StatusOK, "error": false, "message": "Category deleted successfully"})
 	}
 }
+
+// Menggabungkan kategori ke kategori tujuan: tempat wisata, kategori kesukaan user dan subkategori dipindahkan lalu kategori asal dihapus
+func MergeCategoryByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		categoryID, err := helper.ConvertParamToUint(c.Param("id"))
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidCategoryID}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var request struct {
+			TargetCategoryID uint `json:"target_category_id"`
+		}
+		if err := c.Bind(&request); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidRequestBody}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var source, target model.Category
+		if err := db.First(&source, categoryID).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgCategoryNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+		if err := db.First(&target, request.TargetCategoryID).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgCategoryNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		// Subkategori asal pindah ke kategori tujuan, sehingga tujuan tidak boleh berada di bawah kategori asal
+		if !validCategoryParent(db, source.ID, target.ID) {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgCategoryMergeTargetInvalid}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var moved categoryUsage
+		err = db.Transaction(func(tx *gorm.DB) error {
+			// Tempat wisata dan user terarsip ikut dipindah agar tetap valid saat dipulihkan
+			result := tx.Unscoped().Model(&model.Wisata{}).Where("category_id = ?", source.ID).Update("category_id", target.ID)
+			if result.Error != nil {
+				return result.Error
+			}
+			moved.Wisata = result.RowsAffected
+
+			result = tx.Unscoped().Model(&model.User{}).Where("category_id = ?", source.ID).
+				Updates(map[string]interface{}{"category_id": target.ID, "category_kesukaan": target.CategoryName})
+			if result.Error != nil {
+				return result.Error
+			}
+			moved.Users = result.RowsAffected
+
+			// Preferensi user (termasuk user terarsip) yang sudah memiliki kategori tujuan digabung dengan mengambil bobot terbesar
+			var preferences []model.UserCategoryPreference
+			if err := tx.Where("category_id = ?", source.ID).Find(&preferences).Error; err != nil {
+				return err
+			}
+			for _, preference := range preferences {
+				var existing model.UserCategoryPreference
+				if err := tx.Where("user_id = ? AND category_id = ?", preference.UserID, target.ID).First(&existing).Error; err == nil {
+					if preference.Weight > existing.Weight {
+						if err := tx.Model(&existing).Update("weight", preference.Weight).Error; err != nil {
+							return err
+						}
+					}
+					if err := tx.Delete(&preference).Error; err != nil {
+						return err
+					}
+				} else if err := tx.Model(&preference).Update("category_id", target.ID).Error; err != nil {
+					return err
+				}
+			}
+
+			result = tx.Model(&model.Category{}).Where("parent_id = ?", source.ID).Update("parent_id", target.ID)
+			if result.Error != nil {
+				return result.Error
+			}
+			moved.Subcategories = result.RowsAffected
+
+			if err := tx.Where("category_id = ?", source.ID).Delete(&model.CategoryTranslation{}).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&source).Error
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCategoryMergeFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		deleteCategoryIcon(source.Icon)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":     http.StatusOK,
+			"error":    false,
+			"message":  "Category merged successfully",
+			"category": target,
+			"moved":    moved,
+		})
+	}
+}
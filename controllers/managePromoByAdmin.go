@@ -5,6 +5,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"gorm.io/gorm"
 	"io"
+	"log"
 	"myproject/helper"
 	"myproject/middleware"
 	"myproject/model"
@@ -29,87 +30,87 @@ func CreatePromo(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		peraturan := c.FormValue("peraturan")
 
 		if len(title) < 5 {
-			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Title must be at least 5 characters"})
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgTitleMin5})
 		}
 
 		if len(title) > 100 {
-			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Title cannot exceed 100 characters"})
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgTitleTooLong})
 		}
 
 		if len(namaPromo) < 5 {
-			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Nama Promo must be at least 5 characters"})
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgPromoNameTooShort})
 		}
 
 		if len(namaPromo) > 100 {
-			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Nama Promo cannot exceed 100 characters"})
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgPromoNameTooLong})
 		}
 
 		if len(kodeVoucher) < 5 {
-			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Kode voucher must be at least 5 characters"})
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgVoucherCodeTooShort})
 		}
 
 		if len(kodeVoucher) > 40 {
-			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Kode Voucher cannot exceed 40 characters"})
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgVoucherCodeTooLong})
 		}
 
 		if len(deskripsi) < 10 {
-			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Deskripsi must be at least 10 characters"})
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDescriptionTooShort})
 		}
 
 		if len(deskripsi) > 2000 {
-			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Deskripsi cannot exceed 2000 characters"})
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDescriptionTooLong})
 		}
 
 		// Validasi minimal 10 huruf untuk peraturan
 		if len(peraturan) < 10 {
-			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Peraturan must be at least 10 characters"})
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgRulesTooShort})
 		}
 
 		if len(peraturan) > 2000 {
-			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Peraturan cannot exceed 2000 characters"})
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgRulesTooLong})
 		}
 
 		// Validasi agar tidak menggunakan kode_voucher yang sudah ada pada promo_model.go
 		existingKodeVoucher := model.Promo{}
-		if err := db.Where("kode_voucher = ?", kodeVoucher).First(&existingKodeVoucher).Error; err == nil {
-			return c.JSON(http.StatusConflict, helper.ErrorResponse{Code: http.StatusConflict, Message: "Promo with this name for kode_voucher already exists"})
+		if err := db.Unscoped().Where("kode_voucher = ?", kodeVoucher).First(&existingKodeVoucher).Error; err == nil {
+			return c.JSON(http.StatusConflict, helper.ErrorResponse{Code: http.StatusConflict, ErrorCode: helper.MsgPromoVoucherExists})
 		}
 
 		// Validasi jumlah_potongan_persen harus di atas 0
 		jumlahPotongan, err := strconv.Atoi(jumlahPotonganPersenStr)
 		if err != nil || jumlahPotongan <= 0 {
-			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid jumlah_potongan_persen"})
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidDiscountPercent})
 		}
 
 		if jumlahPotongan > 100 {
-			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid jumlah_potongan_persen. It must not exceed 100"})
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDiscountPercentTooHigh})
 		}
 
 		statusAktif, err := strconv.ParseBool(statusAktifStr)
 		if err != nil {
-			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid status_aktif"})
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidStatusAktif})
 		}
 
 		tanggalKadaluarsa, err := time.Parse("2006-01-02", tanggalKadaluarsaStr)
 		if err != nil {
-			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid tanggal_kadaluarsa format. Use YYYY-MM-DD"})
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidExpiryDateFormat})
 		}
 
 		// Validasi agar tidak menggunakan title yang sudah ada pada promo_model.go
 		existingTitle := model.Promo{}
 		if err := db.Where("title = ?", title).First(&existingTitle).Error; err == nil {
-			return c.JSON(http.StatusConflict, helper.ErrorResponse{Code: http.StatusConflict, Message: "Promo with this title already exists"})
+			return c.JSON(http.StatusConflict, helper.ErrorResponse{Code: http.StatusConflict, ErrorCode: helper.MsgPromoTitleExists})
 		}
 
 		// Validasi agar tidak menggunakan nama_promo yang sudah ada pada promo_model.go
 		existingNamaPromo := model.Promo{}
 		if err := db.Where("nama_promo = ?", namaPromo).First(&existingNamaPromo).Error; err == nil {
-			return c.JSON(http.StatusConflict, helper.ErrorResponse{Code: http.StatusConflict, Message: "Promo with this nama_promo already exists"})
+			return c.JSON(http.StatusConflict, helper.ErrorResponse{Code: http.StatusConflict, ErrorCode: helper.MsgPromoNameExists})
 		}
 
 		// Validasi tanggal_kadaluarsa tidak boleh tanggal yang sudah lewat
 		if time.Now().After(tanggalKadaluarsa) {
-			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid tanggal_kadaluarsa. It must be a future date"})
+			return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgExpiryDatePast})
 		}
 
 		randomString := helper.GenerateRandomString(10)
@@ -127,7 +128,7 @@ func CreatePromo(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		imageFile, err := c.FormFile("image_voucher")
 		if err == nil {
 			if !helper.IsImageFile(imageFile) {
-				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid image format"})
+				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidImageFormat})
 			}
 
 			if !helper.IsFileSizeExceeds(imageFile, 5*1024*1024) {
@@ -148,26 +149,15 @@ func CreatePromo(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		}
 
 		if err := db.Create(&newPromo).Error; err != nil {
-			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to create promo"})
+			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgPromoCreateFailed})
 		}
 
-		// Notifikasi kepada semua pengguna
-		var users []model.User
-		db.Find(&users)
-
-		notificationMessage := fmt.Sprintf("Ada promo menarik buat kamu yang suka healing")
-
-		for _, user := range users {
-			notification := model.Notification{
-				UserID:  user.ID,
-				Message: notificationMessage,
-				Title:   fmt.Sprintf("promo %s", newPromo.Title),
-				IsRead:  false,
-				Status:  "unread",
-				PromoID: newPromo.ID, // Tambahkan ID promo ke notifikasi
+		// Notifikasi hanya kepada pengguna yang memiliki tempat wisata favorit, dikirim di latar belakang
+		go func(promo model.Promo) {
+			if err := notifyPromoToFavoriteUsers(db, promo); err != nil {
+				log.Println("Gagal mengirim notifikasi promo:", err)
 			}
-			db.Create(&notification)
-		}
+		}(newPromo)
 
 		return c.JSON(http.StatusOK, map[string]interface{}{
 			"code":       http.StatusOK,
@@ -188,9 +178,11 @@ func EditPromo(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		var existingPromo model.Promo
 		if err := db.First(&existingPromo, promoID).Error; err != nil {
-			return c.JSON(http.StatusNotFound, helper.ErrorResponse{Code: http.StatusNotFound, Message: "Promo not found"})
+			return c.JSON(http.StatusNotFound, helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgPromoNotFound})
 		}
 
+		wasActive := existingPromo.StatusAktif && !time.Now().After(existingPromo.TanggalKadaluarsa)
+
 		title := c.FormValue("title")
 		kodeVoucher := c.FormValue("kode_voucher")
 		jumlahPotonganPersen := c.FormValue("jumlah_potongan_persen")
@@ -202,16 +194,16 @@ func EditPromo(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		if title != "" && title != existingPromo.Title {
 			if len(title) < 5 {
-				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Title must have at least 5 characters"})
+				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgTitleMin5})
 			}
 
 			if len(title) > 100 {
-				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Title cannot exceed 100 characters"})
+				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgTitleTooLong})
 			}
 
 			existingTitle := model.Promo{}
 			if err := db.Where("title = ?", title).First(&existingTitle).Error; err == nil {
-				return c.JSON(http.StatusConflict, helper.ErrorResponse{Code: http.StatusConflict, Message: "Promo with this title already exists"})
+				return c.JSON(http.StatusConflict, helper.ErrorResponse{Code: http.StatusConflict, ErrorCode: helper.MsgPromoTitleExists})
 			}
 
 			existingPromo.Title = title
@@ -219,16 +211,16 @@ func EditPromo(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		if namaPromo != "" && namaPromo != existingPromo.NamaPromo {
 			if len(namaPromo) < 5 {
-				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "NamaPromo must have at least 5 characters"})
+				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgPromoNameTooShort})
 			}
 
 			if len(namaPromo) > 100 {
-				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Nama Promo cannot exceed 100 characters"})
+				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgPromoNameTooLong})
 			}
 
 			existingNamaPromo := model.Promo{}
 			if err := db.Where("nama_promo = ?", namaPromo).First(&existingNamaPromo).Error; err == nil {
-				return c.JSON(http.StatusConflict, helper.ErrorResponse{Code: http.StatusConflict, Message: "Promo with this nama_promo already exists"})
+				return c.JSON(http.StatusConflict, helper.ErrorResponse{Code: http.StatusConflict, ErrorCode: helper.MsgPromoNameExists})
 			}
 
 			existingPromo.NamaPromo = namaPromo
@@ -236,16 +228,16 @@ func EditPromo(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		if kodeVoucher != "" && kodeVoucher != existingPromo.KodeVoucher {
 			if len(kodeVoucher) < 5 {
-				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "KodeVoucher must have at least 5 characters"})
+				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgVoucherCodeTooShort})
 			}
 
 			if len(kodeVoucher) > 40 {
-				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Kode Voucher cannot exceed 40 characters"})
+				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgVoucherCodeTooLong})
 			}
 
 			existingKodeVoucher := model.Promo{}
-			if err := db.Where("kode_voucher = ?", kodeVoucher).First(&existingKodeVoucher).Error; err == nil {
-				return c.JSON(http.StatusConflict, helper.ErrorResponse{Code: http.StatusConflict, Message: "Promo with this kode_voucher already exists"})
+			if err := db.Unscoped().Where("kode_voucher = ?", kodeVoucher).First(&existingKodeVoucher).Error; err == nil {
+				return c.JSON(http.StatusConflict, helper.ErrorResponse{Code: http.StatusConflict, ErrorCode: helper.MsgPromoVoucherExists})
 			}
 
 			existingPromo.KodeVoucher = kodeVoucher
@@ -254,15 +246,15 @@ func EditPromo(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		if jumlahPotonganPersen != "" {
 			jumlahPotongan, err := strconv.Atoi(jumlahPotonganPersen)
 			if err != nil {
-				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid jumlah_potongan_persen"})
+				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidDiscountPercent})
 			}
 
 			if jumlahPotongan <= 0 {
-				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "JumlahPotonganPersen must be greater than 0"})
+				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDiscountPercentPositive})
 			}
 
 			if jumlahPotongan > 100 {
-				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid jumlah_potongan_persen. It must not exceed 100"})
+				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDiscountPercentTooHigh})
 			}
 
 			existingPromo.JumlahPotonganPersen = jumlahPotongan
@@ -270,11 +262,11 @@ func EditPromo(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		if deskripsi != "" && deskripsi != existingPromo.Deskripsi {
 			if len(deskripsi) < 10 {
-				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Deskripsi must be at least 10 characters"})
+				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDescriptionTooShort})
 			}
 
 			if len(deskripsi) > 2000 {
-				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Deskripsi cannot exceed 2000 characters"})
+				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDescriptionTooLong})
 			}
 
 			existingPromo.Deskripsi = deskripsi
@@ -282,11 +274,11 @@ func EditPromo(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		if peraturan != "" && peraturan != existingPromo.Peraturan {
 			if len(peraturan) < 10 {
-				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Peraturan must be at least 10 characters"})
+				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgRulesTooShort})
 			}
 
 			if len(peraturan) > 2000 {
-				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Peraturan cannot exceed 2000 characters"})
+				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgRulesTooLong})
 			}
 			existingPromo.Peraturan = peraturan
 		}
@@ -294,10 +286,10 @@ func EditPromo(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		if tanggalKadaluarsaStr != "" {
 			tanggalKadaluarsa, err := time.Parse("2006-01-02", tanggalKadaluarsaStr)
 			if err != nil {
-				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid tanggal_kadaluarsa format. Use YYYY-MM-DD"})
+				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidExpiryDateFormat})
 			}
 			if time.Now().After(tanggalKadaluarsa) {
-				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid tanggal_kadaluarsa. It must be a future date"})
+				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgExpiryDatePast})
 			}
 			existingPromo.TanggalKadaluarsa = tanggalKadaluarsa
 		}
@@ -305,7 +297,7 @@ func EditPromo(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		if statusAktifStr != "" {
 			statusAktif, err := strconv.ParseBool(statusAktifStr)
 			if err != nil {
-				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid status_aktif"})
+				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidStatusAktif})
 			}
 			existingPromo.StatusAktif = statusAktif
 		}
@@ -313,7 +305,7 @@ func EditPromo(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		imageFile, err := c.FormFile("image_voucher")
 		if err == nil {
 			if !helper.IsImageFile(imageFile) {
-				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid image format"})
+				return c.JSON(http.StatusBadRequest, helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidImageFormat})
 			}
 
 			if !helper.IsFileSizeExceeds(imageFile, 5*1024*1024) {
@@ -334,7 +326,16 @@ func EditPromo(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		}
 
 		if err := db.Save(&existingPromo).Error; err != nil {
-			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update promo"})
+			return c.JSON(http.StatusInternalServerError, helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgPromoUpdateFailed})
+		}
+
+		// Promo yang baru diaktifkan dikirim ke pengguna yang memiliki tempat wisata favorit
+		if !wasActive {
+			go func(promo model.Promo) {
+				if err := notifyPromoToFavoriteUsers(db, promo); err != nil {
+					log.Println("Gagal mengirim notifikasi promo:", err)
+				}
+			}(existingPromo)
 		}
 
 		return c.JSON(http.StatusOK, map[string]interface{}{
@@ -355,19 +356,55 @@ func DeletePromoByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		promoID, err := helper.ConvertParamToUint(c.Param("id"))
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid promo ID"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidPromoID}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		var existingPromo model.Promo
 		result := db.First(&existingPromo, promoID)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Promo not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgPromoNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
+		// Promo diarsipkan, terjemahannya baru dihapus oleh job purge setelah masa retensi
 		db.Delete(&existingPromo)
 
 		return c.JSON(http.StatusOK, map[string]interface{}{"code": http.StatusOK, "error": false, "message": "Promo deleted successfully"})
 	}
 }
+
+// Memulihkan promo yang diarsipkan
+func RestorePromoByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		promoID, err := helper.ConvertParamToUint(c.Param("id"))
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidPromoID}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var archivedPromo model.Promo
+		if err := db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", promoID).First(&archivedPromo).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgArchivedPromoNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if err := db.Unscoped().Model(&archivedPromo).Update("deleted_at", nil).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgRestoreFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		archivedPromo.DeletedAt = gorm.DeletedAt{}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Promo restored successfully",
+			"promo_data": archivedPromo,
+		})
+	}
+}
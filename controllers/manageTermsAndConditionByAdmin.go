@@ -18,35 +18,35 @@ func CreateTermCondition(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		var term model.TermCondition
 		if err := c.Bind(&term); err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidRequestBody}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		// Validasi panjang karakter input
 		if len(term.Name) < 5 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Name should be at least 5 characters"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgNameMin5}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if len(term.Name) > 100 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Name maximum at least 100 characters"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgNameTooLong}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if len(term.Description) < 10 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Description should be at least 10 characters"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDescriptionTooShort}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if len(term.Description) > 2000 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Description maximum at least 2000 characters"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDescriptionTooLong}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		// Check if tnc_name already exists
 		var existingTerm model.TermCondition
 		if err := db.Where("name = ?", term.Name).First(&existingTerm).Error; err == nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "Term and condition with the same name already exists"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, ErrorCode: helper.MsgTermConditionExists}
 			return c.JSON(http.StatusConflict, errorResponse)
 		}
 
@@ -69,7 +69,7 @@ func EditTermCondition(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		term := model.TermCondition{}
 		result := db.First(&term, tncID)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Term and condition not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgTermConditionNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
@@ -82,19 +82,19 @@ func EditTermCondition(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		// Validation for Name
 		if req.Name != "" {
 			if len(req.Name) < 5 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Name should be at least 5 characters"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgNameMin5}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 
 			if len(req.Name) > 100 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Name maximum at least 100 characters"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgNameTooLong}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 
 			// Check if the new name already exists
 			var existingTerm model.TermCondition
 			if err := db.Where("name = ? AND id != ?", req.Name, tncID).First(&existingTerm).Error; err == nil {
-				errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "Term and condition with the same name already exists"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusConflict, ErrorCode: helper.MsgTermConditionExists}
 				return c.JSON(http.StatusConflict, errorResponse)
 			}
 
@@ -104,12 +104,12 @@ func EditTermCondition(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		// Validation for Description
 		if req.Description != "" {
 			if len(req.Description) < 10 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Description should be at least 10 characters"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDescriptionTooShort}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 
 			if len(req.Description) > 2000 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Description maximum at least 2000 characters"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDescriptionTooLong}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 
@@ -134,11 +134,12 @@ func DeleteTermCondition(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		term := model.TermCondition{}
 		result := db.First(&term, tncID)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Term and condition not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgTermConditionNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
 		db.Delete(&term)
+		db.Where("term_condition_id = ?", term.ID).Delete(&model.TermConditionTranslation{})
 
 		return c.JSON(http.StatusOK, map[string]interface{}{"code": http.StatusOK, "error": false, "message": "Term and condition deleted successfully"})
 	}
@@ -1,7 +1,6 @@
 package controllers
 
 import (
-	"fmt"
 	"github.com/labstack/echo/v4"
 	"gorm.io/gorm"
 	"myproject/helper"
@@ -48,7 +47,7 @@ func GetAllTicketsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		searchQuery := c.QueryParam("search")
 		page, perPage := helper.GetPaginationParams(c)
 
-		query := db.Model(&model.Ticket{}).Order("created_at DESC")
+		query := archivedFilter(c, db.Model(&model.Ticket{}).Order("tickets.created_at DESC"), "tickets")
 
 		if searchQuery != "" {
 			query = query.
@@ -79,10 +78,10 @@ func GetAllTicketsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		// Iterate through each ticket and add additional information
 		for _, ticket := range tickets {
 			var wisata model.Wisata
-			db.First(&wisata, ticket.WisataID)
+			db.Unscoped().First(&wisata, ticket.WisataID)
 
 			var user model.User
-			db.First(&user, ticket.UserID)
+			db.Unscoped().First(&user, ticket.UserID)
 
 			ticketDetail := map[string]interface{}{
 				"id":                 ticket.ID,
@@ -105,6 +104,7 @@ func GetAllTicketsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 				"checkin_booking":    ticket.CheckinBooking,
 				"kode_voucher":       ticket.KodeVoucher,
 				"points_earned":      ticket.PointsEarned,
+				"deleted_at":         ticket.DeletedAt,
 			}
 
 			ticketDetails = append(ticketDetails, ticketDetail)
@@ -142,23 +142,23 @@ func GetTicketByInvoiceNumber(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		}
 
 		var ticket model.Ticket
-		result := db.Where("invoice_number = ?", invoiceNumber).First(&ticket)
+		result := db.Unscoped().Where("invoice_number = ?", invoiceNumber).First(&ticket)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Ticket not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgTicketNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
 		var userDetail model.User
-		userResult := db.First(&userDetail, ticket.UserID)
+		userResult := db.Unscoped().First(&userDetail, ticket.UserID)
 		if userResult.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch user data"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
 		var wisata model.Wisata
-		wisataResult := db.First(&wisata, ticket.WisataID)
+		wisataResult := db.Unscoped().First(&wisata, ticket.WisataID)
 		if wisataResult.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch event data"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgEventDataFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -202,43 +202,46 @@ func UpdatePaidStatus(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		}
 
 		if err := c.Bind(&requestBody); err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid request body"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidRequestBody}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		var ticket model.Ticket
 		result := db.Where("invoice_number = ?", invoiceNumber).First(&ticket)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Ticket not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgTicketNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
 		var user model.User
-		result = db.First(&user, ticket.UserID)
+		result = db.Unscoped().First(&user, ticket.UserID)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "User not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgUserNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
 		if ticket.StatusOrder == "dibatalkan" {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Cannot update paid status for canceled ticket"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgTicketCanceledPaidStatus}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		var notification model.Notification
 		var wisata model.Wisata
-		wisataResult := db.First(&wisata, ticket.WisataID)
+		wisataResult := db.Unscoped().First(&wisata, ticket.WisataID)
 		if wisataResult.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Wisata not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgWisataNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
-		notificationMessage := fmt.Sprintf("Tiket untuk wisata %s berhasil dibayar. Selamat liburan!", wisata.Title)
+		locales := helper.PreferredLocales(user.Locale)
+		notificationTitle := helper.Translate(locales, helper.MsgNotificationPaymentSuccessTitle)
+		notificationMessage := helper.Translate(locales, helper.MsgNotificationPaymentSuccess, wisata.Title)
 		notification = model.Notification{
 			UserID:        user.ID,
 			Message:       notificationMessage,
-			Title:         "Transaksi Sukses",   // Tambahkan title
+			Title:         notificationTitle,    // Tambahkan title
 			InvoiceNumber: ticket.InvoiceNumber, // Tambahkan invoice_number
+			Type:          notificationTypePayment,
 		}
 		db.Create(&notification)
 
@@ -248,16 +251,17 @@ func UpdatePaidStatus(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 			var wisata model.Wisata
 			if ticket.PaidStatus {
-				wisataResult := db.First(&wisata, ticket.WisataID)
+				wisataResult := db.Unscoped().First(&wisata, ticket.WisataID)
 				if wisataResult.Error != nil {
-					errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Wisata not found"}
+					errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgWisataNotFound}
 					return c.JSON(http.StatusNotFound, errorResponse)
 				}
 
-				notificationMessage := fmt.Sprintf("Tiket untuk wisata %s berhasil dibayar. Selamat liburan!", wisata.Title)
+				notificationMessage := helper.Translate(locales, helper.MsgNotificationPaymentSuccess, wisata.Title)
 				notification = model.Notification{
 					UserID:  user.ID,
 					Message: notificationMessage,
+					Type:    notificationTypePayment,
 				}
 				db.Create(&notification)
 			}
@@ -274,6 +278,28 @@ func UpdatePaidStatus(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		ticket.PaidStatus = requestBody.PaidStatus
 		db.Save(&ticket)
 
+		offsetStatus := "pending"
+		if ticket.PaidStatus {
+			offsetStatus = "paid"
+		}
+		if err := updateCarbonOffsetStatus(db, ticket.ID, offsetStatus); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCarbonOffsetStatusUpdateFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		// Memberikan hadiah referral setelah tiket berbayar pertama user
+		if ticket.PaidStatus {
+			if err := rewardReferral(db, user.ID); err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgReferralRewardFailed}
+				return c.JSON(http.StatusInternalServerError, errorResponse)
+			}
+
+			if err := awardEcoBadges(db, user.ID); err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgEcoBadgeAwardFailed}
+				return c.JSON(http.StatusInternalServerError, errorResponse)
+			}
+		}
+
 		var userProfile UserProfile
 		db.Model(&user).Select("id as user_id, username, photo_profil").Scan(&userProfile)
 
@@ -301,10 +327,34 @@ func DeleteTicketByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		// Menghapus tiket berdasarkan invoice_number
 		result := db.Where("invoice_number = ?", invoiceNumber).Delete(&model.Ticket{})
 		if result.Error != nil || result.RowsAffected == 0 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Ticket not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgTicketNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
 		return c.JSON(http.StatusOK, map[string]interface{}{"code": http.StatusOK, "error": false, "message": "Ticket deleted successfully"})
 	}
 }
+
+// Memulihkan tiket transaksi yang diarsipkan
+func RestoreTicketByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		result := db.Unscoped().Model(&model.Ticket{}).
+			Where("invoice_number = ? AND deleted_at IS NOT NULL", c.Param("invoice_number")).
+			Update("deleted_at", nil)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgRestoreFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		if result.RowsAffected == 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgArchivedTicketNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{"code": http.StatusOK, "error": false, "message": "Ticket restored successfully"})
+	}
+}
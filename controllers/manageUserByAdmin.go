@@ -23,7 +23,7 @@ func GetAllUsersByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		}
 
 		var users []model.User
-		query := db.Where("is_admin = ?", false)
+		query := archivedFilter(c, db.Where("is_admin = ?", false), "users")
 		if name != "" {
 			// Jika parameter "name" diisi, lakukan pencarian berdasarkan nama user
 			query = query.Where("name LIKE ?", "%"+name+"%")
@@ -58,6 +58,7 @@ func GetAllUsersByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 				CategoryID:       user.CategoryID,
 				CategoryKesukaan: user.CategoryKesukaan,
 				PhotoProfil:      user.PhotoProfil,
+				DeletedAt:        archivedAt(user.DeletedAt),
 			}
 			userResponses = append(userResponses, userResponse)
 		}
@@ -99,7 +100,7 @@ func GetAllAdminsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		}
 
 		var users []model.User
-		query := db.Where("is_admin = ?", true)
+		query := archivedFilter(c, db.Where("is_admin = ?", true), "users")
 		if name != "" {
 			// Jika parameter "name" diisi, lakukan pencarian berdasarkan nama user
 			query = query.Where("name LIKE ?", "%"+name+"%")
@@ -134,6 +135,7 @@ func GetAllAdminsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 				CategoryID:       user.CategoryID,
 				CategoryKesukaan: user.CategoryKesukaan,
 				PhotoProfil:      user.PhotoProfil,
+				DeletedAt:        archivedAt(user.DeletedAt),
 			}
 			userResponses = append(userResponses, userResponse)
 		}
@@ -174,7 +176,7 @@ func EditUserByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var user model.User
 		result := db.First(&user, userID)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "User not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgUserNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
@@ -187,13 +189,13 @@ func EditUserByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		// Validate username
 		if req.Username != "" && req.Username != user.Username {
 			if len(req.Username) < 5 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Username must be at least 5 characters"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgUsernameTooShort}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 
 			var existingUserByUsername model.User
-			if db.Where("username = ?", req.Username).Not("id = ?", user.ID).First(&existingUserByUsername).Error == nil {
-				errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "Username is already taken"}
+			if db.Unscoped().Where("username = ?", req.Username).Not("id = ?", user.ID).First(&existingUserByUsername).Error == nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusConflict, ErrorCode: helper.MsgUsernameExists}
 				return c.JSON(http.StatusConflict, errorResponse)
 			}
 			user.Username = req.Username
@@ -202,13 +204,13 @@ func EditUserByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		// Validate phone_number
 		if req.PhoneNumber != "" && req.PhoneNumber != user.PhoneNumber {
 			if !helper.IsValidPhoneNumber(req.PhoneNumber) {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid phone number must have minimum 10 digits of numbers only"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidPhoneNumber}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 
 			var existingUserByPhone model.User
-			if db.Where("phone_number = ?", req.PhoneNumber).Not("id = ?", user.ID).First(&existingUserByPhone).Error == nil {
-				errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "Phone number is already taken"}
+			if db.Unscoped().Where("phone_number = ?", req.PhoneNumber).Not("id = ?", user.ID).First(&existingUserByPhone).Error == nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusConflict, ErrorCode: helper.MsgPhoneExists}
 				return c.JSON(http.StatusConflict, errorResponse)
 			}
 			user.PhoneNumber = req.PhoneNumber
@@ -217,13 +219,13 @@ func EditUserByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		// Validate email
 		if req.Email != "" && req.Email != user.Email {
 			if !helper.IsValidEmail(req.Email) {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid email format"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidEmailFormat}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 
 			var existingUserByEmail model.User
-			if db.Where("email = ?", req.Email).Not("id = ?", user.ID).First(&existingUserByEmail).Error == nil {
-				errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "Email is already taken"}
+			if db.Unscoped().Where("email = ?", req.Email).Not("id = ?", user.ID).First(&existingUserByEmail).Error == nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusConflict, ErrorCode: helper.MsgEmailExists}
 				return c.JSON(http.StatusConflict, errorResponse)
 			}
 			user.Email = req.Email
@@ -232,7 +234,7 @@ func EditUserByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		// Validate name
 		if req.Name != "" && req.Name != user.Name {
 			if len(req.Name) < 3 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Name must be at least 3 characters"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgNameMin3}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 			user.Name = req.Name
@@ -240,7 +242,7 @@ func EditUserByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		// Update user data
 		if err := db.Save(&user).Error; err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update user"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserUpdateFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -260,7 +262,7 @@ func DeleteUserByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var user model.User
 		result := db.First(&user, userID)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "User not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgUserNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
@@ -282,7 +284,7 @@ func DeleteAdminByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var user model.User
 		result := db.First(&user, userID)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Admin not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgAdminNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
@@ -291,3 +293,26 @@ func DeleteAdminByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		return c.JSON(http.StatusOK, map[string]interface{}{"code": http.StatusOK, "error": false, "message": "Admin deleted successfully"})
 	}
 }
+
+// Memulihkan akun user maupun admin yang diarsipkan
+func RestoreUserByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		var archivedUser model.User
+		if err := db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", c.Param("id")).First(&archivedUser).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgArchivedUserNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if err := db.Unscoped().Model(&archivedUser).Update("deleted_at", nil).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgRestoreFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{"code": http.StatusOK, "error": false, "message": "User restored successfully"})
+	}
+}
@@ -1,17 +1,17 @@
 package controllers
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/labstack/echo/v4"
 	"gorm.io/gorm"
-	"io"
+	"log"
+	"myproject/geo"
 	"myproject/helper"
 	"myproject/middleware"
 	"myproject/model"
 	"net/http"
 	"strconv"
-	"strings"
 	"time"
 )
 
@@ -34,159 +34,151 @@ func CreateWisata(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		long := c.FormValue("long")
 		categoryName := c.FormValue("category_name")
 		fasilitasStr := c.FormValue("fasilitas")
+		facilityIDsStr := c.FormValue("facility_ids")
 		priceInt, err := strconv.Atoi(price)
 
 		if len(title) < 8 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Judul harus minimal 8 huruf"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgTitleMin8}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if len(title) > 100 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Judul harus maksimal 100 karakter"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgTitleTooLong}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		existingWisata := model.Wisata{}
 		result := db.Where("title = ?", title).First(&existingWisata)
 		if result.RowsAffected > 0 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Judul sudah digunakan"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgTitleExists}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if len(kode) < 3 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Kode harus minimal 3 huruf"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgCodeTooShort}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if len(kode) > 5 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Kode harus maksimal 5 karakter"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgCodeTooLong}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		existingKode := model.Wisata{}
-		result = db.Where("kode = ?", kode).First(&existingKode)
+		result = db.Unscoped().Where("kode = ?", kode).First(&existingKode)
 		if result.RowsAffected > 0 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Kode sudah digunakan"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgCodeExists}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if len(kota) < 4 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Kota harus minimal 4 huruf"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgCityTooShort}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if len(kota) > 30 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Kota harus maksimal 30 huruf"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgCityTooLong}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if len(location) < 8 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Lokasi harus minimal 8 huruf"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgLocationTooShort}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if len(location) > 200 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Lokasi harus maksimal 200 karakter"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgLocationTooLong}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if len(description) < 10 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Deskripsi harus minimal 10 huruf"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDescriptionTooShort}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if len(description) > 2000 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Deskripsi harus maksimal 2000 karakter"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDescriptionTooLong}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if priceInt <= 0 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Price must be greater than 0"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgPricePositive}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid price value"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidPrice}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		availableTicketsInt, err := strconv.Atoi(availableTickets)
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid available_tickets value"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidAvailableTickets}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if availableTicketsInt <= 0 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Available Tickets harus lebih dari 0"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgAvailableTicketsPositive}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
-		if len(fasilitasStr) < 5 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Fasilitas harus lebih dari 5 huruf"}
-			return c.JSON(http.StatusBadRequest, errorResponse)
-		}
-
-		if len(fasilitasStr) > 100 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Fasilitas harus maksimal 100 karakter"}
+		if facilityIDsStr == "" && len(parseFacilityNames(fasilitasStr)) == 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgFacilitiesRequired}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if title == "" {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Judul harus diisi"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgTitleRequired}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if location == "" {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Lokasi harus diisi"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgLocationRequired}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if kota == "" {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Kota harus diisi"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgCityRequired}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if description == "" {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Deskripsi harus diisi"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDescriptionRequired}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		latFloat, err := strconv.ParseFloat(lat, 64)
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid latitude value"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidLatitude}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		longFloat, err := strconv.ParseFloat(long, 64)
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid longitude value"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidLongitude}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		var category model.Category
 		result = db.Where("category_name = ?", categoryName).First(&category)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Category not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgCategoryNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
-		//mengubah string menjadi potongan slice
-		fasilitas := strings.Split(fasilitasStr, ",")
-		if len(fasilitas) == 0 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Fasilitas harus di isi minimal satu "}
+		// Fasilitas dipilih dari katalog lewat facility_ids, input nama lama dipetakan ke katalog
+		facilities, missingFacilityNames, err := resolveWisataFacilities(db, facilityIDsStr, fasilitasStr)
+		if errors.Is(err, errFacilityNotFound) {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgFacilityNotFound}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
-
-		// Menghapus karakter escape ganda dari awal dan akhir string
-		for i, f := range fasilitas {
-			fasilitas[i] = strings.Trim(f, "\"")
+		if errors.Is(err, errFacilityNameInvalid) {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgFacilityNameInvalid}
+			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
-
-		// Digunakan untuk mengubah atau konversi dari slice menjadi format slice
-		fasilitasJSON, err := json.Marshal(fasilitas)
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Gagal mengonversi fasilitas ke JSON"}
-			return c.JSON(http.StatusBadRequest, errorResponse)
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgFacilitiesFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
 		mapsLink := c.FormValue("maps_link")
@@ -196,42 +188,42 @@ func CreateWisata(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		videoLink := c.FormValue("video_link")
 
 		if isopenStr == "" {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Field 'is_open' harus diisi"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgIsOpenRequired}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid is_open value"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidIsOpen}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if len(mapsLink) < 5 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Link maps harus lebih dari 5 huruf"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgMapsLinkTooShort}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if len(mapsLink) > 200 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Link maps harus maksimal 200 karakter"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgMapsLinkTooLong}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if mapsLink == "" {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Anda harus memasukan link maps_link"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgMapsLinkRequired}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if len(descriptionIsOpen) < 5 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "DescriptionIsOpen harus lebih dari 5 huruf"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDescriptionIsOpenTooShort}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if len(descriptionIsOpen) > 40 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "DescriptionIsOpen harus maksimal 40 karakter"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDescriptionIsOpenTooLong}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if descriptionIsOpen == "" {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Anda harus memasukan description_is_open, keterangan buka jam berapa sampai jam berapa"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDescriptionIsOpenRequired}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
@@ -247,82 +239,85 @@ func CreateWisata(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			AvailableTickets:  availableTicketsInt,
 			Lat:               latFloat,
 			Long:              longFloat,
+			Geohash:           geo.Encode(latFloat, longFloat, geo.GeohashPrecision),
 			CreatedAt:         &[]time.Time{time.Now()}[0],
 			CategoryID:        category.ID,
 			MapsLink:          mapsLink,
 			IsOpen:            isopen,
 			DescriptionIsOpen: descriptionIsOpen,
-			Fasilitas:         string(fasilitasJSON),
 			VideoLink:         videoLink,
 		}
 
-		var imageUrls []string
-		var photoNames []string
+		var media []model.Media
 		for i := 1; i <= 3; i++ {
 			imageFormField := fmt.Sprintf("photo_wisata%d", i)
 			imageFile, err := c.FormFile(imageFormField)
 			if err != nil {
 				if i == 1 {
-					errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Setidaknya satu gambar diperlukan."}
+					errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgImageRequired}
 					return c.JSON(http.StatusBadRequest, errorResponse)
 				}
 				break
 			}
 
-			if !helper.IsImageFile(imageFile) {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Jenis file tidak valid. Hanya file gambar yang diperbolehkan."}
-				return c.JSON(http.StatusBadRequest, errorResponse)
-			}
-
-			if helper.IsFileSizeExceeds(imageFile, 5*1024*1024) {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Ukuran file melebihi batas yang diizinkan (5MB)."}
-				return c.JSON(http.StatusBadRequest, errorResponse)
-			}
-
-			src, err := imageFile.Open()
-			if err != nil {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Gagal membuka file gambar"}
-				return c.JSON(http.StatusBadRequest, errorResponse)
-			}
-			defer src.Close()
-
-			imageData, err := io.ReadAll(src)
-			if err != nil {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Gagal membaca data gambar"}
-				return c.JSON(http.StatusBadRequest, errorResponse)
-			}
-
 			timestamp := time.Now().Unix()
 			imageName := fmt.Sprintf("wisatas/wisata/image_%s_%d_%d.jpg", randomString, timestamp, i)
-			imageURL, err := helper.UploadImageToGCS(imageData, imageName)
+			imageURL, err := helper.UploadImageFileToGCS(imageFile, maxMediaImageSize, imageName)
 			if err != nil {
-				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Gagal mengunggah gambar ke GCS"}
-				return c.JSON(http.StatusInternalServerError, errorResponse)
+				deleteMediaObjects(media)
+				return uploadImageErrorResponse(c, err)
 			}
 
-			imageUrls = append(imageUrls, imageURL)
-			photoNames = append(photoNames, imageName)
+			media = append(media, model.Media{
+				Type:       "photo",
+				URL:        imageURL,
+				ObjectName: imageName,
+				IsCover:    i == 1,
+				SortOrder:  i - 1,
+				CreatedAt:  &[]time.Time{time.Now()}[0],
+			})
 		}
 
-		if len(imageUrls) >= 1 {
-			createdWisata.PhotoWisata1 = imageUrls[0]
-		}
-		if len(imageUrls) >= 2 {
-			createdWisata.PhotoWisata2 = imageUrls[1]
-		}
-		if len(imageUrls) >= 3 {
-			createdWisata.PhotoWisata3 = imageUrls[2]
+		if videoLink != "" {
+			media = append(media, model.Media{
+				Type:      "video",
+				URL:       videoLink,
+				SortOrder: len(media),
+				CreatedAt: &[]time.Time{time.Now()}[0],
+			})
 		}
 
-		if err := db.Create(&createdWisata).Error; err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Gagal menyimpan wisata"}
+		// Fasilitas baru dari input nama lama dibuat di transaksi yang sama agar tidak tertinggal jika penyimpanan gagal
+		err = db.Transaction(func(tx *gorm.DB) error {
+			newFacilities, err := createFacilities(tx, missingFacilityNames)
+			if err != nil {
+				return err
+			}
+			createdWisata.Facilities = append(facilities, newFacilities...)
+			createdWisata.Fasilitas = facilityNamesJSON(createdWisata.Facilities)
+
+			if err := tx.Create(&createdWisata).Error; err != nil {
+				return err
+			}
+			for i := range media {
+				media[i].WisataID = createdWisata.ID
+			}
+			if err := tx.Create(&media).Error; err != nil {
+				return err
+			}
+			return syncWisataMediaFields(tx, createdWisata.ID)
+		})
+		if err != nil {
+			deleteMediaObjects(media)
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgWisataSaveFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
-		if err := db.Preload("Category").First(&createdWisata, createdWisata.ID).Error; err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Gagal memuat data kategori"}
+		if err := db.Preload("Category").Preload("Facilities").First(&createdWisata, createdWisata.ID).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCategoryFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
+		indexWisata(createdWisata)
 
 		return c.JSON(http.StatusOK, map[string]interface{}{
 			"code":       http.StatusOK,
@@ -342,17 +337,20 @@ func UpdateWisata(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		wisataID, err := strconv.Atoi(c.Param("id"))
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid Wisata ID"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidWisataID}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		var existingWisata model.Wisata
 		result := db.First(&existingWisata, wisataID)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Wisata not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgWisataNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
+		oldPrice := existingWisata.Price
+		oldAvailableTickets := existingWisata.AvailableTickets
+
 		kode := c.FormValue("kode")
 		title := c.FormValue("title")
 		location := c.FormValue("location")
@@ -369,37 +367,41 @@ func UpdateWisata(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		fasilitasStr := c.FormValue("fasilitas")
 		videoLink := c.FormValue("video_link")
 
-		if fasilitasStr != "" {
-			fasilitas := strings.Split(fasilitasStr, ",")
-			for _, f := range fasilitas {
-				if len(strings.Trim(f, "\"")) < 5 {
-					errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Setiap fasilitas harus minimal terdiri dari 5 huruf"}
-					return c.JSON(http.StatusBadRequest, errorResponse)
-				}
+		facilityIDsStr := c.FormValue("facility_ids")
 
-				if len(fasilitasStr) > 100 {
-					errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Fasilitas harus maksimal 100 karakter"}
-					return c.JSON(http.StatusBadRequest, errorResponse)
-				}
+		var facilities []model.Facility
+		var missingFacilityNames []string
+		replaceFacilities := facilityIDsStr != "" || fasilitasStr != ""
+		if replaceFacilities {
+			facilities, missingFacilityNames, err = resolveWisataFacilities(db, facilityIDsStr, fasilitasStr)
+			if errors.Is(err, errFacilityNotFound) {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgFacilityNotFound}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			if errors.Is(err, errFacilityNameInvalid) {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgFacilityNameInvalid}
+				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
-			fasilitasJSON, err := json.Marshal(fasilitas)
 			if err != nil {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Gagal mengonversi fasilitas ke JSON"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgFacilitiesFetchFailed}
+				return c.JSON(http.StatusInternalServerError, errorResponse)
+			}
+			if len(facilities)+len(missingFacilityNames) == 0 {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgFacilitiesRequired}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
-			existingWisata.Fasilitas = string(fasilitasJSON)
 		}
 
 		if kode != "" {
 			if len(kode) < 3 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Kode harus minimal terdiri dari 3 huruf"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgCodeTooShort}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 
 			var existingKodeCount int64
-			db.Model(&model.Wisata{}).Where("kode = ?", kode).Not("id = ?", wisataID).Count(&existingKodeCount)
+			db.Unscoped().Model(&model.Wisata{}).Where("kode = ?", kode).Not("id = ?", wisataID).Count(&existingKodeCount)
 			if existingKodeCount > 0 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Kode sudah ada, pilih Kode lain"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgCodeExists}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 			existingWisata.Kode = kode
@@ -407,19 +409,19 @@ func UpdateWisata(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		if title != "" {
 			if len(title) < 8 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Title harus minimal terdiri dari 8 huruf"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgTitleMin8}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 
 			if len(title) > 100 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Title harus maksimal 100 karakter"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgTitleTooLong}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 
 			var existingTitleCount int64
 			db.Model(&model.Wisata{}).Where("title = ?", title).Not("id = ?", wisataID).Count(&existingTitleCount)
 			if existingTitleCount > 0 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Title sudah ada, pilih title lain"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgTitleExists}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 			existingWisata.Title = title
@@ -427,12 +429,12 @@ func UpdateWisata(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		if location != "" {
 			if len(location) < 8 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Location harus minimal terdiri dari 8 huruf"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgLocationTooShort}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 
 			if len(location) > 200 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Location harus maksimal 200 karakter"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgLocationTooLong}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 		}
@@ -443,12 +445,12 @@ func UpdateWisata(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		if kota != "" {
 			if len(kota) < 4 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Kota harus minimal terdiri dari 4 huruf"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgCityTooShort}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 
 			if len(kota) > 30 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Kota harus maksimal 30 karakter"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgCityTooLong}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 		}
@@ -459,12 +461,12 @@ func UpdateWisata(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		if description != "" {
 			if len(description) < 10 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Description harus minimal terdiri dari 10 huruf"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDescriptionTooShort}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 
 			if len(description) > 2000 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Description harus maksimal 2000 karakter"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDescriptionTooLong}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 		}
@@ -476,7 +478,7 @@ func UpdateWisata(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		if price != "" {
 			priceInt, err := strconv.Atoi(price)
 			if err != nil || priceInt <= 0 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Price harus lebih besar dari 0"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgPricePositive}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 			existingWisata.Price = priceInt
@@ -485,7 +487,7 @@ func UpdateWisata(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		if lat != "" {
 			latFloat, err := strconv.ParseFloat(lat, 64)
 			if err != nil {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid latitude value"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidLatitude}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 			existingWisata.Lat = latFloat
@@ -493,16 +495,17 @@ func UpdateWisata(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		if long != "" {
 			longFloat, err := strconv.ParseFloat(long, 64)
 			if err != nil {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid longitude value"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidLongitude}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 			existingWisata.Long = longFloat
 		}
+		existingWisata.Geohash = geo.Encode(existingWisata.Lat, existingWisata.Long, geo.GeohashPrecision)
 
 		if availableTickets != "" {
 			availableTicketsInt, err := strconv.Atoi(availableTickets)
 			if err != nil || availableTicketsInt <= 0 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Available Tickets harus lebih dari 0"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgAvailableTicketsPositive}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 			existingWisata.AvailableTickets = availableTicketsInt
@@ -511,7 +514,7 @@ func UpdateWisata(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		if categoryName != "" {
 			var existingCategory model.Category
 			if err := db.Where("category_name = ?", categoryName).First(&existingCategory).Error; err != nil {
-				errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Category not found"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgCategoryNotFound}
 				return c.JSON(http.StatusNotFound, errorResponse)
 			}
 			existingWisata.Category = existingCategory
@@ -519,12 +522,12 @@ func UpdateWisata(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		if mapsLink != "" {
 			if len(mapsLink) < 5 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Maps Link harus minimal terdiri dari 5 huruf"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgMapsLinkTooShort}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 
 			if len(mapsLink) > 200 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Maps Link harus maksimal 200 karakter"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgMapsLinkTooLong}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 		}
@@ -540,7 +543,7 @@ func UpdateWisata(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		if isOpenStr != "" {
 			isOpen, err := strconv.ParseBool(isOpenStr)
 			if err != nil {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid is_open value"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidIsOpen}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 			existingWisata.IsOpen = isOpen
@@ -548,12 +551,12 @@ func UpdateWisata(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		if descriptionIsOpen != "" {
 			if len(descriptionIsOpen) < 5 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Description Is Open harus minimal terdiri dari 5 huruf"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDescriptionIsOpenTooShort}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 
 			if len(descriptionIsOpen) > 40 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Description Is Open harus maksimal 40 karakter"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDescriptionIsOpenTooLong}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 		}
@@ -562,47 +565,81 @@ func UpdateWisata(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			existingWisata.DescriptionIsOpen = descriptionIsOpen
 		}
 
+		// Foto photo_wisata1..3 diunggah lebih dulu, lalu mengganti foto pada urutan yang sama di galeri
+		uploadedPhotos := make(map[int]model.Media)
 		for i := 1; i <= 3; i++ {
 			imageFormField := fmt.Sprintf("photo_wisata%d", i)
 			imageFile, err := c.FormFile(imageFormField)
-			if err == nil {
-				if helper.IsImageFile(imageFile) {
-					if !helper.IsFileSizeExceeds(imageFile, 5*1024*1024) {
-						src, err := imageFile.Open()
-						if err == nil {
-							defer src.Close()
-							imageData, err := io.ReadAll(src)
-							if err == nil {
-								timestamp := time.Now().Unix()
-								imageName := fmt.Sprintf("wisata%d/photo%d_%d.jpg", wisataID, i, timestamp)
-								imageURL, err := helper.UploadImageToGCS(imageData, imageName)
-								if err == nil {
-									if i == 1 {
-										existingWisata.PhotoWisata1 = imageURL
-									} else if i == 2 {
-										existingWisata.PhotoWisata2 = imageURL
-									} else if i == 3 {
-										existingWisata.PhotoWisata3 = imageURL
-									}
-								}
-							}
-						}
-					}
+			if err != nil {
+				continue
+			}
+			imageURL, objectName, err := uploadWisataPhoto(imageFile, existingWisata.ID, i)
+			if err != nil {
+				for _, photo := range uploadedPhotos {
+					deleteMediaObjects([]model.Media{photo})
 				}
+				return uploadImageErrorResponse(c, err)
 			}
+			uploadedPhotos[i] = model.Media{URL: imageURL, ObjectName: objectName}
 		}
 
-		if err := db.Save(&existingWisata).Error; err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to save changes to Wisata"}
+		// Data wisata dan galerinya disimpan dalam satu transaksi. Jika gagal, foto yang baru diunggah dihapus dari GCS,
+		// foto lama yang diganti baru dihapus setelah transaksi berhasil.
+		var replacedPhotos []model.Media
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Save(&existingWisata).Error; err != nil {
+				return err
+			}
+
+			for slot := 1; slot <= 3; slot++ {
+				photo, ok := uploadedPhotos[slot]
+				if !ok {
+					continue
+				}
+				oldPhoto, err := setWisataPhotoSlot(tx, existingWisata.ID, slot, photo.URL, photo.ObjectName)
+				if err != nil {
+					return err
+				}
+				if oldPhoto != nil {
+					replacedPhotos = append(replacedPhotos, *oldPhoto)
+				}
+			}
+			if replaceFacilities {
+				newFacilities, err := createFacilities(tx, missingFacilityNames)
+				if err != nil {
+					return err
+				}
+				if err := tx.Model(&existingWisata).Association("Facilities").Replace(append(facilities, newFacilities...)); err != nil {
+					return err
+				}
+				if err := syncWisataFacilityNames(tx, []uint{existingWisata.ID}); err != nil {
+					return err
+				}
+			}
+			if err := setWisataVideoLink(tx, existingWisata.ID, videoLink); err != nil {
+				return err
+			}
+			return syncWisataMediaFields(tx, existingWisata.ID)
+		})
+		if err != nil {
+			for _, photo := range uploadedPhotos {
+				deleteMediaObjects([]model.Media{photo})
+			}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgWisataSaveFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
+		deleteMediaObjects(replacedPhotos)
 
 		// Preload Category
-		result = db.Preload("Category").First(&existingWisata, wisataID)
+		result = db.Preload("Category").Preload("Facilities").First(&existingWisata, wisataID)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Wisata not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgWisataNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
+		indexWisata(existingWisata)
+		if err := notifyFavoriteWisataChanges(db, existingWisata, oldPrice, oldAvailableTickets); err != nil {
+			log.Println("Gagal mengirim notifikasi tempat wisata favorit:", err)
+		}
 
 		return c.JSON(http.StatusOK, map[string]interface{}{
 			"code":       http.StatusOK,
@@ -626,15 +663,16 @@ func DeleteWisata(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		// Cek apakah wisata dengan ID tersebut ada dalam basis data
 		var existingWisata model.Wisata
 		if err := db.Where("id = ?", wisataID).First(&existingWisata).Error; err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Wisata not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgWisataNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
-		// Menghapus wisata dari basis data
+		// Mengarsipkan wisata, galeri, fasilitas, favorit dan terjemahannya baru dihapus oleh job purge setelah masa retensi
 		if err := db.Delete(&existingWisata).Error; err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to delete Wisata"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgWisataDeleteFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
+		unindexWisata(existingWisata.ID)
 
 		// Mengembalikan respons sukses jika berhasil
 		return c.JSON(http.StatusOK, map[string]interface{}{
@@ -644,3 +682,35 @@ func DeleteWisata(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		})
 	}
 }
+
+// Memulihkan tempat wisata yang diarsipkan
+func RestoreWisataByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		var archivedWisata model.Wisata
+		if err := db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", c.Param("id")).First(&archivedWisata).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgArchivedWisataNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if err := db.Unscoped().Model(&archivedWisata).Update("deleted_at", nil).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgRestoreFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var wisata model.Wisata
+		db.Preload("Category").Preload("Facilities").First(&wisata, archivedWisata.ID)
+		indexWisata(wisata)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"message":    "Wisata restored successfully",
+			"wisataData": wisata,
+		})
+	}
+}
@@ -0,0 +1,378 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"log"
+	"mime/multipart"
+	"myproject/helper"
+	"myproject/middleware"
+	"myproject/model"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxMediaImageSize = 5 * 1024 * 1024
+
+// uploadImageErrorResponse mengubah error unggah gambar menjadi response yang sama dengan pesan sebelumnya
+func uploadImageErrorResponse(c echo.Context, err error) error {
+	var errorResponse helper.ErrorResponse
+	switch {
+	case errors.Is(err, helper.ErrInvalidImageType):
+		errorResponse = helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidImageFileType}
+	case errors.Is(err, helper.ErrImageTooLarge):
+		errorResponse = helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgFileTooLarge}
+	case errors.Is(err, helper.ErrReadImage):
+		errorResponse = helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgImageReadFailed}
+	default:
+		errorResponse = helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgImageUploadFailed}
+	}
+	return c.JSON(errorResponse.Code, errorResponse)
+}
+
+// uploadWisataPhoto mengunggah foto galeri wisata dan mengembalikan URL beserta nama objeknya
+func uploadWisataPhoto(file *multipart.FileHeader, wisataID uint, index int) (string, string, error) {
+	objectName := fmt.Sprintf("wisata%d/media_%d_%d.jpg", wisataID, time.Now().UnixNano(), index)
+	url, err := helper.UploadImageFileToGCS(file, maxMediaImageSize, objectName)
+	return url, objectName, err
+}
+
+// wisataMediaQuery mengurutkan galeri sesuai urutan yang diatur admin
+func wisataMediaQuery(db *gorm.DB) *gorm.DB {
+	return db.Order("sort_order asc, id asc")
+}
+
+func nextMediaOrder(db *gorm.DB, wisataID uint) int {
+	var maxOrder int
+	db.Model(&model.Media{}).Select("COALESCE(MAX(sort_order), -1)").Where("wisata_id = ?", wisataID).Row().Scan(&maxOrder)
+	return maxOrder + 1
+}
+
+// syncWisataMediaFields mengisi PhotoWisata1..3 dan VideoLink dari galeri agar client lama tetap bekerja
+func syncWisataMediaFields(db *gorm.DB, wisataID uint) error {
+	var photos []model.Media
+	if err := db.Where("wisata_id = ? AND type = ?", wisataID, "photo").Order("is_cover desc, sort_order asc, id asc").Limit(3).Find(&photos).Error; err != nil {
+		return err
+	}
+
+	var video model.Media
+	db.Where("wisata_id = ? AND type = ?", wisataID, "video").Order("sort_order asc, id asc").Limit(1).Find(&video)
+
+	fields := map[string]interface{}{
+		"photo_wisata1": "",
+		"photo_wisata2": "",
+		"photo_wisata3": "",
+		"video_link":    video.URL,
+	}
+	for i, photo := range photos {
+		fields[fmt.Sprintf("photo_wisata%d", i+1)] = photo.URL
+	}
+	return db.Model(&model.Wisata{}).Where("id = ?", wisataID).Updates(fields).Error
+}
+
+// deleteMediaObjects menghapus file galeri dari GCS, kegagalan hanya dicatat karena data di database sudah terhapus
+func deleteMediaObjects(media []model.Media) {
+	for _, item := range media {
+		if item.ObjectName == "" {
+			continue
+		}
+		if err := helper.DeleteObjectFromGCS(item.ObjectName); err != nil {
+			log.Println("Gagal menghapus objek media dari GCS:", item.ObjectName, err)
+		}
+	}
+}
+
+// setWisataPhotoSlot mengganti foto ke-slot (1..3) pada urutan kompatibilitas, atau menambahkannya jika belum ada.
+// Foto lama yang diganti dikembalikan agar file-nya di GCS baru dihapus setelah transaksi berhasil.
+func setWisataPhotoSlot(db *gorm.DB, wisataID uint, slot int, url, objectName string) (*model.Media, error) {
+	var photos []model.Media
+	if err := db.Where("wisata_id = ? AND type = ?", wisataID, "photo").Order("is_cover desc, sort_order asc, id asc").Find(&photos).Error; err != nil {
+		return nil, err
+	}
+
+	if slot <= len(photos) {
+		oldPhoto := photos[slot-1]
+		if err := db.Model(&model.Media{}).Where("id = ?", oldPhoto.ID).Updates(map[string]interface{}{
+			"url":         url,
+			"object_name": objectName,
+		}).Error; err != nil {
+			return nil, err
+		}
+		return &oldPhoto, nil
+	}
+
+	return nil, db.Create(&model.Media{
+		WisataID:   wisataID,
+		Type:       "photo",
+		URL:        url,
+		ObjectName: objectName,
+		IsCover:    len(photos) == 0,
+		SortOrder:  nextMediaOrder(db, wisataID),
+		CreatedAt:  &[]time.Time{time.Now()}[0],
+	}).Error
+}
+
+// setWisataVideoLink mengganti video utama wisata, link kosong berarti tidak mengubah video
+func setWisataVideoLink(db *gorm.DB, wisataID uint, videoLink string) error {
+	if videoLink == "" {
+		return nil
+	}
+
+	var video model.Media
+	if err := db.Where("wisata_id = ? AND type = ?", wisataID, "video").Order("sort_order asc, id asc").First(&video).Error; err == nil {
+		return db.Model(&model.Media{}).Where("id = ?", video.ID).Update("url", videoLink).Error
+	}
+
+	return db.Create(&model.Media{
+		WisataID:  wisataID,
+		Type:      "video",
+		URL:       videoLink,
+		SortOrder: nextMediaOrder(db, wisataID),
+		CreatedAt: &[]time.Time{time.Now()}[0],
+	}).Error
+}
+
+// Menambahkan foto (file) atau video (url) ke galeri tempat wisata
+func AddWisataMediaByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		var wisata model.Wisata
+		if err := db.First(&wisata, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgWisataNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		media := model.Media{
+			WisataID:  wisata.ID,
+			Type:      c.FormValue("type"),
+			Caption:   strings.TrimSpace(c.FormValue("caption")),
+			AltText:   strings.TrimSpace(c.FormValue("alt_text")),
+			IsCover:   c.FormValue("is_cover") == "true",
+			SortOrder: nextMediaOrder(db, wisata.ID),
+			CreatedAt: &[]time.Time{time.Now()}[0],
+		}
+		if media.Type == "" {
+			media.Type = "photo"
+		}
+
+		switch media.Type {
+		case "photo":
+			file, err := c.FormFile("file")
+			if err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgImageFileRequired}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			media.URL, media.ObjectName, err = uploadWisataPhoto(file, wisata.ID, 0)
+			if err != nil {
+				return uploadImageErrorResponse(c, err)
+			}
+		case "video":
+			media.URL = strings.TrimSpace(c.FormValue("url"))
+			if media.URL == "" {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgVideoURLRequired}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			media.IsCover = false
+		default:
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidMediaType}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if media.IsCover {
+				if err := tx.Model(&model.Media{}).Where("wisata_id = ?", wisata.ID).Update("is_cover", false).Error; err != nil {
+					return err
+				}
+			}
+			if err := tx.Create(&media).Error; err != nil {
+				return err
+			}
+			return syncWisataMediaFields(tx, wisata.ID)
+		})
+		if err != nil {
+			deleteMediaObjects([]model.Media{media})
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgMediaSaveFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": "Media added successfully",
+			"media":   media,
+		})
+	}
+}
+
+// Mengubah caption, alt text atau cover sebuah media
+func UpdateWisataMediaByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		var media model.Media
+		if err := db.Where("id = ? AND wisata_id = ?", c.Param("media_id"), c.Param("id")).First(&media).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgMediaNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var requestBody struct {
+			Caption *string `json:"caption"`
+			AltText *string `json:"alt_text"`
+			IsCover *bool   `json:"is_cover"`
+		}
+		if err := c.Bind(&requestBody); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidRequestBody}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if requestBody.Caption != nil {
+			media.Caption = strings.TrimSpace(*requestBody.Caption)
+		}
+		if requestBody.AltText != nil {
+			media.AltText = strings.TrimSpace(*requestBody.AltText)
+		}
+		if requestBody.IsCover != nil {
+			if *requestBody.IsCover && media.Type != "photo" {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgCoverNotPhoto}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			media.IsCover = *requestBody.IsCover
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if media.IsCover {
+				if err := tx.Model(&model.Media{}).Where("wisata_id = ? AND id <> ?", media.WisataID, media.ID).Update("is_cover", false).Error; err != nil {
+					return err
+				}
+			}
+			if err := tx.Save(&media).Error; err != nil {
+				return err
+			}
+			return syncWisataMediaFields(tx, media.WisataID)
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgMediaUpdateFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Media updated successfully",
+			"media":   media,
+		})
+	}
+}
+
+// Mengatur ulang urutan galeri sesuai daftar ID media yang dikirim
+func ReorderWisataMediaByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		wisataID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidWisataID}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var requestBody struct {
+			MediaIDs []uint `json:"media_ids"`
+		}
+		if err := c.Bind(&requestBody); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidRequestBody}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var existing []model.Media
+		db.Where("wisata_id = ?", wisataID).Find(&existing)
+
+		existingIDs := make(map[uint]bool)
+		for _, item := range existing {
+			existingIDs[item.ID] = true
+		}
+		seen := make(map[uint]bool)
+		for _, id := range requestBody.MediaIDs {
+			if !existingIDs[id] || seen[id] {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidMediaOrder}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			seen[id] = true
+		}
+		if len(seen) != len(existingIDs) {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidMediaOrder}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			for order, id := range requestBody.MediaIDs {
+				if err := tx.Model(&model.Media{}).Where("id = ?", id).Update("sort_order", order).Error; err != nil {
+					return err
+				}
+			}
+			return syncWisataMediaFields(tx, uint(wisataID))
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgMediaReorderFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var media []model.Media
+		wisataMediaQuery(db).Where("wisata_id = ?", wisataID).Find(&media)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Media reordered successfully",
+			"media":   media,
+		})
+	}
+}
+
+// Menghapus media dari galeri beserta file-nya di GCS
+func DeleteWisataMediaByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		var media model.Media
+		if err := db.Where("id = ? AND wisata_id = ?", c.Param("media_id"), c.Param("id")).First(&media).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgMediaNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Delete(&media).Error; err != nil {
+				return err
+			}
+			return syncWisataMediaFields(tx, media.WisataID)
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgMediaDeleteFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		deleteMediaObjects([]model.Media{media})
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Media deleted successfully",
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"myproject/helper"
+	"myproject/middleware"
+	"myproject/model"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// loadOpeningSchedule memuat jam buka mingguan dan pengecualian tanggal mulai kemarin untuk sebuah tempat wisata
+func loadOpeningSchedule(db *gorm.DB, wisata model.Wisata) helper.OpeningSchedule {
+	var hours []model.OpeningHour
+	db.Where("wisata_id = ?", wisata.ID).Order("day_of_week asc, open_time asc").Find(&hours)
+
+	var closures []model.WisataClosure
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	db.Where("wisata_id = ? AND date >= ?", wisata.ID, yesterday).Order("date asc").Find(&closures)
+
+	return helper.NewOpeningSchedule(wisata, hours, closures)
+}
+
+// openNowWisataIDs mengembalikan ID tempat wisata yang sedang buka pada waktu now
+func openNowWisataIDs(db *gorm.DB, now time.Time) []uint {
+	var wisatas []model.Wisata
+	db.Select("id", "time_zone", "is_open").Find(&wisatas)
+
+	var hours []model.OpeningHour
+	db.Find(&hours)
+	hoursByWisata := make(map[uint][]model.OpeningHour)
+	for _, hour := range hours {
+		hoursByWisata[hour.WisataID] = append(hoursByWisata[hour.WisataID], hour)
+	}
+
+	// Selisih zona waktu paling jauh satu hari, jadi cukup memuat pengecualian sekitar hari ini
+	var closures []model.WisataClosure
+	db.Where("date BETWEEN ? AND ?", now.AddDate(0, 0, -2).Format("2006-01-02"), now.AddDate(0, 0, 1).Format("2006-01-02")).Find(&closures)
+	closuresByWisata := make(map[uint][]model.WisataClosure)
+	for _, closure := range closures {
+		closuresByWisata[closure.WisataID] = append(closuresByWisata[closure.WisataID], closure)
+	}
+
+	ids := []uint{}
+	for _, wisata := range wisatas {
+		schedule := helper.NewOpeningSchedule(wisata, hoursByWisata[wisata.ID], closuresByWisata[wisata.ID])
+		if schedule.IsOpenAt(now) {
+			ids = append(ids, wisata.ID)
+		}
+	}
+	return ids
+}
+
+// openingStatus merangkum status buka tempat wisata untuk ditampilkan di response
+func openingStatus(schedule helper.OpeningSchedule, now time.Time) map[string]interface{} {
+	openNow := schedule.IsOpenAt(now)
+
+	status := map[string]interface{}{
+		"open_now":     openNow,
+		"time_zone":    schedule.Location.String(),
+		"closes_at":    nil,
+		"next_opening": nil,
+	}
+	if openNow {
+		status["closes_at"] = schedule.ClosesAt(now)
+	} else if nextOpening := schedule.NextOpening(now); nextOpening != nil {
+		status["next_opening"] = nextOpening
+	}
+	return status
+}
+
+// Menampilkan jam buka mingguan, pengecualian tanggal dan status buka tempat wisata
+func GetOpeningHours(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var wisata model.Wisata
+		if err := db.First(&wisata, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgWisataNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		schedule := loadOpeningSchedule(db, wisata)
+
+		closures := []model.WisataClosure{}
+		for _, closure := range schedule.Closures {
+			closures = append(closures, closure)
+		}
+		sort.Slice(closures, func(i, j int) bool { return closures[i].Date < closures[j].Date })
+
+		hours := schedule.Hours
+		if hours == nil {
+			hours = []model.OpeningHour{}
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":           http.StatusOK,
+			"error":          false,
+			"opening_hours":  hours,
+			"closures":       closures,
+			"opening_status": openingStatus(schedule, time.Now()),
+		})
+	}
+}
+
+// Mengganti seluruh jam buka mingguan dan zona waktu tempat wisata oleh admin
+func UpdateOpeningHoursByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		var wisata model.Wisata
+		if err := db.First(&wisata, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgWisataNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var requestBody struct {
+			TimeZone string              `json:"time_zone"`
+			Hours    []model.OpeningHour `json:"hours"`
+		}
+		if err := c.Bind(&requestBody); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidRequestBody}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if requestBody.TimeZone != "" {
+			if _, err := time.LoadLocation(requestBody.TimeZone); err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidTimeZone}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			wisata.TimeZone = requestBody.TimeZone
+		}
+
+		for i, hour := range requestBody.Hours {
+			if hour.DayOfWeek < 0 || hour.DayOfWeek > 6 {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidDayOfWeek}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			openMinute, err := helper.ParseClock(hour.OpenTime)
+			if err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidOpenTime}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			closeMinute, err := helper.ParseClock(hour.CloseTime)
+			if err != nil || openMinute == closeMinute {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidCloseTime}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			requestBody.Hours[i].ID = 0
+			requestBody.Hours[i].WisataID = wisata.ID
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("wisata_id = ?", wisata.ID).Delete(&model.OpeningHour{}).Error; err != nil {
+				return err
+			}
+			if len(requestBody.Hours) > 0 {
+				if err := tx.Create(&requestBody.Hours).Error; err != nil {
+					return err
+				}
+			}
+			return tx.Model(&model.Wisata{}).Where("id = ?", wisata.ID).Update("time_zone", wisata.TimeZone).Error
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgOpeningHoursUpdateFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		schedule := loadOpeningSchedule(db, wisata)
+
+		hours := schedule.Hours
+		if hours == nil {
+			hours = []model.OpeningHour{}
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":           http.StatusOK,
+			"error":          false,
+			"message":        "Opening hours updated successfully",
+			"opening_hours":  hours,
+			"opening_status": openingStatus(schedule, time.Now()),
+		})
+	}
+}
+
+// Menambahkan pengecualian jadwal (libur, perawatan atau jam khusus) oleh admin
+func CreateClosureByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		var wisata model.Wisata
+		if err := db.First(&wisata, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgWisataNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var requestBody struct {
+			Date      string `json:"date"`
+			IsClosed  *bool  `json:"is_closed"`
+			OpenTime  string `json:"open_time"`
+			CloseTime string `json:"close_time"`
+			Reason    string `json:"reason"`
+		}
+		if err := c.Bind(&requestBody); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidRequestBody}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if _, err := time.Parse("2006-01-02", requestBody.Date); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidDateFormat}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		closure := model.WisataClosure{
+			WisataID:  wisata.ID,
+			Date:      requestBody.Date,
+			IsClosed:  requestBody.IsClosed == nil || *requestBody.IsClosed,
+			Reason:    strings.TrimSpace(requestBody.Reason),
+			CreatedAt: &[]time.Time{time.Now()}[0],
+		}
+
+		if !closure.IsClosed {
+			openMinute, err := helper.ParseClock(requestBody.OpenTime)
+			if err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidOpenTime}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			closeMinute, err := helper.ParseClock(requestBody.CloseTime)
+			if err != nil || openMinute == closeMinute {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidCloseTime}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			closure.OpenTime = requestBody.OpenTime
+			closure.CloseTime = requestBody.CloseTime
+		}
+
+		// Satu tanggal hanya memiliki satu pengecualian, yang baru menggantikan yang lama
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("wisata_id = ? AND date = ?", wisata.ID, closure.Date).Delete(&model.WisataClosure{}).Error; err != nil {
+				return err
+			}
+			return tx.Create(&closure).Error
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgClosureSaveFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": "Closure saved successfully",
+			"closure": closure,
+		})
+	}
+}
+
+// Menghapus pengecualian jadwal oleh admin
+func DeleteClosureByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		closureID, err := strconv.ParseUint(c.Param("closure_id"), 10, 64)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidClosureID}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var closure model.WisataClosure
+		if err := db.Where("id = ? AND wisata_id = ?", closureID, c.Param("id")).First(&closure).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgClosureNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		if err := db.Delete(&closure).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgClosureDeleteFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Closure deleted successfully",
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"myproject/helper"
+	"myproject/middleware"
+	"myproject/model"
+	"net/http"
+	"sort"
+)
+
+// Skala maksimal jawaban minat wisata alam dan budaya pada kuis
+const quizInterestScale = 5
+
+// Bobot tambahan untuk kategori yang sesuai gaya perjalanan dan kategori yang dipilih langsung
+const (
+	travelStyleWeight     = 0.6
+	chosenCategoryWeight  = 1.0
+	profileCategoryWeight = 1.0
+)
+
+// travelStyles adalah pilihan gaya perjalanan pada kuis, sekaligus tema kategori yang dicocokkan
+var travelStyles = []string{"adventure", "relaxation", "family", "culinary"}
+
+// preferenceThemes adalah tema yang dapat dipasang admin pada kategori
+var preferenceThemes = append([]string{"nature", "culture"}, travelStyles...)
+
+// budgetMaxPrices adalah anggaran maksimal per tiket untuk setiap pilihan budget, 0 berarti tanpa batas
+var budgetMaxPrices = map[string]int{
+	"low":    50000,
+	"medium": 150000,
+	"high":   0,
+}
+
+type quizQuestion struct {
+	Key      string        `json:"key"`
+	Question string        `json:"question"`
+	Type     string        `json:"type"` // scale (0..max) atau choice
+	Max      int           `json:"max,omitempty"`
+	Options  []interface{} `json:"options,omitempty"`
+}
+
+type quizAnswers struct {
+	Nature      int    `json:"nature"`
+	Culture     int    `json:"culture"`
+	Budget      string `json:"budget"`
+	TravelStyle string `json:"travel_style"`
+	CategoryIDs []uint `json:"category_ids"` // Kategori yang dipilih langsung oleh user, opsional
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// userPreferredCategories mengembalikan kategori kesukaan user beserta bobotnya, termasuk subkategorinya
+func userPreferredCategories(db *gorm.DB, user model.User) map[uint]float64 {
+	var preferences []model.UserCategoryPreference
+	db.Where("user_id = ?", user.ID).Find(&preferences)
+	if len(preferences) == 0 && user.CategoryID != 0 {
+		preferences = []model.UserCategoryPreference{{CategoryID: user.CategoryID, Weight: profileCategoryWeight}}
+	}
+
+	preferred := make(map[uint]float64)
+	for _, preference := range preferences {
+		for _, categoryID := range categoryDescendantIDs(db, []uint{preference.CategoryID}) {
+			if preference.Weight > preferred[categoryID] {
+				preferred[categoryID] = preference.Weight
+			}
+		}
+	}
+	return preferred
+}
+
+// userBudget mengembalikan anggaran maksimal per tiket dari kuis preferensi user, 0 berarti tanpa batas
+func userBudget(db *gorm.DB, userID uint) int {
+	var quiz model.UserPreferenceQuiz
+	if err := db.Where("user_id = ?", userID).First(&quiz).Error; err != nil {
+		return 0
+	}
+	return budgetMaxPrices[quiz.Budget]
+}
+
+// quizCategoryWeights menghitung bobot kategori dari jawaban kuis berdasarkan tema kategori yang aktif
+func quizCategoryWeights(db *gorm.DB, answers quizAnswers) map[uint]float64 {
+	weights := make(map[uint]float64)
+
+	var categories []model.Category
+	db.Where("is_active = ? AND theme <> ?", true, "").Find(&categories)
+	for _, category := range categories {
+		switch category.Theme {
+		case "nature":
+			weights[category.ID] += float64(answers.Nature) / quizInterestScale
+		case "culture":
+			weights[category.ID] += float64(answers.Culture) / quizInterestScale
+		}
+		if category.Theme == answers.TravelStyle {
+			weights[category.ID] += travelStyleWeight
+		}
+	}
+	for _, categoryID := range answers.CategoryIDs {
+		weights[categoryID] += chosenCategoryWeight
+	}
+
+	for categoryID, weight := range weights {
+		if weight <= 0 {
+			delete(weights, categoryID)
+		}
+	}
+	return weights
+}
+
+// loadUserPreferences memuat preferensi kategori user dari bobot tertinggi beserta nama kategori sesuai bahasa
+func loadUserPreferences(db *gorm.DB, locales []string, userID uint) []model.UserCategoryPreference {
+	preferences := []model.UserCategoryPreference{}
+	db.Preload("Category").Where("user_id = ?", userID).Order("weight desc, category_id asc").Find(&preferences)
+
+	categories := make([]model.Category, len(preferences))
+	for i, preference := range preferences {
+		categories[i] = preference.Category
+	}
+	localizeCategories(db, locales, categories)
+	for i := range preferences {
+		preferences[i].Category = categories[i]
+	}
+	return preferences
+}
+
+// setProfileCategoryPreference menyimpan kategori kesukaan dari profil user sebagai preferensi dengan bobot penuh
+func setProfileCategoryPreference(tx *gorm.DB, userID, categoryID uint) error {
+	if err := tx.Where("user_id = ? AND source = ? AND category_id <> ?", userID, "profile", categoryID).Delete(&model.UserCategoryPreference{}).Error; err != nil {
+		return err
+	}
+	var preference model.UserCategoryPreference
+	return tx.Where(model.UserCategoryPreference{UserID: userID, CategoryID: categoryID}).
+		Assign(model.UserCategoryPreference{Weight: profileCategoryWeight, Source: "profile"}).
+		FirstOrCreate(&preference).Error
+}
+
+// Menampilkan pertanyaan kuis preferensi beserta jawaban dan preferensi kategori user saat ini
+func GetPreferenceQuiz(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		locales := requestLocales(c)
+		budgets := []interface{}{}
+		for _, budget := range []string{"low", "medium", "high"} {
+			budgets = append(budgets, map[string]interface{}{"value": budget, "max_price": budgetMaxPrices[budget]})
+		}
+		styles := []interface{}{}
+		for _, style := range travelStyles {
+			styles = append(styles, style)
+		}
+		questions := []quizQuestion{
+			{Key: "nature", Question: helper.Translate(locales, helper.MsgQuizNatureQuestion), Type: "scale", Max: quizInterestScale},
+			{Key: "culture", Question: helper.Translate(locales, helper.MsgQuizCultureQuestion), Type: "scale", Max: quizInterestScale},
+			{Key: "budget", Question: helper.Translate(locales, helper.MsgQuizBudgetQuestion), Type: "choice", Options: budgets},
+			{Key: "travel_style", Question: helper.Translate(locales, helper.MsgQuizTravelStyleQuestion), Type: "choice", Options: styles},
+		}
+
+		var answers *model.UserPreferenceQuiz
+		var quiz model.UserPreferenceQuiz
+		if err := db.Where("user_id = ?", user.ID).First(&quiz).Error; err == nil {
+			answers = &quiz
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":        http.StatusOK,
+			"error":       false,
+			"questions":   questions,
+			"answers":     answers,
+			"preferences": loadUserPreferences(db, locales, user.ID),
+		})
+	}
+}
+
+// Menyimpan jawaban kuis preferensi dan mengganti preferensi kategori user dengan bobot dari jawaban tersebut
+func SubmitPreferenceQuiz(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var answers quizAnswers
+		if err := c.Bind(&answers); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidRequestBody}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if answers.Nature < 0 || answers.Nature > quizInterestScale || answers.Culture < 0 || answers.Culture > quizInterestScale {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgQuizInterestRange, Args: []interface{}{quizInterestScale}}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+		if _, ok := budgetMaxPrices[answers.Budget]; !ok {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgQuizBudgetInvalid}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+		if !containsString(travelStyles, answers.TravelStyle) {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgQuizTravelStyleInvalid}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		// Kategori yang dipilih langsung harus aktif, ID ganda diabaikan
+		chosen := make(map[uint]bool)
+		categoryIDs := []uint{}
+		for _, categoryID := range answers.CategoryIDs {
+			if !chosen[categoryID] {
+				chosen[categoryID] = true
+				categoryIDs = append(categoryIDs, categoryID)
+			}
+		}
+		answers.CategoryIDs = categoryIDs
+		if len(answers.CategoryIDs) > 0 {
+			var count int64
+			db.Model(&model.Category{}).Where("id IN ? AND is_active = ?", answers.CategoryIDs, true).Count(&count)
+			if count != int64(len(answers.CategoryIDs)) {
+				errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgCategoryNotFound}
+				return c.JSON(http.StatusNotFound, errorResponse)
+			}
+		}
+
+		weights := quizCategoryWeights(db, answers)
+		categoryIDs = make([]uint, 0, len(weights))
+		for categoryID := range weights {
+			categoryIDs = append(categoryIDs, categoryID)
+		}
+		sort.Slice(categoryIDs, func(i, j int) bool {
+			if weights[categoryIDs[i]] != weights[categoryIDs[j]] {
+				return weights[categoryIDs[i]] > weights[categoryIDs[j]]
+			}
+			return categoryIDs[i] < categoryIDs[j]
+		})
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			quiz := model.UserPreferenceQuiz{UserID: user.ID}
+			if err := tx.Where("user_id = ?", user.ID).FirstOrInit(&quiz).Error; err != nil {
+				return err
+			}
+			quiz.Nature = answers.Nature
+			quiz.Culture = answers.Culture
+			quiz.Budget = answers.Budget
+			quiz.TravelStyle = answers.TravelStyle
+			if err := tx.Save(&quiz).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Where("user_id = ?", user.ID).Delete(&model.UserCategoryPreference{}).Error; err != nil {
+				return err
+			}
+			for _, categoryID := range categoryIDs {
+				preference := model.UserCategoryPreference{UserID: user.ID, CategoryID: categoryID, Weight: weights[categoryID], Source: "quiz"}
+				if err := tx.Create(&preference).Error; err != nil {
+					return err
+				}
+			}
+
+			// Kategori dengan bobot tertinggi tetap disimpan sebagai kategori kesukaan utama pada profil
+			updates := map[string]interface{}{"status_category": true}
+			if len(categoryIDs) > 0 {
+				var top model.Category
+				if err := tx.First(&top, categoryIDs[0]).Error; err != nil {
+					return err
+				}
+				updates["category_id"] = top.ID
+				updates["category_kesukaan"] = top.CategoryName
+			}
+			return tx.Model(&user).Updates(updates).Error
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgQuizSaveFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":        http.StatusOK,
+			"error":       false,
+			"message":     "Preference quiz saved successfully",
+			"preferences": loadUserPreferences(db, requestLocales(c), user.ID),
+		})
+	}
+}
@@ -26,6 +26,14 @@ func GetPromos(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			query = query.Where("nama_promo LIKE ?", "%"+namaPromo+"%")
 		}
 
+		// Admin dapat melihat promo terarsip lewat parameter archived, user hanya melihat promo publik dan voucher pribadinya yang belum dipakai
+		var user model.User
+		if db.Where("username = ?", username).First(&user).Error == nil && user.IsAdmin {
+			query = archivedFilter(c, query, "promos")
+		} else {
+			query = query.Where("owner_user_id IS NULL OR (owner_user_id = ? AND used_at IS NULL)", user.ID)
+		}
+
 		var totalPromos int64
 		query.Count(&totalPromos)
 
@@ -54,6 +62,7 @@ func GetPromos(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		if promos == nil {
 			promos = []model.Promo{}
 		}
+		localizePromos(db, requestLocales(c), promos)
 
 		response := map[string]interface{}{
 			"code":     http.StatusOK,
@@ -81,15 +90,20 @@ func GetPromoByID(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		// Mendapatkan ID promo dari parameter URL
 		promoID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid promo ID"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidPromoID}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
+		var user model.User
+		db.Where("username = ?", username).First(&user)
+
+		// Voucher pribadi hanya dapat dilihat pemiliknya dan admin
 		var promo model.Promo
-		if err := db.First(&promo, promoID).Error; err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Promo not found"}
+		if err := db.First(&promo, promoID).Error; err != nil || (promo.OwnerUserID != nil && *promo.OwnerUserID != user.ID && !user.IsAdmin) {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgPromoNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
+		localizePromo(db, requestLocales(c), &promo)
 
 		return c.JSON(http.StatusOK, map[string]interface{}{
 			"code":     http.StatusOK,
@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"myproject/helper"
+	"myproject/middleware"
+	"myproject/model"
+	"myproject/recommend"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Jumlah rekomendasi teratas yang diperiksa pada evaluasi offline jika parameter k tidak diisi
+const defaultEvaluationK = 10
+
+// bookingHistorySQL adalah kondisi tiket yang dihitung sebagai riwayat kunjungan user
+const bookingHistorySQL = "status_order <> 'dibatalkan' AND (paid_status = true OR status_order = 'success')"
+
+// loadRecommendationCandidates memuat seluruh tempat wisata beserta popularitasnya sebagai kandidat rekomendasi
+func loadRecommendationCandidates(db *gorm.DB) ([]recommend.Candidate, error) {
+	var candidates []recommend.Candidate
+	err := db.Model(&model.Wisata{}).
+		Select("wisata.id, wisata.category_id, wisata.kota AS city, wisata.lat, wisata.`long`, wisata.price, " + popularitySQL + " AS popularity").
+		Scan(&candidates).Error
+	return candidates, err
+}
+
+// userFavoriteIDs mengembalikan ID tempat wisata favorit user
+func userFavoriteIDs(db *gorm.DB, userID uint) []uint {
+	var favoriteIDs []uint
+	db.Model(&model.Favorite{}).Where("user_id = ?", userID).Pluck("wisata_id", &favoriteIDs)
+	return favoriteIDs
+}
+
+// recommendWisata mengurutkan seluruh tempat wisata untuk feed beranda user
+func recommendWisata(db *gorm.DB, user model.User) ([]recommend.Scored, bool, error) {
+	candidates, err := loadRecommendationCandidates(db)
+	if err != nil {
+		return nil, false, err
+	}
+	catalog := make(map[uint]recommend.Candidate, len(candidates))
+	for _, candidate := range candidates {
+		catalog[candidate.ID] = candidate
+	}
+
+	var bookedIDs []uint
+	if err := db.Model(&model.Ticket{}).Where("user_id = ? AND "+bookingHistorySQL, user.ID).Pluck("wisata_id", &bookedIDs).Error; err != nil {
+		return nil, false, err
+	}
+
+	profile := recommend.NewProfile(userPreferredCategories(db, user), bookedIDs, userFavoriteIDs(db, user.ID), catalog)
+	if user.Lat != 0 || user.Long != 0 {
+		profile = profile.WithLocation(user.Lat, user.Long)
+	}
+	profile = profile.WithBudget(userBudget(db, user.ID))
+
+	return recommend.Rank(profile, candidates, recommend.DefaultWeights), profile.IsColdStart(), nil
+}
+
+// Mengevaluasi hit-rate rekomendasi secara offline terhadap pemesanan terakhir setiap user
+func GetRecommendationEvaluationByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		k := defaultEvaluationK
+		if kStr := c.QueryParam("k"); kStr != "" {
+			k, err = strconv.Atoi(kStr)
+			if err != nil || k <= 0 {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidK}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+		}
+
+		candidates, err := loadRecommendationCandidates(db)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgWisataFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var tickets []model.Ticket
+		if err := db.Select("user_id, wisata_id, created_at").Where(bookingHistorySQL).Find(&tickets).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgTicketsFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		bookings := make([]recommend.Booking, 0, len(tickets))
+		userIDs := make(map[uint]bool)
+		for _, ticket := range tickets {
+			booking := recommend.Booking{UserID: ticket.UserID, WisataID: ticket.WisataID}
+			if ticket.CreatedAt != nil {
+				booking.BookedAt = *ticket.CreatedAt
+			}
+			bookings = append(bookings, booking)
+			userIDs[ticket.UserID] = true
+		}
+
+		var users []model.User
+		ids := make([]uint, 0, len(userIDs))
+		for id := range userIDs {
+			ids = append(ids, id)
+		}
+		db.Where("id IN ?", ids).Find(&users)
+
+		signals := make(map[uint]recommend.UserSignals, len(users))
+		for _, user := range users {
+			signals[user.ID] = recommend.UserSignals{
+				PreferredCategories: userPreferredCategories(db, user),
+				FavoriteIDs:         userFavoriteIDs(db, user.ID),
+				Lat:                 user.Lat,
+				Long:                user.Long,
+				HasLocation:         user.Lat != 0 || user.Long != 0,
+				MaxPrice:            userBudget(db, user.ID),
+			}
+		}
+
+		evaluation := recommend.Evaluate(bookings, candidates, signals, recommend.DefaultWeights, k)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":         http.StatusOK,
+			"error":        false,
+			"evaluation":   evaluation,
+			"evaluated_at": time.Now(),
+		})
+	}
+}
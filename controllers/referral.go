@@ -0,0 +1,338 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"myproject/helper"
+	"myproject/middleware"
+	"myproject/model"
+	"net/http"
+	"time"
+)
+
+var (
+	errReferralCodeNotFound = helper.NewMessageError(helper.MsgReferralCodeInvalid)
+	errSelfReferral         = helper.NewMessageError(helper.MsgSelfReferral)
+	errReferralDeviceLimit  = helper.NewMessageError(helper.MsgReferralDeviceLimit)
+	errReferralPhoneLimit   = helper.NewMessageError(helper.MsgReferralPhoneLimit)
+	errReferralEmailLimit   = helper.NewMessageError(helper.MsgReferralEmailLimit)
+	errReferrerLimit        = helper.NewMessageError(helper.MsgReferrerLimit)
+)
+
+func isReferralError(err error) bool {
+	return errors.Is(err, errReferralCodeNotFound) ||
+		errors.Is(err, errSelfReferral) ||
+		errors.Is(err, errReferralDeviceLimit) ||
+		errors.Is(err, errReferralPhoneLimit) ||
+		errors.Is(err, errReferralEmailLimit) ||
+		errors.Is(err, errReferrerLimit)
+}
+
+// generateUniqueReferralCode membuat kode referral yang belum dipakai user lain
+func generateUniqueReferralCode(db *gorm.DB, name string) string {
+	for {
+		code := helper.GenerateReferralCode(name)
+		var count int64
+		db.Model(&model.User{}).Where("referral_code = ?", code).Count(&count)
+		if count == 0 {
+			return code
+		}
+	}
+}
+
+// ensureReferralCode memberikan kode referral kepada user lama yang belum memilikinya
+func ensureReferralCode(db *gorm.DB, user *model.User) error {
+	if user.ReferralCode != "" {
+		return nil
+	}
+	user.ReferralCode = generateUniqueReferralCode(db, user.Name)
+	return db.Model(user).Update("referral_code", user.ReferralCode).Error
+}
+
+// validateReferralCode memeriksa kode referral beserta batasan anti-fraud sebelum user baru dibuat.
+// phoneNumber dikosongkan untuk signup Google karena nomornya acak, pemeriksaan lalu bertumpu pada email dan device id.
+func validateReferralCode(db *gorm.DB, code, phoneNumber, email, deviceID string) (*model.User, error) {
+	config := helper.GetReferralConfig()
+
+	var referrer model.User
+	if err := db.Where("referral_code = ?", code).First(&referrer).Error; err != nil {
+		return nil, errReferralCodeNotFound
+	}
+
+	normalizedEmail := helper.NormalizeEmail(email)
+	if (phoneNumber != "" && referrer.PhoneNumber == phoneNumber) ||
+		helper.NormalizeEmail(referrer.Email) == normalizedEmail ||
+		(deviceID != "" && referrer.DeviceID == deviceID) {
+		return nil, errSelfReferral
+	}
+
+	var count int64
+	if deviceID != "" {
+		db.Model(&model.Referral{}).Where("device_id = ?", deviceID).Count(&count)
+		if int(count) >= config.MaxPerDevice {
+			return nil, errReferralDeviceLimit
+		}
+	}
+
+	if phoneNumber != "" {
+		db.Model(&model.Referral{}).Where("referee_phone = ?", phoneNumber).Count(&count)
+		if int(count) >= config.MaxPerPhone {
+			return nil, errReferralPhoneLimit
+		}
+	}
+
+	db.Model(&model.Referral{}).Where("referee_email = ?", normalizedEmail).Count(&count)
+	if int(count) >= config.MaxPerEmail {
+		return nil, errReferralEmailLimit
+	}
+
+	db.Model(&model.Referral{}).Where("referrer_id = ?", referrer.ID).Count(&count)
+	if int(count) >= config.MaxPerReferrer {
+		return nil, errReferrerLimit
+	}
+
+	return &referrer, nil
+}
+
+// createReferral mencatat referral, refereePhone dikosongkan jika nomor telepon referee bukan nomor asli
+func createReferral(db *gorm.DB, referrer *model.User, referee *model.User, refereePhone string) error {
+	referral := model.Referral{
+		ReferrerID:   referrer.ID,
+		RefereeID:    referee.ID,
+		ReferralCode: referrer.ReferralCode,
+		RefereePhone: refereePhone,
+		RefereeEmail: helper.NormalizeEmail(referee.Email),
+		DeviceID:     referee.DeviceID,
+		Status:       "pending",
+	}
+	return db.Create(&referral).Error
+}
+
+// rewardReferral memberikan hadiah kepada referrer dan referee setelah tiket berbayar pertama referee
+func rewardReferral(db *gorm.DB, refereeID uint) error {
+	var referral model.Referral
+	if err := db.Where("referee_id = ? AND status = ?", refereeID, "pending").First(&referral).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	config := helper.GetReferralConfig()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		// Status diklaim lebih dulu agar pemanggilan bersamaan atau berulang tidak memberi hadiah dua kali
+		now := time.Now()
+		result := tx.Model(&model.Referral{}).Where("id = ? AND status = ?", referral.ID, "pending").Updates(map[string]interface{}{
+			"status":      "rewarded",
+			"reward_type": config.RewardType,
+			"rewarded_at": &now,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return nil
+		}
+
+		rewards := []struct {
+			userID uint
+			points int
+		}{
+			{referral.ReferrerID, config.ReferrerPoints},
+			{referral.RefereeID, config.RefereePoints},
+		}
+
+		for _, reward := range rewards {
+			if config.RewardType == "voucher" {
+				if err := giveReferralVoucher(tx, reward.userID, config); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := tx.Model(&model.User{}).Where("id = ?", reward.userID).
+				Update("points", gorm.Expr("points + ?", reward.points)).Error; err != nil {
+				return err
+			}
+
+			locales := userLocales(tx, reward.userID)
+			notification := model.Notification{
+				UserID:  reward.userID,
+				Title:   helper.Translate(locales, helper.MsgNotificationReferralTitle),
+				Message: helper.Translate(locales, helper.MsgNotificationReferralPoints, reward.points),
+				Status:  "unread",
+				Type:    notificationTypeReferral,
+			}
+			if err := tx.Create(&notification).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func giveReferralVoucher(tx *gorm.DB, userID uint, config helper.ReferralConfig) error {
+	// Voucher hanya berlaku untuk user penerima dan hanya sekali pakai
+	kodeVoucher := "REF" + helper.GenerateRandomString(8)
+	promo := model.Promo{
+		OwnerUserID:          &userID,
+		SingleUse:            true,
+		Title:                "Voucher Referral " + kodeVoucher,
+		NamaPromo:            "Voucher Referral " + kodeVoucher,
+		KodeVoucher:          kodeVoucher,
+		JumlahPotonganPersen: config.VoucherPercent,
+		StatusAktif:          true,
+		TanggalKadaluarsa:    time.Now().AddDate(0, 0, config.VoucherDays),
+		Deskripsi:            "Voucher hadiah dari program referral Destimate",
+		Peraturan:            fmt.Sprintf("Berlaku %d hari sejak diterima", config.VoucherDays),
+	}
+	if err := tx.Create(&promo).Error; err != nil {
+		return err
+	}
+
+	locales := userLocales(tx, userID)
+	notification := model.Notification{
+		UserID:  userID,
+		Title:   helper.Translate(locales, helper.MsgNotificationPromoTitle, promo.Title),
+		Message: helper.Translate(locales, helper.MsgNotificationReferralVoucher, kodeVoucher, config.VoucherPercent),
+		Status:  "unread",
+		PromoID: promo.ID,
+		Type:    notificationTypeReferral,
+	}
+	return tx.Create(&notification).Error
+}
+
+func GetUserReferral(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		if err := ensureReferralCode(db, &user); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgReferralCodeGenerateFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var totalReferrals, rewardedReferrals int64
+		db.Model(&model.Referral{}).Where("referrer_id = ?", user.ID).Count(&totalReferrals)
+		db.Model(&model.Referral{}).Where("referrer_id = ? AND status = ?", user.ID, "rewarded").Count(&rewardedReferrals)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":               http.StatusOK,
+			"error":              false,
+			"message":            "User referral retrieved successfully",
+			"referral_code":      user.ReferralCode,
+			"total_referrals":    totalReferrals,
+			"rewarded_referrals": rewardedReferrals,
+			"pending_referrals":  totalReferrals - rewardedReferrals,
+		})
+	}
+}
+
+func GetReferralStatsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		status := c.QueryParam("status")
+		page, perPage := helper.GetPaginationParams(c)
+
+		var totalReferrals, rewardedReferrals int64
+		db.Model(&model.Referral{}).Count(&totalReferrals)
+		db.Model(&model.Referral{}).Where("status = ?", "rewarded").Count(&rewardedReferrals)
+
+		type TopReferrer struct {
+			UserID            uint   `json:"user_id"`
+			Name              string `json:"name"`
+			ReferralCode      string `json:"referral_code"`
+			TotalReferrals    int64  `json:"total_referrals"`
+			RewardedReferrals int64  `json:"rewarded_referrals"`
+		}
+		var topReferrers []TopReferrer
+		if err := db.Model(&model.Referral{}).
+			Select("referrals.referrer_id AS user_id, users.name AS name, users.referral_code AS referral_code, COUNT(referrals.id) AS total_referrals, SUM(CASE WHEN referrals.status = 'rewarded' THEN 1 ELSE 0 END) AS rewarded_referrals").
+			Joins("JOIN users ON users.id = referrals.referrer_id").
+			Group("referrals.referrer_id, users.name, users.referral_code").
+			Order("total_referrals desc").Limit(5).
+			Scan(&topReferrers).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgTopReferrersFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		query := db.Model(&model.Referral{}).Order("created_at DESC")
+		if status != "" {
+			query = query.Where("status = ?", status)
+		}
+
+		var totalFiltered int64
+		query.Count(&totalFiltered)
+
+		var totalPages int
+		if perPage > 0 {
+			totalPages = int((totalFiltered + int64(perPage) - 1) / int64(perPage))
+		} else {
+			totalPages = 0
+		}
+
+		var referrals []model.Referral
+		query.Offset((page - 1) * perPage).Limit(perPage).Find(&referrals)
+
+		var referralDetails []map[string]interface{}
+		for _, referral := range referrals {
+			var referrer, referee model.User
+			db.First(&referrer, referral.ReferrerID)
+			db.First(&referee, referral.RefereeID)
+
+			referralDetails = append(referralDetails, map[string]interface{}{
+				"id":            referral.ID,
+				"referrer_id":   referral.ReferrerID,
+				"referrer_name": referrer.Name,
+				"referee_id":    referral.RefereeID,
+				"referee_name":  referee.Name,
+				"referral_code": referral.ReferralCode,
+				"status":        referral.Status,
+				"reward_type":   referral.RewardType,
+				"rewarded_at":   referral.RewardedAt,
+				"created_at":    referral.CreatedAt,
+			})
+		}
+
+		if referralDetails == nil {
+			referralDetails = []map[string]interface{}{}
+		}
+
+		if topReferrers == nil {
+			topReferrers = []TopReferrer{}
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":  http.StatusOK,
+			"error": false,
+			"stats": map[string]interface{}{
+				"total_referrals":    totalReferrals,
+				"rewarded_referrals": rewardedReferrals,
+				"pending_referrals":  totalReferrals - rewardedReferrals,
+				"top_referrers":      topReferrers,
+			},
+			"referrals": referralDetails,
+			"pagination": map[string]interface{}{
+				"current_page": page,
+				"from":         (page-1)*perPage + 1,
+				"last_page":    totalPages,
+				"per_page":     perPage,
+				"to":           (page-1)*perPage + len(referrals),
+				"total":        totalFiltered,
+			},
+		})
+	}
+}
@@ -0,0 +1,310 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"log"
+	"math"
+	"myproject/helper"
+	"myproject/middleware"
+	"myproject/model"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Jumlah pasangan co-booking yang disimpan untuk setiap tempat wisata
+const maxCoBookingsPerWisata = 20
+
+// Interval bawaan job perhitungan co-booking, dapat diubah lewat env CO_BOOKING_REFRESH_MINUTES
+const defaultCoBookingRefreshInterval = time.Hour
+
+// Nama lock database agar hanya satu instance aplikasi yang menghitung co-booking pada satu waktu
+const coBookingJobLock = "destimate_co_booking_refresh"
+
+const (
+	defaultRelatedLimit = 10
+	maxRelatedLimit     = 50
+)
+
+// Rentang harga (persen dari harga wisata acuan) yang masih dianggap satu kelas harga
+const similarPriceBand = 0.5
+
+// Bobot setiap sinyal kemiripan tempat wisata
+const (
+	similarCategoryWeight = 0.4
+	similarCityWeight     = 0.3
+	similarFacilityWeight = 0.2
+	similarPriceWeight    = 0.1
+)
+
+// RefreshCoBookings menghitung ulang pasangan tempat wisata yang dipesan oleh user yang sama dari tabel tickets.
+// Seluruh perhitungan ulang berjalan dalam satu transaksi dan dilewati jika instance lain sedang menjalankannya.
+func RefreshCoBookings(db *gorm.DB) error {
+	return withJobLock(db, coBookingJobLock, func(tx *gorm.DB) error {
+		var rows []struct {
+			UserID   uint
+			WisataID uint
+		}
+		if err := tx.Model(&model.Ticket{}).Distinct("user_id", "wisata_id").Where(bookingHistorySQL).Scan(&rows).Error; err != nil {
+			return err
+		}
+
+		wisataByUser := make(map[uint][]uint)
+		userCount := make(map[uint]int)
+		for _, row := range rows {
+			wisataByUser[row.UserID] = append(wisataByUser[row.UserID], row.WisataID)
+			userCount[row.WisataID]++
+		}
+
+		pairCount := make(map[[2]uint]int)
+		for _, wisataIDs := range wisataByUser {
+			for _, a := range wisataIDs {
+				for _, b := range wisataIDs {
+					if a != b {
+						pairCount[[2]uint{a, b}]++
+					}
+				}
+			}
+		}
+
+		now := time.Now()
+		related := make(map[uint][]model.CoBooking)
+		for pair, count := range pairCount {
+			related[pair[0]] = append(related[pair[0]], model.CoBooking{
+				WisataID:        pair[0],
+				RelatedWisataID: pair[1],
+				UserCount:       count,
+				Score:           float64(count) / math.Sqrt(float64(userCount[pair[0]]*userCount[pair[1]])),
+				UpdatedAt:       now,
+			})
+		}
+
+		var coBookings []model.CoBooking
+		for _, pairs := range related {
+			sort.Slice(pairs, func(i, j int) bool {
+				if pairs[i].Score != pairs[j].Score {
+					return pairs[i].Score > pairs[j].Score
+				}
+				return pairs[i].RelatedWisataID < pairs[j].RelatedWisataID
+			})
+			if len(pairs) > maxCoBookingsPerWisata {
+				pairs = pairs[:maxCoBookingsPerWisata]
+			}
+			coBookings = append(coBookings, pairs...)
+		}
+
+		if err := tx.Where("1 = 1").Delete(&model.CoBooking{}).Error; err != nil {
+			return err
+		}
+		if len(coBookings) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(&coBookings, 500).Error
+	})
+}
+
+func coBookingRefreshInterval() time.Duration {
+	minutes, err := strconv.Atoi(os.Getenv("CO_BOOKING_REFRESH_MINUTES"))
+	if err != nil || minutes <= 0 {
+		return defaultCoBookingRefreshInterval
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// StartCoBookingJob menjalankan perhitungan co-booking saat server mulai lalu secara berkala
+func StartCoBookingJob(db *gorm.DB) {
+	go func() {
+		ticker := time.NewTicker(coBookingRefreshInterval())
+		defer ticker.Stop()
+		for {
+			if err := RefreshCoBookings(db); err != nil {
+				log.Println("Gagal menghitung co-booking wisata:", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+func relatedLimit(c echo.Context) int {
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil || limit <= 0 {
+		return defaultRelatedLimit
+	}
+	return min(limit, maxRelatedLimit)
+}
+
+// findWisatasInOrder memuat tempat wisata sesuai urutan ID yang diberikan
+func findWisatasInOrder(db *gorm.DB, wisataIDs []uint) ([]model.Wisata, error) {
+	wisatas := []model.Wisata{}
+	if len(wisataIDs) == 0 {
+		return wisatas, nil
+	}
+	err := db.Preload("Category").
+		Where("id IN ?", wisataIDs).
+		Order(clause.Expr{SQL: "FIELD(id, ?)", Vars: []interface{}{wisataIDs}, WithoutParentheses: true}).
+		Find(&wisatas).Error
+	return wisatas, err
+}
+
+// similarityScore menghitung kemiripan dua tempat wisata dari kategori, kota, fasilitas dan kelas harga
+func similarityScore(base, other model.Wisata) float64 {
+	var score float64
+	if base.CategoryID == other.CategoryID {
+		score += similarCategoryWeight
+	}
+	if base.Kota == other.Kota {
+		score += similarCityWeight
+	}
+
+	baseFacilities := make(map[uint]bool)
+	for _, facility := range base.Facilities {
+		baseFacilities[facility.ID] = true
+	}
+	shared := 0
+	for _, facility := range other.Facilities {
+		if baseFacilities[facility.ID] {
+			shared++
+		}
+	}
+	if union := len(base.Facilities) + len(other.Facilities) - shared; union > 0 {
+		score += similarFacilityWeight * float64(shared) / float64(union)
+	}
+
+	if maxPrice := math.Max(float64(base.Price), float64(other.Price)); maxPrice > 0 {
+		if diff := math.Abs(float64(base.Price-other.Price)) / maxPrice; diff <= similarPriceBand {
+			score += similarPriceWeight * (1 - diff/similarPriceBand)
+		}
+	} else {
+		score += similarPriceWeight
+	}
+	return score
+}
+
+// Menampilkan tempat wisata yang mirip berdasarkan kategori, kota, fasilitas dan kelas harga
+func GetSimilarWisata(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var wisata model.Wisata
+		if err := db.Preload("Facilities").First(&wisata, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgWisataNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		facilityIDs := []uint{0}
+		for _, facility := range wisata.Facilities {
+			facilityIDs = append(facilityIDs, facility.ID)
+		}
+		minPrice := int(float64(wisata.Price) * (1 - similarPriceBand))
+		maxPrice := int(float64(wisata.Price) * (1 + similarPriceBand))
+
+		// Kandidat dibatasi pada wisata yang memiliki setidaknya satu kesamaan
+		var candidates []model.Wisata
+		if err := db.Preload("Facilities").
+			Where("id <> ?", wisata.ID).
+			Where(db.Where("category_id = ?", wisata.CategoryID).
+				Or("kota = ?", wisata.Kota).
+				Or("price BETWEEN ? AND ?", minPrice, maxPrice).
+				Or("id IN (SELECT wisata_id FROM wisata_facilities WHERE facility_id IN ?)", facilityIDs)).
+			Find(&candidates).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgSimilarWisataFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		scores := make(map[uint]float64, len(candidates))
+		for _, candidate := range candidates {
+			scores[candidate.ID] = similarityScore(wisata, candidate)
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			if scores[candidates[i].ID] != scores[candidates[j].ID] {
+				return scores[candidates[i].ID] > scores[candidates[j].ID]
+			}
+			return candidates[i].AverageRating > candidates[j].AverageRating
+		})
+
+		limit := relatedLimit(c)
+		var similarIDs []uint
+		for _, candidate := range candidates {
+			if len(similarIDs) >= limit {
+				break
+			}
+			similarIDs = append(similarIDs, candidate.ID)
+		}
+
+		wisatas, err := findWisatasInOrder(db, similarIDs)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgSimilarWisataFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		localizeWisatas(db, requestLocales(c), wisatas)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"wisatas": wisatas,
+		})
+	}
+}
+
+// Menampilkan tempat wisata yang juga dipesan oleh user lain yang memesan tempat wisata ini
+func GetAlsoBookedWisata(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var wisata model.Wisata
+		if err := db.First(&wisata, c.Param("id")).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgWisataNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var coBookings []model.CoBooking
+		if err := db.Where("wisata_id = ?", wisata.ID).
+			Order("score desc, user_count desc").
+			Limit(relatedLimit(c)).
+			Find(&coBookings).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgAlsoBookedFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var relatedIDs []uint
+		for _, coBooking := range coBookings {
+			relatedIDs = append(relatedIDs, coBooking.RelatedWisataID)
+		}
+		var updatedAt *time.Time
+		if len(coBookings) > 0 {
+			updatedAt = &coBookings[0].UpdatedAt
+		}
+
+		wisatas, err := findWisatasInOrder(db, relatedIDs)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgAlsoBookedFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		localizeWisatas(db, requestLocales(c), wisatas)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":       http.StatusOK,
+			"error":      false,
+			"wisatas":    wisatas,
+			"updated_at": updatedAt,
+		})
+	}
+}
@@ -0,0 +1,403 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"io"
+	"myproject/helper"
+	"myproject/middleware"
+	"myproject/model"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxReviewPhotos = 5
+
+// findReviewableTicket mencari tiket yang membuktikan user benar-benar berkunjung: sudah dibayar (success) dan tidak dibatalkan
+func findReviewableTicket(db *gorm.DB, userID, wisataID uint) (model.Ticket, error) {
+	var ticket model.Ticket
+	err := db.Where("user_id = ? AND wisata_id = ? AND status_order <> ?", userID, wisataID, "dibatalkan").
+		Where("paid_status = ? OR status_order = ?", true, "success").
+		Order("checkin_booking desc").
+		First(&ticket).Error
+	return ticket, err
+}
+
+// recalculateWisataRating menghitung ulang rata-rata dan jumlah rating dari review yang tidak disembunyikan
+func recalculateWisataRating(db *gorm.DB, wisataID uint) error {
+	var summary struct {
+		AverageRating float64
+		RatingCount   int
+	}
+	if err := db.Model(&model.Review{}).
+		Select("COALESCE(AVG(rating), 0) AS average_rating, COUNT(id) AS rating_count").
+		Where("wisata_id = ? AND is_hidden = ?", wisataID, false).
+		Scan(&summary).Error; err != nil {
+		return err
+	}
+
+	return db.Model(&model.Wisata{}).Where("id = ?", wisataID).Updates(map[string]interface{}{
+		"average_rating": summary.AverageRating,
+		"rating_count":   summary.RatingCount,
+	}).Error
+}
+
+func reviewResponse(review model.Review, user model.User) map[string]interface{} {
+	var photos []string
+	if review.Photos != "" {
+		json.Unmarshal([]byte(review.Photos), &photos)
+	}
+	if photos == nil {
+		photos = []string{}
+	}
+
+	return map[string]interface{}{
+		"id":            review.ID,
+		"wisata_id":     review.WisataID,
+		"user_id":       review.UserID,
+		"user_name":     user.Name,
+		"photo_profil":  user.PhotoProfil,
+		"rating":        review.Rating,
+		"text":          review.Text,
+		"photos":        photos,
+		"is_hidden":     review.IsHidden,
+		"is_flagged":    review.IsFlagged,
+		"flag_reason":   review.FlagReason,
+		"admin_reply":   review.AdminReply,
+		"replied_at":    review.RepliedAt,
+		"created_at":    review.CreatedAt,
+		"verified_stay": review.TicketID != 0,
+	}
+}
+
+// Membuat atau memperbarui review user untuk tempat wisata yang pernah dikunjungi
+func CreateReview(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		wisataID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidWisataID}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var wisata model.Wisata
+		if err := db.First(&wisata, wisataID).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgWisataNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		rating, err := strconv.Atoi(c.FormValue("rating"))
+		if err != nil || rating < 1 || rating > 5 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidRating}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		ticket, err := findReviewableTicket(db, user.ID, wisata.ID)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, ErrorCode: helper.MsgReviewNotAllowed}
+			return c.JSON(http.StatusForbidden, errorResponse)
+		}
+
+		var photoUrls []string
+		if form, err := c.MultipartForm(); err == nil {
+			photoFiles := form.File["photos"]
+			if len(photoFiles) > maxReviewPhotos {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgReviewTooManyPhotos, Args: []interface{}{maxReviewPhotos}}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+
+			for i, photoFile := range photoFiles {
+				if !helper.IsImageFile(photoFile) {
+					errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidImageFileType}
+					return c.JSON(http.StatusBadRequest, errorResponse)
+				}
+
+				if helper.IsFileSizeExceeds(photoFile, 5*1024*1024) {
+					errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgFileTooLarge}
+					return c.JSON(http.StatusBadRequest, errorResponse)
+				}
+
+				src, err := photoFile.Open()
+				if err != nil {
+					errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgImageOpenFailed}
+					return c.JSON(http.StatusBadRequest, errorResponse)
+				}
+				imageData, err := io.ReadAll(src)
+				src.Close()
+				if err != nil {
+					errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgImageReadFailed}
+					return c.JSON(http.StatusBadRequest, errorResponse)
+				}
+
+				imageName := fmt.Sprintf("reviews/wisata%d/user%d_%d_%d.jpg", wisata.ID, user.ID, time.Now().Unix(), i)
+				imageURL, err := helper.UploadImageToGCS(imageData, imageName)
+				if err != nil {
+					errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgImageUploadFailed}
+					return c.JSON(http.StatusInternalServerError, errorResponse)
+				}
+				photoUrls = append(photoUrls, imageURL)
+			}
+		}
+
+		// Satu user hanya memiliki satu review per wisata, review baru menggantikan yang lama
+		var review model.Review
+		db.Where("user_id = ? AND wisata_id = ?", user.ID, wisata.ID).First(&review)
+		review.UserID = user.ID
+		review.WisataID = wisata.ID
+		review.TicketID = ticket.ID
+		review.Rating = rating
+		review.Text = strings.TrimSpace(c.FormValue("text"))
+		if photoUrls != nil || review.Photos == "" {
+			if photoUrls == nil {
+				photoUrls = []string{}
+			}
+			photosJSON, _ := json.Marshal(photoUrls)
+			review.Photos = string(photosJSON)
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Save(&review).Error; err != nil {
+				return err
+			}
+			return recalculateWisataRating(tx, wisata.ID)
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgReviewSaveFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"code":    http.StatusCreated,
+			"error":   false,
+			"message": "Review saved successfully",
+			"review":  reviewResponse(review, user),
+		})
+	}
+}
+
+// Menampilkan review yang tidak disembunyikan untuk sebuah tempat wisata
+func GetWisataReviews(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		wisataID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidWisataID}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var wisata model.Wisata
+		if err := db.First(&wisata, wisataID).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgWisataNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		page, perPage := helper.GetPaginationParams(c)
+
+		query := db.Model(&model.Review{}).Where("wisata_id = ? AND is_hidden = ?", wisata.ID, false)
+		if rating := c.QueryParam("rating"); rating != "" {
+			query = query.Where("rating = ?", rating)
+		}
+
+		var totalReviews int64
+		query.Count(&totalReviews)
+
+		var totalPages int
+		if perPage > 0 {
+			totalPages = int((totalReviews + int64(perPage) - 1) / int64(perPage))
+		} else {
+			totalPages = 0
+		}
+
+		var reviews []model.Review
+		query.Order("created_at desc").Offset((page - 1) * perPage).Limit(perPage).Find(&reviews)
+
+		reviewDetails := []map[string]interface{}{}
+		for _, review := range reviews {
+			var user model.User
+			db.First(&user, review.UserID)
+			reviewDetails = append(reviewDetails, reviewResponse(review, user))
+		}
+
+		type RatingDistribution struct {
+			Rating int   `json:"rating"`
+			Total  int64 `json:"total"`
+		}
+		var distribution []RatingDistribution
+		db.Model(&model.Review{}).
+			Select("rating, COUNT(id) AS total").
+			Where("wisata_id = ? AND is_hidden = ?", wisata.ID, false).
+			Group("rating").
+			Order("rating desc").
+			Scan(&distribution)
+
+		if distribution == nil {
+			distribution = []RatingDistribution{}
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":           http.StatusOK,
+			"error":          false,
+			"average_rating": wisata.AverageRating,
+			"rating_count":   wisata.RatingCount,
+			"distribution":   distribution,
+			"reviews":        reviewDetails,
+			"pagination": map[string]interface{}{
+				"current_page": page,
+				"from":         (page-1)*perPage + 1,
+				"last_page":    totalPages,
+				"per_page":     perPage,
+				"to":           (page-1)*perPage + len(reviews),
+				"total":        totalReviews,
+			},
+		})
+	}
+}
+
+// Menampilkan seluruh review untuk moderasi admin, termasuk yang disembunyikan
+func GetReviewsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		page, perPage := helper.GetPaginationParams(c)
+
+		query := db.Model(&model.Review{}).Order("created_at desc")
+		if wisataID := c.QueryParam("wisata_id"); wisataID != "" {
+			query = query.Where("wisata_id = ?", wisataID)
+		}
+		if flagged := c.QueryParam("flagged"); flagged != "" {
+			query = query.Where("is_flagged = ?", flagged == "true")
+		}
+		if hidden := c.QueryParam("hidden"); hidden != "" {
+			query = query.Where("is_hidden = ?", hidden == "true")
+		}
+
+		var totalReviews int64
+		query.Count(&totalReviews)
+
+		var totalPages int
+		if perPage > 0 {
+			totalPages = int((totalReviews + int64(perPage) - 1) / int64(perPage))
+		} else {
+			totalPages = 0
+		}
+
+		var reviews []model.Review
+		query.Offset((page - 1) * perPage).Limit(perPage).Find(&reviews)
+
+		reviewDetails := []map[string]interface{}{}
+		for _, review := range reviews {
+			var user model.User
+			db.Unscoped().First(&user, review.UserID)
+			var wisata model.Wisata
+			db.Unscoped().First(&wisata, review.WisataID)
+
+			detail := reviewResponse(review, user)
+			detail["wisata_title"] = wisata.Title
+			reviewDetails = append(reviewDetails, detail)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"reviews": reviewDetails,
+			"pagination": map[string]interface{}{
+				"current_page": page,
+				"from":         (page-1)*perPage + 1,
+				"last_page":    totalPages,
+				"per_page":     perPage,
+				"to":           (page-1)*perPage + len(reviews),
+				"total":        totalReviews,
+			},
+		})
+	}
+}
+
+// Menyembunyikan, menandai atau membalas review oleh admin
+func ModerateReviewByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		reviewID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidReviewID}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var review model.Review
+		if err := db.First(&review, reviewID).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgReviewNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		var requestBody struct {
+			Hidden     *bool   `json:"hidden"`
+			Flagged    *bool   `json:"flagged"`
+			FlagReason *string `json:"flag_reason"`
+			Reply      *string `json:"reply"`
+		}
+		if err := c.Bind(&requestBody); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidRequestBody}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		if requestBody.Hidden != nil {
+			review.IsHidden = *requestBody.Hidden
+		}
+		if requestBody.Flagged != nil {
+			review.IsFlagged = *requestBody.Flagged
+			if !review.IsFlagged {
+				review.FlagReason = ""
+			}
+		}
+		if requestBody.FlagReason != nil {
+			review.FlagReason = *requestBody.FlagReason
+		}
+		if requestBody.Reply != nil {
+			review.AdminReply = strings.TrimSpace(*requestBody.Reply)
+			if review.AdminReply == "" {
+				review.RepliedAt = nil
+			} else {
+				now := time.Now()
+				review.RepliedAt = &now
+			}
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Save(&review).Error; err != nil {
+				return err
+			}
+			return recalculateWisataRating(tx, review.WisataID)
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgReviewModerateFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var user model.User
+		db.First(&user, review.UserID)
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Review moderated successfully",
+			"review":  reviewResponse(review, user),
+		})
+	}
+}
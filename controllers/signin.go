@@ -23,11 +23,11 @@ func AdminSignin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		// Validasi apakah username dan password telah diisi
 		if user.Username == "" {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Username is required"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgUsernameRequired}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 		if user.Password == "" {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Password is required"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgPasswordRequired}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
@@ -36,10 +36,10 @@ func AdminSignin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		result := db.Where("username = ?", user.Username).First(&existingUser)
 		if result.Error != nil {
 			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-				errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid username"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, ErrorCode: helper.MsgInvalidUsername}
 				return c.JSON(http.StatusUnauthorized, errorResponse)
 			} else {
-				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to check username"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUsernameCheckFailed}
 				return c.JSON(http.StatusInternalServerError, errorResponse)
 			}
 		}
@@ -47,40 +47,40 @@ func AdminSignin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		// Membandingkan password yang dimasukkan dengan password yang di-hash
 		err := bcrypt.CompareHashAndPassword([]byte(existingUser.Password), []byte(user.Password))
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid password"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, ErrorCode: helper.MsgInvalidPassword}
 			return c.JSON(http.StatusUnauthorized, errorResponse)
 		}
 
 		if !existingUser.IsAdmin {
-			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied. User is not an admin."}
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, ErrorCode: helper.MsgNotAdmin}
 			return c.JSON(http.StatusForbidden, errorResponse)
 		}
 
 		if !existingUser.IsVerified {
-			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Account not verified. Please verify your email before logging in."}
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, ErrorCode: helper.MsgAccountNotVerified}
 			return c.JSON(http.StatusUnauthorized, errorResponse)
 		}
 
 		// Generate JWT token
 		tokenString, err := middleware.GenerateToken(existingUser.Username, secretKey)
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to generate token"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgTokenGenerateFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
 		// Mendapatkan informasi waktu kadaluwarsa token
 		token, _, err := new(jwt.Parser).ParseUnverified(tokenString, jwt.MapClaims{})
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to parse token"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgTokenParseFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
 		// Goroutine untuk mengirimkan notifikasi login ke email
-		go func(email, username string) {
-			if err := helper.SendLoginNotification(email, username); err != nil {
+		go func(locales []string, email, username string) {
+			if err := helper.SendLoginNotification(locales, email, username); err != nil {
 				fmt.Println("Failed to send notification email:", err)
 			}
-		}(existingUser.Email, existingUser.Username)
+		}(helper.UserLocales(existingUser.Locale, helper.RequestLocales(c)), existingUser.Email, existingUser.Username)
 
 		return c.JSON(http.StatusOK, map[string]interface{}{
 			"code":        http.StatusOK,
@@ -107,11 +107,11 @@ func Signin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		// Validasi apakah username dan password telah diisi
 		if user.Username == "" {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Username is required"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgUsernameRequired}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 		if user.Password == "" {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Password is required"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgPasswordRequired}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
@@ -120,10 +120,10 @@ func Signin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		result := db.Where("username = ?", user.Username).First(&existingUser)
 		if result.Error != nil {
 			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-				errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid username"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, ErrorCode: helper.MsgInvalidUsername}
 				return c.JSON(http.StatusUnauthorized, errorResponse)
 			} else {
-				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to check username"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUsernameCheckFailed}
 				return c.JSON(http.StatusInternalServerError, errorResponse)
 			}
 		}
@@ -131,33 +131,33 @@ func Signin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		// Membandingkan password yang dimasukkan dengan password yang di-hash
 		err := bcrypt.CompareHashAndPassword([]byte(existingUser.Password), []byte(user.Password))
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Invalid password"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, ErrorCode: helper.MsgInvalidPassword}
 			return c.JSON(http.StatusUnauthorized, errorResponse)
 		}
 
 		if existingUser.IsAdmin {
-			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied. Admin cannot use this endpoint."}
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, ErrorCode: helper.MsgAdminEndpointForbidden}
 			return c.JSON(http.StatusForbidden, errorResponse)
 		}
 
 		if !existingUser.IsVerified {
-			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Account not verified. Please verify your email before logging in."}
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, ErrorCode: helper.MsgAccountNotVerified}
 			return c.JSON(http.StatusUnauthorized, errorResponse)
 		}
 
 		// Generate JWT token
 		tokenString, err := middleware.GenerateToken(existingUser.Username, secretKey)
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to generate token"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgTokenGenerateFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
 		// Goroutine untuk mengirimkan email notifikasi
-		go func(email, username string) {
-			if err := helper.SendLoginNotification(email, username); err != nil {
+		go func(locales []string, email, username string) {
+			if err := helper.SendLoginNotification(locales, email, username); err != nil {
 				fmt.Println("Failed to send notification email:", err)
 			}
-		}(existingUser.Email, existingUser.Username)
+		}(helper.UserLocales(existingUser.Locale, helper.RequestLocales(c)), existingUser.Email, existingUser.Username)
 
 		// Menyertakan ID pengguna dalam respons
 		return c.JSON(http.StatusOK, map[string]interface{}{"code": http.StatusOK, "error": false, "message": "User login successful", "token": tokenString, "id": existingUser.ID})
@@ -23,19 +23,19 @@ func Signup(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		// Validasi data name
 		if len(user.Name) < 3 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Name must be at least 3 characters"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgNameMin3}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		// Validasi data username
 		if len(user.Username) < 5 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Username must be at least 5 characters"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgUsernameTooShort}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		// Validasi data password
 		if len(user.Password) < 8 || !helper.IsValidPassword(user.Password) {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Password must be at least 8 characters and contain a combination of letters and numbers"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgWeakPassword}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
@@ -43,56 +43,77 @@ func Signup(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		emailPattern := `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`
 		match, _ := regexp.MatchString(emailPattern, user.Email)
 		if !match {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid email format"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidEmailFormat}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		// Validasi data phone number
 		if !helper.IsValidPhoneNumber(user.PhoneNumber) {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid phone number format"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidPhoneFormat}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if user.Password != user.ConfirmPassword {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Password confirmation does not match"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgPasswordConfirmationMismatch}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
-		// Memeriksa apakah username sudah ada di database
+		// Memeriksa apakah username sudah ada di database, akun terarsip tetap memakai username, email dan nomor teleponnya hingga dihapus permanen
 		var existingUser model.User
-		result := db.Where("username = ?", user.Username).First(&existingUser)
+		result := db.Unscoped().Where("username = ?", user.Username).First(&existingUser)
 		if result.Error == nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "Username already exists"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, ErrorCode: helper.MsgUsernameExists}
 			return c.JSON(http.StatusConflict, errorResponse)
 		} else if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to check username"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUsernameCheckFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
 		// Memeriksa apakah email sudah ada di database
-		result = db.Where("email = ?", user.Email).First(&existingUser)
+		result = db.Unscoped().Where("email = ?", user.Email).First(&existingUser)
 		if result.Error == nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "Email already exists"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, ErrorCode: helper.MsgEmailExists}
 			return c.JSON(http.StatusConflict, errorResponse)
 		} else if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to check email"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgEmailCheckFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
 		// Memeriksa apakah nomor telepon sudah ada di database
-		result = db.Where("phone_number = ?", user.PhoneNumber).First(&existingUser)
+		result = db.Unscoped().Where("phone_number = ?", user.PhoneNumber).First(&existingUser)
 		if result.Error == nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, Message: "Phone number already exists"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusConflict, ErrorCode: helper.MsgPhoneExists}
 			return c.JSON(http.StatusConflict, errorResponse)
 		} else if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to check phone number"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgPhoneCheckFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
+		// Locale pilihan user hanya disimpan jika formatnya valid
+		if locale, ok := helper.NormalizeLocale(user.Locale); ok {
+			user.Locale = locale
+		} else {
+			user.Locale = ""
+		}
+
+		// Validasi kode referral jika diisi
+		if user.DeviceID == "" {
+			user.DeviceID = c.Request().Header.Get("X-Device-ID")
+		}
+		var referrer *model.User
+		if user.ReferrerCode != "" {
+			var err error
+			referrer, err = validateReferralCode(db, user.ReferrerCode, user.PhoneNumber, user.Email, user.DeviceID)
+			if err != nil {
+				errorResponse := helper.NewErrorResponse(http.StatusBadRequest, err)
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+		}
+
 		// Mengenkripsi password
 		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to hash password"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgPasswordHashFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -102,28 +123,37 @@ func Signup(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		// Menyimpan password yang dienkripsi ke database
 		user.Password = string(hashedPassword)
+		user.ReferralCode = generateUniqueReferralCode(db, user.Name)
 		db.Create(&user)
 		user.Password = ""
 
+		if referrer != nil {
+			if err := createReferral(db, referrer, &user, user.PhoneNumber); err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgReferralSaveFailed}
+				return c.JSON(http.StatusInternalServerError, errorResponse)
+			}
+		}
+
 		// Generate token otentikasi
 		tokenString, err := middleware.GenerateToken(user.Username, secretKey)
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to generate token"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgTokenGenerateFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
 		// Mengirim email selamat datang
-		if err := helper.SendWelcomeEmail(user.Email, user.Name, uniqueToken); err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to send welcome email"}
+		if err := helper.SendWelcomeEmail(helper.UserLocales(user.Locale, helper.RequestLocales(c)), user.Email, user.Name, uniqueToken); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgWelcomeEmailFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
 		// Respon sukses
 		response := map[string]interface{}{
-			"code":    http.StatusOK,
-			"message": "User created successfully, Please check your email to verify your account",
-			"token":   tokenString,
-			"id":      user.ID,
+			"code":          http.StatusOK,
+			"message":       "User created successfully, Please check your email to verify your account",
+			"token":         tokenString,
+			"id":            user.ID,
+			"referral_code": user.ReferralCode,
 		}
 
 		return c.JSON(http.StatusOK, response)
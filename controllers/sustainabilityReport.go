@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"myproject/helper"
+	"myproject/middleware"
+	"myproject/model"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func GetSustainabilityReport(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		year := time.Now().Year()
+		if yearStr := c.QueryParam("year"); yearStr != "" {
+			parsedYear, err := strconv.Atoi(yearStr)
+			if err != nil || parsedYear < 2000 || parsedYear > year {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidYear}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			year = parsedYear
+		}
+
+		format := c.QueryParam("format")
+		if format != "" && format != "json" && format != "pdf" {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidExportFormat}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		startOfYear := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		endOfYear := startOfYear.AddDate(1, 0, 0)
+
+		var tickets []model.Ticket
+		if err := db.Where("user_id = ? AND paid_status = ? AND checkin_booking >= ? AND checkin_booking < ?", user.ID, true, startOfYear, endOfYear).Find(&tickets).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserTicketsFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var wisataIDs []uint
+		for _, ticket := range tickets {
+			wisataIDs = append(wisataIDs, ticket.WisataID)
+		}
+
+		wisatas := make(map[uint]model.Wisata)
+		if len(wisataIDs) > 0 {
+			var wisataList []model.Wisata
+			db.Unscoped().Where("id IN ?", wisataIDs).Find(&wisataList)
+			for _, wisata := range wisataList {
+				wisatas[wisata.ID] = wisata
+			}
+		}
+
+		var offsets []model.CarbonOffset
+		db.Where("user_id = ? AND status = ?", user.ID, "paid").Find(&offsets)
+
+		var platformAverage float64
+		db.Model(&model.Ticket{}).
+			Where("paid_status = ? AND checkin_booking >= ? AND checkin_booking < ?", true, startOfYear, endOfYear).
+			Select("COALESCE(AVG(carbon_footprint), 0)").Row().Scan(&platformAverage)
+
+		report := helper.BuildSustainabilityReport(year, user, tickets, wisatas, offsets, platformAverage)
+
+		if format == "pdf" {
+			c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=destimate-wrapped-%d.pdf", year))
+			return c.Blob(http.StatusOK, "application/pdf", helper.RenderSustainabilityReportPDF(report))
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Sustainability report generated successfully",
+			"report":  report,
+		})
+	}
+}
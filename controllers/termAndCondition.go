@@ -16,7 +16,7 @@ func GetAllTermCondition(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var user model.User
 		result := db.Where("username = ?", username).First(&user)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch user data"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -44,6 +44,7 @@ func GetAllTermCondition(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		if termConditions == nil {
 			termConditions = []model.TermCondition{}
 		}
+		localizeTermConditions(db, requestLocales(c), termConditions)
 
 		response := map[string]interface{}{
 			"code":            http.StatusOK,
@@ -69,7 +70,7 @@ func GetTermConditionByID(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var user model.User
 		result := db.Where("username = ?", username).First(&user)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch user data"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -78,9 +79,10 @@ func GetTermConditionByID(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		var term model.TermCondition
 		if err := db.First(&term, id).Error; err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "TermCondition not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgTermConditionNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
+		localizeTermCondition(db, requestLocales(c), &term)
 
 		return c.JSON(http.StatusOK, map[string]interface{}{"code": http.StatusOK, "error": false, "term_condition": term})
 	}
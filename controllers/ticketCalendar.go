@@ -0,0 +1,216 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"myproject/helper"
+	"myproject/middleware"
+	"myproject/model"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func calendarFeedPath(token string) string {
+	return "/calendar/" + token + "/tickets.ics"
+}
+
+// ticketICSEvent menyusun acara kalender sepanjang hari pada tanggal check-in tiket.
+// UID tetap per tiket sehingga perubahan jadwal dan pembatalan memperbarui acara yang sama di aplikasi kalender.
+func ticketICSEvent(ticket model.Ticket, wisata model.Wisata) helper.ICSEvent {
+	checkin := ticket.CheckinBooking.In(helper.LoadTimeZone(wisata.TimeZone))
+	date := time.Date(checkin.Year(), checkin.Month(), checkin.Day(), 0, 0, 0, 0, time.UTC)
+
+	description := []string{
+		"Invoice: " + ticket.InvoiceNumber,
+		fmt.Sprintf("Jumlah tiket: %d", ticket.Quantity),
+	}
+	if wisata.MapsLink != "" {
+		description = append(description, "Maps: "+wisata.MapsLink)
+	}
+
+	status := helper.ICSStatusTentative
+	switch {
+	case ticket.StatusOrder == "dibatalkan":
+		status = helper.ICSStatusCancelled
+	case ticket.PaidStatus || ticket.StatusOrder == "success":
+		status = helper.ICSStatusConfirmed
+	}
+
+	event := helper.ICSEvent{
+		UID:          fmt.Sprintf("ticket-%d@destimate", ticket.ID),
+		Summary:      "Check-in " + wisata.Title,
+		Description:  strings.Join(description, "\n"),
+		Location:     wisata.Location,
+		URL:          wisata.MapsLink,
+		Start:        date,
+		End:          date.AddDate(0, 0, 1),
+		AllDay:       true,
+		Status:       status,
+		LastModified: ticket.UpdatedAt,
+	}
+	// Sequence naik setiap kali tiket diubah
+	if ticket.CreatedAt != nil && ticket.UpdatedAt.After(*ticket.CreatedAt) {
+		event.Sequence = int(ticket.UpdatedAt.Sub(*ticket.CreatedAt) / time.Second)
+	}
+	return event
+}
+
+// ticketsICS menyusun kalender dari tiket yang memiliki tanggal check-in
+func ticketsICS(db *gorm.DB, calendarName string, tickets []model.Ticket) (string, error) {
+	var wisataIDs []uint
+	for _, ticket := range tickets {
+		wisataIDs = append(wisataIDs, ticket.WisataID)
+	}
+
+	wisatas := make(map[uint]model.Wisata)
+	if len(wisataIDs) > 0 {
+		var rows []model.Wisata
+		if err := db.Unscoped().Where("id IN ?", wisataIDs).Find(&rows).Error; err != nil {
+			return "", err
+		}
+		for _, wisata := range rows {
+			wisatas[wisata.ID] = wisata
+		}
+	}
+
+	events := []helper.ICSEvent{}
+	for _, ticket := range tickets {
+		if ticket.CheckinBooking == nil {
+			continue
+		}
+		events = append(events, ticketICSEvent(ticket, wisatas[ticket.WisataID]))
+	}
+	return helper.BuildICS(calendarName, events), nil
+}
+
+// Mengunduh tanggal check-in pada satu invoice sebagai file kalender ICS
+func ExportTicketICS(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		invoiceNumber := c.Param("invoice_number")
+
+		var tickets []model.Ticket
+		if err := db.Where("user_id = ? AND invoice_number = ?", user.ID, invoiceNumber).Find(&tickets).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgTransactionHistoryFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		if len(tickets) == 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgTicketNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		ics, err := ticketsICS(db, "Destimate "+invoiceNumber, tickets)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCalendarBuildFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s.ics", invoiceNumber))
+		return c.Blob(http.StatusOK, helper.ICSContentType, []byte(ics))
+	}
+}
+
+// Menampilkan URL langganan kalender tiket milik user, token dibuat saat pertama kali diminta
+func GetCalendarFeed(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		if user.CalendarToken == nil {
+			if err := rotateCalendarToken(db, &user); err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCalendarFeedFailed}
+				return c.JSON(http.StatusInternalServerError, errorResponse)
+			}
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":      http.StatusOK,
+			"error":     false,
+			"feed_path": calendarFeedPath(*user.CalendarToken),
+		})
+	}
+}
+
+// Mengganti token langganan kalender, URL lama tidak dapat dipakai lagi
+func RotateCalendarFeed(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		if err := rotateCalendarToken(db, &user); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCalendarFeedFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":      http.StatusOK,
+			"error":     false,
+			"message":   "Calendar feed URL regenerated successfully",
+			"feed_path": calendarFeedPath(*user.CalendarToken),
+		})
+	}
+}
+
+func rotateCalendarToken(db *gorm.DB, user *model.User) error {
+	token, err := helper.GenerateSecureToken(24)
+	if err != nil {
+		return err
+	}
+	if err := db.Model(user).Update("calendar_token", token).Error; err != nil {
+		return err
+	}
+	user.CalendarToken = &token
+	return nil
+}
+
+// Feed kalender seluruh check-in mendatang milik user berdasarkan token rahasia, dipakai aplikasi kalender tanpa login.
+// Tiket yang dibatalkan tetap dikirim dengan status CANCELLED agar acaranya terhapus di aplikasi kalender.
+func GetCalendarFeedICS(db *gorm.DB) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var user model.User
+		if err := db.Where("calendar_token = ?", c.Param("token")).First(&user).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgCalendarFeedNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		// Mundur satu hari agar check-in hari ini tetap tampil di semua zona waktu
+		from := time.Now().AddDate(0, 0, -1)
+
+		var tickets []model.Ticket
+		if err := db.Where("user_id = ? AND checkin_booking >= ?", user.ID, from).Order("checkin_booking asc").Find(&tickets).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgTicketsFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		ics, err := ticketsICS(db, "Destimate", tickets)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgCalendarBuildFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		c.Response().Header().Set(echo.HeaderCacheControl, "no-cache")
+		return c.Blob(http.StatusOK, helper.ICSContentType, []byte(ics))
+	}
+}
@@ -0,0 +1,402 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"log"
+	"myproject/helper"
+	"myproject/middleware"
+	"myproject/model"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// translationKind menjelaskan tabel terjemahan untuk satu jenis konten yang bisa diedit admin
+type translationKind struct {
+	newParent      func() interface{}
+	newTranslation func() interface{}
+	foreignKey     string
+	fields         []string // Kolom yang boleh diterjemahkan
+	notFound       helper.MessageCode
+}
+
+// Jenis konten yang dapat diterjemahkan, key mengikuti path resource-nya
+var translationKinds = map[string]translationKind{
+	"tourism-attractions": {
+		newParent:      func() interface{} { return &model.Wisata{} },
+		newTranslation: func() interface{} { return &model.WisataTranslation{} },
+		foreignKey:     "wisata_id",
+		fields:         []string{"title", "description", "description_is_open"},
+		notFound:       helper.MsgWisataNotFound,
+	},
+	"promos": {
+		newParent:      func() interface{} { return &model.Promo{} },
+		newTranslation: func() interface{} { return &model.PromoTranslation{} },
+		foreignKey:     "promo_id",
+		fields:         []string{"title", "deskripsi", "peraturan"},
+		notFound:       helper.MsgPromoNotFound,
+	},
+	"categories": {
+		newParent:      func() interface{} { return &model.Category{} },
+		newTranslation: func() interface{} { return &model.CategoryTranslation{} },
+		foreignKey:     "category_id",
+		fields:         []string{"category_name"},
+		notFound:       helper.MsgCategoryNotFound,
+	},
+	"terms-and-conditions": {
+		newParent:      func() interface{} { return &model.TermCondition{} },
+		newTranslation: func() interface{} { return &model.TermConditionTranslation{} },
+		foreignKey:     "term_condition_id",
+		fields:         []string{"name", "description"},
+		notFound:       helper.MsgTermConditionNotFound,
+	},
+}
+
+// requestLocales mengembalikan urutan locale yang diinginkan client, locale yang disimpan user diutamakan
+func requestLocales(c echo.Context) []string {
+	return helper.RequestLocales(c)
+}
+
+// lookupLocales mengembalikan locale yang perlu dicari di tabel terjemahan, yaitu yang lebih diinginkan dari konten asli
+func lookupLocales(locales []string) []string {
+	for i, locale := range locales {
+		if locale == helper.DefaultLocale {
+			return locales[:i]
+		}
+	}
+	return locales
+}
+
+// pickTranslation memilih terjemahan dengan locale paling diinginkan
+func pickTranslation[T any](translations map[string]T, locales []string) (T, bool) {
+	for _, locale := range lookupLocales(locales) {
+		if translation, ok := translations[locale]; ok {
+			return translation, true
+		}
+	}
+	var empty T
+	return empty, false
+}
+
+// overrideText mengganti teks asli hanya jika terjemahannya diisi
+func overrideText(target *string, value string) {
+	if value != "" {
+		*target = value
+	}
+}
+
+// localizeWisatas mengganti judul dan deskripsi wisata beserta kategorinya sesuai locale client
+func localizeWisatas(db *gorm.DB, locales []string, wisatas []model.Wisata) {
+	if len(wisatas) == 0 || len(lookupLocales(locales)) == 0 {
+		return
+	}
+
+	var wisataIDs []uint
+	for _, wisata := range wisatas {
+		wisataIDs = append(wisataIDs, wisata.ID)
+	}
+	var rows []model.WisataTranslation
+	if err := db.Where("wisata_id IN ? AND locale IN ?", wisataIDs, lookupLocales(locales)).Find(&rows).Error; err != nil {
+		log.Println("Gagal memuat terjemahan wisata:", err)
+		return
+	}
+	translations := make(map[uint]map[string]model.WisataTranslation)
+	for _, row := range rows {
+		if translations[row.WisataID] == nil {
+			translations[row.WisataID] = make(map[string]model.WisataTranslation)
+		}
+		translations[row.WisataID][row.Locale] = row
+	}
+
+	categories := make([]model.Category, len(wisatas))
+	for i := range wisatas {
+		if translation, ok := pickTranslation(translations[wisatas[i].ID], locales); ok {
+			overrideText(&wisatas[i].Title, translation.Title)
+			overrideText(&wisatas[i].Description, translation.Description)
+			overrideText(&wisatas[i].DescriptionIsOpen, translation.DescriptionIsOpen)
+		}
+		categories[i] = wisatas[i].Category
+	}
+
+	localizeCategories(db, locales, categories)
+	for i := range wisatas {
+		wisatas[i].Category = categories[i]
+	}
+}
+
+func localizeWisata(db *gorm.DB, locales []string, wisata *model.Wisata) {
+	wisatas := []model.Wisata{*wisata}
+	localizeWisatas(db, locales, wisatas)
+	*wisata = wisatas[0]
+}
+
+// localizePromos mengganti judul, deskripsi dan peraturan promo sesuai locale client
+func localizePromos(db *gorm.DB, locales []string, promos []model.Promo) {
+	if len(promos) == 0 || len(lookupLocales(locales)) == 0 {
+		return
+	}
+
+	var promoIDs []uint
+	for _, promo := range promos {
+		promoIDs = append(promoIDs, promo.ID)
+	}
+	var rows []model.PromoTranslation
+	if err := db.Where("promo_id IN ? AND locale IN ?", promoIDs, lookupLocales(locales)).Find(&rows).Error; err != nil {
+		log.Println("Gagal memuat terjemahan promo:", err)
+		return
+	}
+	translations := make(map[uint]map[string]model.PromoTranslation)
+	for _, row := range rows {
+		if translations[row.PromoID] == nil {
+			translations[row.PromoID] = make(map[string]model.PromoTranslation)
+		}
+		translations[row.PromoID][row.Locale] = row
+	}
+
+	for i := range promos {
+		if translation, ok := pickTranslation(translations[promos[i].ID], locales); ok {
+			overrideText(&promos[i].Title, translation.Title)
+			overrideText(&promos[i].Deskripsi, translation.Deskripsi)
+			overrideText(&promos[i].Peraturan, translation.Peraturan)
+		}
+	}
+}
+
+func localizePromo(db *gorm.DB, locales []string, promo *model.Promo) {
+	promos := []model.Promo{*promo}
+	localizePromos(db, locales, promos)
+	*promo = promos[0]
+}
+
+// localizeCategories mengganti nama kategori sesuai locale client, kategori kosong (ID 0) dilewati
+func localizeCategories(db *gorm.DB, locales []string, categories []model.Category) {
+	if len(categories) == 0 || len(lookupLocales(locales)) == 0 {
+		return
+	}
+
+	var categoryIDs []uint
+	for _, category := range categories {
+		if category.ID != 0 {
+			categoryIDs = append(categoryIDs, category.ID)
+		}
+	}
+	if len(categoryIDs) == 0 {
+		return
+	}
+	var rows []model.CategoryTranslation
+	if err := db.Where("category_id IN ? AND locale IN ?", categoryIDs, lookupLocales(locales)).Find(&rows).Error; err != nil {
+		log.Println("Gagal memuat terjemahan kategori:", err)
+		return
+	}
+	translations := make(map[uint]map[string]model.CategoryTranslation)
+	for _, row := range rows {
+		if translations[row.CategoryID] == nil {
+			translations[row.CategoryID] = make(map[string]model.CategoryTranslation)
+		}
+		translations[row.CategoryID][row.Locale] = row
+	}
+
+	for i := range categories {
+		if translation, ok := pickTranslation(translations[categories[i].ID], locales); ok {
+			overrideText(&categories[i].CategoryName, translation.CategoryName)
+		}
+	}
+}
+
+// localizeTermConditions mengganti nama dan isi syarat dan ketentuan sesuai locale client
+func localizeTermConditions(db *gorm.DB, locales []string, terms []model.TermCondition) {
+	if len(terms) == 0 || len(lookupLocales(locales)) == 0 {
+		return
+	}
+
+	var termIDs []uint
+	for _, term := range terms {
+		termIDs = append(termIDs, term.ID)
+	}
+	var rows []model.TermConditionTranslation
+	if err := db.Where("term_condition_id IN ? AND locale IN ?", termIDs, lookupLocales(locales)).Find(&rows).Error; err != nil {
+		log.Println("Gagal memuat terjemahan syarat dan ketentuan:", err)
+		return
+	}
+	translations := make(map[uint]map[string]model.TermConditionTranslation)
+	for _, row := range rows {
+		if translations[row.TermConditionID] == nil {
+			translations[row.TermConditionID] = make(map[string]model.TermConditionTranslation)
+		}
+		translations[row.TermConditionID][row.Locale] = row
+	}
+
+	for i := range terms {
+		if translation, ok := pickTranslation(translations[terms[i].ID], locales); ok {
+			overrideText(&terms[i].Name, translation.Name)
+			overrideText(&terms[i].Description, translation.Description)
+		}
+	}
+}
+
+func localizeTermCondition(db *gorm.DB, locales []string, term *model.TermCondition) {
+	terms := []model.TermCondition{*term}
+	localizeTermConditions(db, locales, terms)
+	*term = terms[0]
+}
+
+// parseTranslationTarget memvalidasi jenis konten dan memastikan konten dengan ID tersebut ada
+func parseTranslationTarget(db *gorm.DB, c echo.Context) (translationKind, uint, *helper.ErrorResponse) {
+	kind, ok := translationKinds[c.Param("type")]
+	if !ok {
+		return kind, 0, &helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidTranslationType}
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return kind, 0, &helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidID}
+	}
+	if err := db.First(kind.newParent(), id).Error; err != nil {
+		return kind, 0, &helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: kind.notFound}
+	}
+	return kind, uint(id), nil
+}
+
+// Menampilkan seluruh terjemahan sebuah konten oleh admin
+func GetTranslationsByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		kind, id, errorResponse := parseTranslationTarget(db, c)
+		if errorResponse != nil {
+			return c.JSON(errorResponse.Code, errorResponse)
+		}
+
+		translations := []map[string]interface{}{}
+		if err := db.Model(kind.newTranslation()).Where(kind.foreignKey+" = ?", id).Order("locale asc").Find(&translations).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgTranslationsFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":           http.StatusOK,
+			"error":          false,
+			"default_locale": helper.DefaultLocale,
+			"fields":         kind.fields,
+			"translations":   translations,
+		})
+	}
+}
+
+// Menambahkan atau mengubah terjemahan sebuah konten untuk satu locale oleh admin
+func UpsertTranslationByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		kind, id, errorResponse := parseTranslationTarget(db, c)
+		if errorResponse != nil {
+			return c.JSON(errorResponse.Code, errorResponse)
+		}
+
+		locale, ok := helper.NormalizeLocale(c.Param("locale"))
+		if !ok {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidLocale}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+		if locale == helper.DefaultLocale {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDefaultLocaleTranslation}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		var requestBody map[string]interface{}
+		if err := c.Bind(&requestBody); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidRequestBody}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		values := map[string]interface{}{}
+		for _, field := range kind.fields {
+			value, exists := requestBody[field]
+			if !exists {
+				continue
+			}
+			text, ok := value.(string)
+			if !ok {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgFieldNotString, Args: []interface{}{field}}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			values[field] = strings.TrimSpace(text)
+		}
+		if len(values) == 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgTranslationFieldsRequired, Args: []interface{}{strings.Join(kind.fields, ", ")}}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+		values["updated_at"] = time.Now()
+
+		translation := map[string]interface{}{}
+		err = db.Transaction(func(tx *gorm.DB) error {
+			var count int64
+			if err := tx.Model(kind.newTranslation()).Where(kind.foreignKey+" = ? AND locale = ?", id, locale).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				if err := tx.Model(kind.newTranslation()).Where(kind.foreignKey+" = ? AND locale = ?", id, locale).Updates(values).Error; err != nil {
+					return err
+				}
+			} else {
+				values[kind.foreignKey] = id
+				values["locale"] = locale
+				if err := tx.Model(kind.newTranslation()).Create(values).Error; err != nil {
+					return err
+				}
+			}
+			return tx.Model(kind.newTranslation()).Where(kind.foreignKey+" = ? AND locale = ?", id, locale).Take(&translation).Error
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgTranslationSaveFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":        http.StatusOK,
+			"error":       false,
+			"message":     "Translation saved successfully",
+			"translation": translation,
+		})
+	}
+}
+
+// Menghapus terjemahan sebuah konten untuk satu locale oleh admin
+func DeleteTranslationByAdmin(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, err := middleware.AuthenticateAndAuthorize(c, db, secretKey)
+		if err != nil {
+			return err
+		}
+
+		kind, id, errorResponse := parseTranslationTarget(db, c)
+		if errorResponse != nil {
+			return c.JSON(errorResponse.Code, errorResponse)
+		}
+
+		locale, _ := helper.NormalizeLocale(c.Param("locale"))
+		result := db.Where(kind.foreignKey+" = ? AND locale = ?", id, locale).Delete(kind.newTranslation())
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgTranslationDeleteFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		if result.RowsAffected == 0 {
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgTranslationNotFound}
+			return c.JSON(http.StatusNotFound, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "Translation deleted successfully",
+		})
+	}
+}
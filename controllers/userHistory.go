@@ -18,7 +18,7 @@ func GetTicketsByUser(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var user model.User
 		result := db.Where("username = ?", username).First(&user)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch user data"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -26,7 +26,7 @@ func GetTicketsByUser(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var tickets []model.Ticket
 		result = db.Where("user_id = ?", user.ID).Find(&tickets)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch user's tickets"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserTicketsFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -48,14 +48,14 @@ func GetTicketsByUser(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 				// Simpan perubahan ke dalam database
 				if err := db.Save(&ticket).Error; err != nil {
-					errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update ticket status"}
+					errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgTicketStatusUpdateFailed}
 					return c.JSON(http.StatusInternalServerError, errorResponse)
 				}
 			}
 
 			// Mengambil detail event berdasarkan EventID yang ada pada tiket
 			var wisata model.Wisata
-			eventResult := db.First(&wisata, ticket.WisataID)
+			eventResult := db.Unscoped().First(&wisata, ticket.WisataID)
 			if eventResult.Error != nil {
 				// Handle jika event tidak ditemukan
 				continue
@@ -84,6 +84,9 @@ func GetTicketsByUser(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 				"quantity":                    ticket.Quantity,
 				"paid":                        ticket.PaidStatus,
 				"carboon_footprint":           ticket.CarbonFootprint,
+				"transport_mode":              ticket.TransportMode,
+				"emission_factor":             ticket.EmissionFactor,
+				"travel_distance":             ticket.TravelDistance,
 				"points_earned":               ticket.PointsEarned,
 				"checkin_booking":             ticket.CheckinBooking,
 				"photo_wisata1":               wisata.PhotoWisata1,
@@ -120,7 +123,7 @@ func GetTransactionHistoryByInvoiceNumber(db *gorm.DB, secretKey []byte) echo.Ha
 		var user model.User
 		result := db.Where("username = ?", username).First(&user)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch user data"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -131,7 +134,7 @@ func GetTransactionHistoryByInvoiceNumber(db *gorm.DB, secretKey []byte) echo.Ha
 		var tickets []model.Ticket
 		result = db.Where("user_id = ? AND invoice_number = ?", user.ID, invoiceNumber).Find(&tickets)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch transaction history"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgTransactionHistoryFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -140,7 +143,7 @@ func GetTransactionHistoryByInvoiceNumber(db *gorm.DB, secretKey []byte) echo.Ha
 		for _, ticket := range tickets {
 			// Mendapatkan detail event berdasarkan EventID yang ada pada tiket
 			var wisata model.Wisata
-			eventResult := db.First(&wisata, ticket.WisataID)
+			eventResult := db.Unscoped().First(&wisata, ticket.WisataID)
 			if eventResult.Error != nil {
 				// Handle jika event tidak ditemukan
 				continue
@@ -169,6 +172,9 @@ func GetTransactionHistoryByInvoiceNumber(db *gorm.DB, secretKey []byte) echo.Ha
 				"quantity":                    ticket.Quantity,
 				"paid":                        ticket.PaidStatus,
 				"carboon_footprint":           ticket.CarbonFootprint,
+				"transport_mode":              ticket.TransportMode,
+				"emission_factor":             ticket.EmissionFactor,
+				"travel_distance":             ticket.TravelDistance,
 				"points_earned":               ticket.PointsEarned,
 				"checkin_booking":             ticket.CheckinBooking,
 				"photo_wisata1":               wisata.PhotoWisata1,
@@ -204,7 +210,7 @@ func GetPointsHistory(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var user model.User
 		result := db.Where("username = ?", username).First(&user)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch user data"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -212,7 +218,7 @@ func GetPointsHistory(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var tickets []model.Ticket
 		result = db.Where("user_id = ? AND (points_earned > 0 OR used_points > 0) AND paid_status = ?", user.ID, true).Find(&tickets)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch user's points history"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgPointsHistoryFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -227,13 +233,13 @@ func GetPointsHistory(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 				}
 
 				if err := db.Save(&ticket).Error; err != nil {
-					errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update ticket status"}
+					errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgTicketStatusUpdateFailed}
 					return c.JSON(http.StatusInternalServerError, errorResponse)
 				}
 			}
 
 			var wisata model.Wisata
-			eventResult := db.First(&wisata, ticket.WisataID)
+			eventResult := db.Unscoped().First(&wisata, ticket.WisataID)
 			if eventResult.Error != nil {
 				continue
 			}
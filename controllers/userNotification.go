@@ -13,6 +13,50 @@ import (
 	"time"
 )
 
+// userLocales mengembalikan urutan locale pesan untuk user penerima notifikasi, mengikuti locale yang disimpan user
+func userLocales(db *gorm.DB, userID uint) []string {
+	var savedLocales []string
+	db.Model(&model.User{}).Where("id = ?", userID).Pluck("locale", &savedLocales)
+	if len(savedLocales) == 0 {
+		return helper.PreferredLocales("")
+	}
+	return helper.PreferredLocales(savedLocales[0])
+}
+
+// Jenis notifikasi yang menentukan label di daftar notifikasi
+const (
+	notificationTypePayment  = "payment"
+	notificationTypePromo    = "promo"
+	notificationTypeFavorite = "favorite"
+	notificationTypeBadge    = "badge"
+	notificationTypeReferral = "referral"
+)
+
+// notificationLabel menentukan label notifikasi dari jenisnya, notifikasi lama tanpa jenis ditebak dari judul dan tempat wisata
+func notificationLabel(notification model.Notification) string {
+	switch notification.Type {
+	case notificationTypePayment:
+		return "Pembayaran"
+	case notificationTypePromo:
+		return "Promo"
+	case notificationTypeFavorite:
+		return "Favorit"
+	case notificationTypeBadge:
+		return "Lencana"
+	case notificationTypeReferral:
+		return "Referral"
+	}
+
+	switch {
+	case strings.HasPrefix(notification.Title, "promo "):
+		return "Promo"
+	case notification.WisataID != 0:
+		return "Favorit"
+	default:
+		return "Pembayaran"
+	}
+}
+
 type NotificationResponse struct {
 	ID            uint       `json:"id"`
 	UserID        uint       `json:"user_id"`
@@ -21,6 +65,7 @@ type NotificationResponse struct {
 	Status        string     `json:"status"`
 	Title         string     `json:"title"`
 	InvoiceNumber string     `json:"invoice_number"`
+	WisataID      uint       `json:"wisata_id,omitempty"`
 	CreatedAt     *time.Time `json:"created_at"`
 	IsRead        bool       `json:"is_read"`
 }
@@ -31,6 +76,7 @@ type PromoNotificationResponse struct {
 	Label     string     `json:"label"`
 	Message   string     `json:"message"`
 	PromoID   uint       `json:"promo_id"`
+	WisataID  uint       `json:"wisata_id,omitempty"`
 	Status    string     `json:"status"`
 	CreatedAt *time.Time `json:"created_at"`
 	IsRead    bool       `json:"is_read"`
@@ -43,7 +89,7 @@ func GetUserNotifications(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var user model.User
 		result := db.Where("username = ?", username).First(&user)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch user data"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -62,10 +108,11 @@ func GetUserNotifications(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 				promoTitle := strings.TrimPrefix(notification.Title, "promo ")
 				promoNotificationResponse := PromoNotificationResponse{
 					ID:        notification.ID,
-					Label:     "Promo",
+					Label:     notificationLabel(notification),
 					Title:     "Promo " + promoTitle,
 					Message:   notification.Message,
 					PromoID:   notification.PromoID,
+					WisataID:  notification.WisataID,
 					Status:    notification.Status,
 					CreatedAt: notification.CreatedAt,
 					IsRead:    isRead,
@@ -75,11 +122,12 @@ func GetUserNotifications(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 				notificationResponse := NotificationResponse{
 					ID:            notification.ID,
 					UserID:        notification.UserID,
-					Label:         "Pembayaran",
+					Label:         notificationLabel(notification),
 					Message:       notification.Message,
 					Status:        notification.Status,
 					Title:         notification.Title,
 					InvoiceNumber: notification.InvoiceNumber,
+					WisataID:      notification.WisataID,
 					CreatedAt:     notification.CreatedAt,
 					IsRead:        isRead,
 				}
@@ -103,7 +151,7 @@ func MarkNotificationAsRead(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var notification model.Notification
 		result := db.First(&notification, notificationID)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Notification not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgNotificationNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
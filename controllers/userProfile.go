@@ -20,20 +20,20 @@ func GetUserDataByID(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		userIDStr := c.Param("user_id")
 		userID, err := strconv.Atoi(userIDStr)
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid user ID"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidUserID}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		var authUser model.User
 		if err := db.Where("username = ?", username).First(&authUser).Error; err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch user data"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
 		if authUser.IsAdmin || uint(userID) == authUser.ID {
 			var user model.User
 			if err := db.First(&user, uint(userID)).Error; err != nil {
-				errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "User not found"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgUserNotFound}
 				return c.JSON(http.StatusNotFound, errorResponse)
 			}
 
@@ -48,6 +48,7 @@ func GetUserDataByID(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 				PhotoProfil:      user.PhotoProfil,
 				CategoryKesukaan: user.CategoryKesukaan,
 				CategoryID:       user.CategoryID,
+				Locale:           user.Locale,
 			}
 
 			return c.JSON(http.StatusOK, map[string]interface{}{
@@ -58,7 +59,7 @@ func GetUserDataByID(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			})
 		}
 
-		errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+		errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, ErrorCode: helper.MsgAccessDenied}
 		return c.JSON(http.StatusForbidden, errorResponse)
 	}
 }
@@ -69,7 +70,7 @@ func GetProfile(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var user model.User
 		result := db.Where("username = ?", username).First(&user)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch user data"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -98,49 +99,49 @@ func EditUser(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		var user model.User
 		if err := db.First(&user, userID).Error; err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "User not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgUserNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
 		if user.Username != username {
-			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Unauthorized to edit this user"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, ErrorCode: helper.MsgUserEditForbidden}
 			return c.JSON(http.StatusUnauthorized, errorResponse)
 		}
 
 		err := c.Request().ParseMultipartForm(10 << 20)
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Failed to parse form data"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgFormParseFailed}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		newName := c.FormValue("name")
 		if newName != "" && len(newName) < 3 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Name should be at least 3 characters"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgNameMin3}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		newUsername := c.FormValue("username")
 		if newUsername != "" && len(newUsername) < 5 {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Username should be at least 5 characters"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgUsernameTooShort}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 		if newUsername != "" && newUsername != user.Username {
 			var existingUser model.User
-			if err := db.Where("username = ?", newUsername).First(&existingUser).Error; err == nil {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Username already exists"}
+			if err := db.Unscoped().Where("username = ?", newUsername).First(&existingUser).Error; err == nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgUsernameExists}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 		}
 
 		newEmail := c.FormValue("email")
 		if newEmail != "" && !helper.IsValidEmail(newEmail) {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid email format"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidEmailFormat}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 		if newEmail != "" && newEmail != user.Email {
 			var existingUser model.User
-			if err := db.Where("email = ?", newEmail).First(&existingUser).Error; err == nil {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Email already exists"}
+			if err := db.Unscoped().Where("email = ?", newEmail).First(&existingUser).Error; err == nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgEmailExists}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 		}
@@ -150,20 +151,20 @@ func EditUser(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 			// Validasi: phone_number harus memiliki minimal 10 digit
 			if len(newPhoneNumber) < 10 {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Phone number should be at least 10 digits"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgPhoneTooShort}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 			// Validasi: phone_number hanya boleh mengandung digit
 			if !helper.IsValidPhoneNumber(newPhoneNumber) {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid phone number format"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidPhoneFormat}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 
 			// Periksa apakah phone_number sudah digunakan oleh pengguna lain
 			if newPhoneNumber != user.PhoneNumber {
 				var existingUser model.User
-				if err := db.Where("phone_number = ?", newPhoneNumber).First(&existingUser).Error; err == nil {
-					errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Phone number already exists"}
+				if err := db.Unscoped().Where("phone_number = ?", newPhoneNumber).First(&existingUser).Error; err == nil {
+					errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgPhoneExists}
 					return c.JSON(http.StatusBadRequest, errorResponse)
 				}
 			}
@@ -172,7 +173,7 @@ func EditUser(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		profileImageFile, err := c.FormFile("profile_image")
 		if err == nil {
 			if !helper.IsImageFile(profileImageFile) {
-				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid image format"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidImageFormat}
 				return c.JSON(http.StatusBadRequest, errorResponse)
 			}
 
@@ -203,7 +204,7 @@ func EditUser(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			var category model.Category
 			result := db.Where("category_name = ?", categoryKesukaan).First(&category)
 			if result.Error != nil {
-				errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Category not found"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgCategoryNotFound}
 				return c.JSON(http.StatusNotFound, errorResponse)
 			}
 			user.CategoryKesukaan = categoryKesukaan
@@ -224,14 +225,23 @@ func EditUser(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			user.PhoneNumber = phoneNumber
 		}
 
-		if err := db.Save(&user).Error; err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update user"}
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Save(&user).Error; err != nil {
+				return err
+			}
+			if categoryKesukaan == "" {
+				return nil
+			}
+			return setProfileCategoryPreference(tx, user.ID, user.CategoryID)
+		})
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserUpdateFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
 		newTokenString, err := middleware.GenerateToken(user.Username, secretKey)
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to generate token"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgTokenGenerateFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -265,20 +275,20 @@ func DeleteUserProfilePhoto(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		// Mengambil data pengguna dari database berdasarkan ID
 		var user model.User
 		if err := db.First(&user, userID).Error; err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "User not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgUserNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
 		// Memastikan bahwa pengguna yang meminta penghapusan adalah pemilik akun
 		if user.Username != username {
-			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Unauthorized to delete photo for this user"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, ErrorCode: helper.MsgPhotoDeleteForbidden}
 			return c.JSON(http.StatusUnauthorized, errorResponse)
 		}
 
 		// Menghapus foto profil pengguna
 		user.PhotoProfil = ""
 		if err := db.Save(&user).Error; err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to delete user profile photo"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgProfilePhotoDeleteFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -298,12 +308,12 @@ func ChangePassword(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var user model.User
 		result := db.Where("username = ?", username).First(&user)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "User not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgUserNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
 		if userID != fmt.Sprint(user.ID) {
-			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, ErrorCode: helper.MsgAccessDenied}
 			return c.JSON(http.StatusForbidden, errorResponse)
 		}
 
@@ -315,34 +325,34 @@ func ChangePassword(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		// Validasi password baru
 		if len(req.NewPassword) < 8 || !helper.IsValidPassword(req.NewPassword) {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "New password must be at least 8 characters and contain a combination of letters and numbers"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgWeakNewPassword}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		// Validasi konfirmasi password
 		if req.NewPassword != req.ConfirmPassword {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Confirmation password does not match the new password"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgNewPasswordConfirmationMismatch}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		// Validasi password baru tidak sama dengan password sekarang
 		err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.NewPassword))
 		if err == nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "New password must be different from the current password"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgNewPasswordSame}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		// Validasi password sekarang
 		err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.CurrentPassword))
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Incorrect current password"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, ErrorCode: helper.MsgCurrentPasswordIncorrect}
 			return c.JSON(http.StatusUnauthorized, errorResponse)
 		}
 
 		// Mengenkripsi password baru
 		hashedNewPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to hash new password"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgPasswordHashFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -360,24 +370,35 @@ func GetTotalCarbonFootprintByUser(db *gorm.DB, secretKey []byte) echo.HandlerFu
 		var currentUser model.User
 		result := db.Where("username = ?", username).First(&currentUser)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "User not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgUserNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
 		userStrID := c.Param("user_id")
 		userID, err := strconv.ParseUint(userStrID, 10, 64)
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid user ID"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidUserID}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		if currentUser.IsAdmin || currentUser.ID == uint(userID) {
 			var totalCarbonFootprint float64
 			if err := db.Model(&model.Ticket{}).Where("user_id = ? AND paid_status = ?", userID, true).Select("COALESCE(SUM(carbon_footprint), 0)").Row().Scan(&totalCarbonFootprint); err != nil {
-				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to calculate total carbon footprint"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgTotalCarbonFootprintFailed}
 				return c.JSON(http.StatusInternalServerError, errorResponse)
 			}
 
+			var totalOffset float64
+			if err := db.Model(&model.CarbonOffset{}).Where("user_id = ? AND status = ?", userID, "paid").Select("COALESCE(SUM(offset_grams), 0)").Row().Scan(&totalOffset); err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgTotalCarbonOffsetFailed}
+				return c.JSON(http.StatusInternalServerError, errorResponse)
+			}
+
+			netCarbonFootprint := totalCarbonFootprint - totalOffset
+			if netCarbonFootprint < 0 {
+				netCarbonFootprint = 0
+			}
+
 			// Rumus menulis aplikasi trip it 0,36tco2 setara dengan listrik 1 rumah selama 1 bulan
 			// setelah di kalkulasi didapatkan bahwa satu rumah membutuhkan 1000 gram co2 untuk daya listrik/jam
 
@@ -391,12 +412,15 @@ func GetTotalCarbonFootprintByUser(db *gorm.DB, secretKey []byte) echo.HandlerFu
 				"error":                              false,
 				"rounded_total_carbon_footprint":     roundedTotalCarbonFootprint,
 				"equivalent_powering_house_in_hours": carbonEquivalentHours,
+				"gross_carbon_footprint":             totalCarbonFootprint,
+				"offset_carbon_footprint":            totalOffset,
+				"net_carbon_footprint":               netCarbonFootprint,
 			}
 
 			return c.JSON(http.StatusOK, responseData)
 		}
 
-		errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, Message: "Access denied"}
+		errorResponse := helper.ErrorResponse{Code: http.StatusForbidden, ErrorCode: helper.MsgAccessDenied}
 		return c.JSON(http.StatusForbidden, errorResponse)
 	}
 }
@@ -410,13 +434,13 @@ func EditUserLocation(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		var user model.User
 		if err := db.First(&user, userID).Error; err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "User not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgUserNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
 		// Memeriksa otorisasi
 		if user.Username != username {
-			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, Message: "Unauthorized to edit this user"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusUnauthorized, ErrorCode: helper.MsgUserEditForbidden}
 			return c.JSON(http.StatusUnauthorized, errorResponse)
 		}
 
@@ -435,7 +459,7 @@ func EditUserLocation(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		}
 
 		if err := db.Save(&user).Error; err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to update user location"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserLocationUpdateFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -461,7 +485,7 @@ func GetUserPoints(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var authUser model.User
 		result := db.Where("username = ?", username).First(&authUser)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch user data"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -474,3 +498,47 @@ func GetUserPoints(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		})
 	}
 }
+
+// UpdateUserLocale menyimpan bahasa pilihan user untuk pesan, notifikasi dan email, locale kosong kembali mengikuti Accept-Language
+func UpdateUserLocale(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		var requestBody struct {
+			Locale string `json:"locale"`
+		}
+		if err := c.Bind(&requestBody); err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidRequestBody}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		locale := ""
+		if requestBody.Locale != "" {
+			var ok bool
+			locale, ok = helper.NormalizeLocale(requestBody.Locale)
+			if !ok {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidLocale}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+		}
+
+		if err := db.Model(&user).Update("locale", locale).Error; err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgLocaleUpdateFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":    http.StatusOK,
+			"error":   false,
+			"message": "User locale updated successfully",
+			"locale":  locale,
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"log"
+	"myproject/helper"
+	"myproject/middleware"
+	"myproject/model"
+	"myproject/wallet"
+	"net/http"
+	"time"
+)
+
+// findWalletTicket mengambil tiket lunas milik user berdasarkan invoice dan menyusun data e-ticket untuk wallet
+func findWalletTicket(db *gorm.DB, username, invoiceNumber string) (wallet.Ticket, *helper.ErrorResponse) {
+	var user model.User
+	result := db.Where("username = ?", username).First(&user)
+	if result.Error != nil {
+		return wallet.Ticket{}, &helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+	}
+
+	var ticket model.Ticket
+	if err := db.Where("user_id = ? AND invoice_number = ?", user.ID, invoiceNumber).First(&ticket).Error; err != nil {
+		return wallet.Ticket{}, &helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgTicketNotFound}
+	}
+	if ticket.StatusOrder == "dibatalkan" || !(ticket.PaidStatus || ticket.StatusOrder == "success") {
+		return wallet.Ticket{}, &helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgWalletTicketUnpaid}
+	}
+
+	var wisata model.Wisata
+	if err := db.Unscoped().First(&wisata, ticket.WisataID).Error; err != nil {
+		return wallet.Ticket{}, &helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgWisataNotFound}
+	}
+
+	walletTicket := wallet.Ticket{
+		ID:           ticket.ID,
+		SerialNumber: ticket.InvoiceNumber,
+		WisataID:     wisata.ID,
+		Title:        wisata.Title,
+		Location:     wisata.Location,
+		HolderName:   user.Name,
+		Quantity:     ticket.Quantity,
+		Lat:          wisata.Lat,
+		Long:         wisata.Long,
+	}
+	if ticket.CheckinBooking != nil {
+		checkin := ticket.CheckinBooking.In(helper.LoadTimeZone(wisata.TimeZone))
+		walletTicket.Date = time.Date(checkin.Year(), checkin.Month(), checkin.Day(), 0, 0, 0, 0, checkin.Location())
+	}
+	return walletTicket, nil
+}
+
+// Mengunduh e-ticket sebagai Apple Wallet pass (.pkpass) yang ditandatangani
+func GetAppleWalletPass(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		walletTicket, errorResponse := findWalletTicket(db, username, c.Param("invoice_number"))
+		if errorResponse != nil {
+			return c.JSON(errorResponse.Code, errorResponse)
+		}
+
+		config, err := wallet.AppleConfigFromEnv()
+		if err != nil {
+			if !errors.Is(err, wallet.ErrNotConfigured) {
+				log.Println("Konfigurasi Apple Wallet tidak valid:", err)
+			}
+			errorResponse := helper.ErrorResponse{Code: http.StatusServiceUnavailable, ErrorCode: helper.MsgAppleWalletUnavailable}
+			return c.JSON(http.StatusServiceUnavailable, errorResponse)
+		}
+
+		pass, err := wallet.BuildPKPass(config, walletTicket)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgWalletPassFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=ticket-%d.pkpass", walletTicket.ID))
+		return c.Blob(http.StatusOK, wallet.PKPassContentType, pass)
+	}
+}
+
+// Membuat JWT dan link Save to Google Wallet untuk e-ticket
+func GetGoogleWalletPass(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		walletTicket, errorResponse := findWalletTicket(db, username, c.Param("invoice_number"))
+		if errorResponse != nil {
+			return c.JSON(errorResponse.Code, errorResponse)
+		}
+
+		config, err := wallet.GoogleConfigFromEnv()
+		if err != nil {
+			if !errors.Is(err, wallet.ErrNotConfigured) {
+				log.Println("Konfigurasi Google Wallet tidak valid:", err)
+			}
+			errorResponse := helper.ErrorResponse{Code: http.StatusServiceUnavailable, ErrorCode: helper.MsgGoogleWalletUnavailable}
+			return c.JSON(http.StatusServiceUnavailable, errorResponse)
+		}
+
+		token, saveURL, err := wallet.GoogleSaveJWT(config, walletTicket)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgWalletPassFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":     http.StatusOK,
+			"error":    false,
+			"jwt":      token,
+			"save_url": saveURL,
+		})
+	}
+}
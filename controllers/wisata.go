@@ -3,13 +3,36 @@ package controllers
 import (
 	"github.com/labstack/echo/v4"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"myproject/geo"
 	"myproject/helper"
 	"myproject/middleware"
 	"myproject/model"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 )
 
+// distanceSQL menghitung jarak haversine (km) dari titik (lat, lat, long) ke koordinat tempat wisata
+const distanceSQL = "(6371.0088 * 2 * ASIN(SQRT(POWER(SIN(RADIANS(wisata.lat - ?) / 2), 2) + COS(RADIANS(?)) * COS(RADIANS(wisata.lat)) * POWER(SIN(RADIANS(wisata.`long` - ?) / 2), 2))))"
+
+// whereInBoundingBox menyaring tempat wisata di dalam bounding box, memakai prefix geohash agar index terpakai
+func whereInBoundingBox(query *gorm.DB, box geo.BoundingBox) *gorm.DB {
+	prefixes := geo.Cover(box)
+	if len(prefixes) > 0 {
+		conditions := make([]string, len(prefixes))
+		args := make([]interface{}, len(prefixes))
+		for i, prefix := range prefixes {
+			conditions[i] = "wisata.geohash LIKE ?"
+			args[i] = prefix + "%"
+		}
+		query = query.Where("("+strings.Join(conditions, " OR ")+")", args...)
+	}
+
+	return query.Where("wisata.lat BETWEEN ? AND ? AND wisata.`long` BETWEEN ? AND ?", box.MinLat, box.MaxLat, box.MinLong, box.MaxLong)
+}
+
 func GetWisatas(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		username := middleware.ExtractUsernameFromToken(c, secretKey)
@@ -17,7 +40,7 @@ func GetWisatas(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var user model.User
 		result := db.Where("username = ?", username).First(&user)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch user data"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -29,14 +52,109 @@ func GetWisatas(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			Preload("Category").
 			Joins("JOIN categories ON wisata.category_id = categories.id")
 
+		// Admin dapat melihat tempat wisata terarsip lewat parameter archived
+		if user.IsAdmin {
+			query = archivedFilter(c, query, "wisata")
+		}
+
 		// Add searching condition
+		var searchIDs []uint
 		if searchQuery != "" {
-			query = query.Where("title LIKE ? OR kota LIKE ? OR category_name LIKE ?", "%"+searchQuery+"%", "%"+searchQuery+"%", "%"+searchQuery+"%")
+			searchIDs = searchWisataIDs(searchQuery)
+			query = query.Where("wisata.id IN ?", searchIDs)
+		}
+
+		// Add location condition
+		sortBy := c.QueryParam("sort")
+		var hasOrigin bool
+		var originLat, originLong float64
+		if near := c.QueryParam("near"); near != "" {
+			var err error
+			originLat, originLong, err = geo.ParsePoint(near)
+			if err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidNear}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			hasOrigin = true
+
+			if radius := c.QueryParam("radius_km"); radius != "" {
+				radiusKm, err := strconv.ParseFloat(radius, 64)
+				if err != nil || radiusKm <= 0 {
+					errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidRadius}
+					return c.JSON(http.StatusBadRequest, errorResponse)
+				}
+				query = whereInBoundingBox(query, geo.Around(originLat, originLong, radiusKm))
+				query = query.Where(distanceSQL+" <= ?", originLat, originLat, originLong, radiusKm)
+			}
+		} else if sortBy == "distance" && (user.Lat != 0 || user.Long != 0) {
+			// Tanpa parameter near, jarak dihitung dari lokasi yang disimpan user
+			hasOrigin = true
+			originLat, originLong = user.Lat, user.Long
+		}
+
+		if bbox := c.QueryParam("bbox"); bbox != "" {
+			box, err := geo.ParseBoundingBox(bbox)
+			if err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidBbox}
+				return c.JSON(http.StatusBadRequest, errorResponse)
+			}
+			query = whereInBoundingBox(query, box)
 		}
 
+		if sortBy == "distance" && !hasOrigin {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgDistanceSortNeedsLocation}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+
+		// Add facet filter condition
+		filter, err := parseWisataFilter(c)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidFilter}
+			return c.JSON(http.StatusBadRequest, errorResponse)
+		}
+		// Filter kategori utama juga mencakup seluruh subkategorinya
+		filter.CategoryIDs = categoryDescendantIDs(db, filter.CategoryIDs)
+
+		if filter.OpenNow {
+			filter.OpenNowIDs = openNowWisataIDs(db, time.Now())
+		}
+
+		query = query.Session(&gorm.Session{})
+		facets, err := wisataFacets(query, filter)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgFacetsFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+		query = filter.apply(query, "")
+
 		var totalWisatas int64
 		query.Count(&totalWisatas)
 
+		if hasOrigin {
+			query = query.Select("wisata.*, "+distanceSQL+" AS distance", originLat, originLat, originLong)
+		}
+
+		// Add sorting condition
+		switch sortBy {
+		case "rating":
+			query = query.Order("wisata.average_rating desc, wisata.rating_count desc")
+		case "distance":
+			query = query.Order("distance asc")
+		case "price_asc":
+			query = query.Order("wisata.price asc")
+		case "price_desc":
+			query = query.Order("wisata.price desc")
+		case "newest":
+			query = query.Order("wisata.created_at desc")
+		case "popular":
+			query = query.Order(popularitySQL + " desc")
+		default:
+			// Tanpa sort, hasil pencarian diurutkan berdasarkan relevansi
+			if len(searchIDs) > 0 {
+				query = query.Order(clause.Expr{SQL: "FIELD(wisata.id, ?)", Vars: []interface{}{searchIDs}, WithoutParentheses: true})
+			}
+		}
+
 		// Calculate pagination information
 		var totalPages int
 		if perPage > 0 {
@@ -52,11 +170,13 @@ func GetWisatas(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		if wisatas == nil {
 			wisatas = []model.Wisata{}
 		}
+		localizeWisatas(db, requestLocales(c), wisatas)
 
 		response := map[string]interface{}{
 			"code":    http.StatusOK,
 			"error":   false,
 			"wisatas": wisatas,
+			"facets":  facets,
 			"pagination": map[string]interface{}{
 				"current_page": page,
 				"from":         (page-1)*perPage + 1,
@@ -78,7 +198,7 @@ func GetWisataByID(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 		var user model.User
 		result := db.Where("username = ?", username).First(&user)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch user data"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -86,23 +206,24 @@ func GetWisataByID(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 
 		wisataID, err := strconv.ParseUint(wisataIDParam, 10, 64)
 		if err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid wisata ID"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgInvalidWisataID}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		var wisata model.Wisata
-		if err := db.Preload("Category").First(&wisata, wisataID).Error; err != nil {
+		if err := db.Preload("Category").Preload("Facilities").Preload("Media", wisataMediaQuery).First(&wisata, wisataID).Error; err != nil {
 			if err == gorm.ErrRecordNotFound {
-				errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Wisata not found"}
+				errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgWisataNotFound}
 				return c.JSON(http.StatusNotFound, errorResponse)
 			}
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch wisata"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgWisataFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
+		localizeWisata(db, requestLocales(c), &wisata)
 
 		var totalCarbonFootprint float64
 		if err := db.Model(&model.Ticket{}).Select("COALESCE(SUM(carbon_footprint), 0)").Where("wisata_id = ? AND paid_status = ?", wisataID, true).Row().Scan(&totalCarbonFootprint); err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to calculate total carbon footprint"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgTotalCarbonFootprintFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -111,6 +232,7 @@ func GetWisataByID(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
 			"error":                  false,
 			"wisata":                 wisata,
 			"total_carbon_footprint": totalCarbonFootprint,
+			"opening_status":         openingStatus(loadOpeningSchedule(db, wisata), time.Now()),
 		}
 
 		return c.JSON(http.StatusOK, response)
@@ -124,25 +246,25 @@ func GetTotalCarbonFootprintByWisataID(db *gorm.DB, secretKey []byte) echo.Handl
 		var user model.User
 		result := db.Where("username = ?", username).First(&user)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch user data"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
 		wisataID := c.Param("wisata_id")
 		if wisataID == "" {
-			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, Message: "Wisata ID is required"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusBadRequest, ErrorCode: helper.MsgWisataIDRequired}
 			return c.JSON(http.StatusBadRequest, errorResponse)
 		}
 
 		var wisata model.Wisata
 		if err := db.Where("id = ?", wisataID).First(&wisata).Error; err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, Message: "Wisata not found"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusNotFound, ErrorCode: helper.MsgWisataNotFound}
 			return c.JSON(http.StatusNotFound, errorResponse)
 		}
 
 		var totalCarbonFootprint float64
 		if err := db.Model(&model.Ticket{}).Select("COALESCE(SUM(carbon_footprint), 0)").Where("wisata_id = ? AND paid_status = ?", wisataID, true).Row().Scan(&totalCarbonFootprint); err != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to calculate total carbon footprint"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgTotalCarbonFootprintFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
@@ -161,31 +283,58 @@ func GetWisataByCategoryKesukaan(db *gorm.DB, secretKey []byte) echo.HandlerFunc
 		var user model.User
 		result := db.Where("username = ?", username).First(&user)
 		if result.Error != nil {
-			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch user data"}
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
 			return c.JSON(http.StatusInternalServerError, errorResponse)
 		}
 
-		// Dapatkan wisata berdasarkan category_kesukaan user
-		var wisatas []model.Wisata
+		// Mengurutkan wisata dari kategori kesukaan, riwayat pemesanan, favorit, jarak dan popularitas
+		ranked, coldStart, err := recommendWisata(db, user)
+		if err != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgRecommendationsFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
 
-		if user.CategoryID == 0 || user.CategoryKesukaan == "" {
-			// Jika category_kesukaan user kosong, tampilkan semua wisata
-			if err := db.Preload("Category").Find(&wisatas).Error; err != nil {
-				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch all wisatas"}
-				return c.JSON(http.StatusInternalServerError, errorResponse)
-			}
+		page, perPage := helper.GetPaginationParams(c)
+		totalWisatas := len(ranked)
+		var totalPages int
+		if perPage > 0 {
+			totalPages = (totalWisatas + perPage - 1) / perPage
 		} else {
-			// Jika category_kesukaan user tidak kosong, tampilkan wisata berdasarkan category_kesukaan
-			if err := db.Preload("Category").Where("category_id = ?", user.CategoryID).Find(&wisatas).Error; err != nil {
-				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, Message: "Failed to fetch wisatas by category_kesukaan"}
+			totalPages = 0
+		}
+
+		from := min((page-1)*perPage, totalWisatas)
+		to := min(from+perPage, totalWisatas)
+		pageIDs := []uint{}
+		for _, scored := range ranked[from:to] {
+			pageIDs = append(pageIDs, scored.ID)
+		}
+
+		wisatas := []model.Wisata{}
+		if len(pageIDs) > 0 {
+			if err := db.Preload("Category").
+				Where("id IN ?", pageIDs).
+				Order(clause.Expr{SQL: "FIELD(id, ?)", Vars: []interface{}{pageIDs}, WithoutParentheses: true}).
+				Find(&wisatas).Error; err != nil {
+				errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgRecommendationsFetchFailed}
 				return c.JSON(http.StatusInternalServerError, errorResponse)
 			}
 		}
+		localizeWisatas(db, requestLocales(c), wisatas)
 
 		return c.JSON(http.StatusOK, map[string]interface{}{
-			"code":    http.StatusOK,
-			"error":   false,
-			"wisatas": wisatas,
+			"code":       http.StatusOK,
+			"error":      false,
+			"wisatas":    wisatas,
+			"cold_start": coldStart,
+			"pagination": map[string]interface{}{
+				"current_page": page,
+				"from":         from + 1,
+				"last_page":    totalPages,
+				"per_page":     perPage,
+				"to":           to,
+				"total":        totalWisatas,
+			},
 		})
 	}
 }
@@ -0,0 +1,183 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"strconv"
+	"strings"
+)
+
+// popularitySQL menghitung jumlah tiket terbayar sebagai ukuran popularitas tempat wisata
+const popularitySQL = "(SELECT COUNT(tickets.id) FROM tickets WHERE tickets.wisata_id = wisata.id AND tickets.paid_status = true)"
+
+var errInvalidWisataFilter = errors.New("invalid wisata filter")
+
+// wisataFilter berisi filter faset pada daftar tempat wisata
+type wisataFilter struct {
+	MinPrice    *int
+	MaxPrice    *int
+	Cities      []string
+	CategoryIDs []uint
+	Facilities  []string
+	FacilityIDs []uint
+	OpenNow     bool
+	OpenNowIDs  []uint // Diisi dari jadwal buka karena status buka bergantung pada zona waktu tiap wisata
+	MinRating   float64
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+type CategoryFacetCount struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// queryParamList membaca parameter yang boleh diulang (?city=a&city=b) maupun dipisah koma (?city=a,b)
+func queryParamList(c echo.Context, key string) []string {
+	var values []string
+	for _, param := range c.QueryParams()[key] {
+		for _, value := range strings.Split(param, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+func parseWisataFilter(c echo.Context) (wisataFilter, error) {
+	var filter wisataFilter
+
+	if minPrice := c.QueryParam("min_price"); minPrice != "" {
+		value, err := strconv.Atoi(minPrice)
+		if err != nil || value < 0 {
+			return filter, errInvalidWisataFilter
+		}
+		filter.MinPrice = &value
+	}
+	if maxPrice := c.QueryParam("max_price"); maxPrice != "" {
+		value, err := strconv.Atoi(maxPrice)
+		if err != nil || value < 0 {
+			return filter, errInvalidWisataFilter
+		}
+		filter.MaxPrice = &value
+	}
+
+	filter.Cities = queryParamList(c, "city")
+	for _, categoryID := range queryParamList(c, "category_id") {
+		value, err := strconv.ParseUint(categoryID, 10, 64)
+		if err != nil {
+			return filter, errInvalidWisataFilter
+		}
+		filter.CategoryIDs = append(filter.CategoryIDs, uint(value))
+	}
+	for _, facility := range queryParamList(c, "facilities") {
+		filter.Facilities = append(filter.Facilities, strings.ToLower(facility))
+	}
+	for _, facilityID := range queryParamList(c, "facility_id") {
+		value, err := strconv.ParseUint(facilityID, 10, 64)
+		if err != nil {
+			return filter, errInvalidWisataFilter
+		}
+		filter.FacilityIDs = append(filter.FacilityIDs, uint(value))
+	}
+
+	filter.OpenNow = c.QueryParam("open_now") == "true"
+
+	if minRating := c.QueryParam("min_rating"); minRating != "" {
+		value, err := strconv.ParseFloat(minRating, 64)
+		if err != nil || value < 0 || value > 5 {
+			return filter, errInvalidWisataFilter
+		}
+		filter.MinRating = value
+	}
+
+	return filter, nil
+}
+
+// apply menambahkan kondisi filter ke query, kecuali faset yang sedang dihitung agar pilihan lain di faset itu tetap terlihat
+func (f wisataFilter) apply(query *gorm.DB, except string) *gorm.DB {
+	if except != "price" {
+		if f.MinPrice != nil {
+			query = query.Where("wisata.price >= ?", *f.MinPrice)
+		}
+		if f.MaxPrice != nil {
+			query = query.Where("wisata.price <= ?", *f.MaxPrice)
+		}
+	}
+	if except != "city" && len(f.Cities) > 0 {
+		query = query.Where("wisata.kota IN ?", f.Cities)
+	}
+	if except != "category" && len(f.CategoryIDs) > 0 {
+		query = query.Where("wisata.category_id IN ?", f.CategoryIDs)
+	}
+	if except != "facility" {
+		for _, facility := range f.Facilities {
+			query = query.Where("wisata.id IN (SELECT wisata_facilities.wisata_id FROM wisata_facilities JOIN facilities ON facilities.id = wisata_facilities.facility_id WHERE LOWER(facilities.name) = ?)", facility)
+		}
+		for _, facilityID := range f.FacilityIDs {
+			query = query.Where("wisata.id IN (SELECT wisata_facilities.wisata_id FROM wisata_facilities WHERE wisata_facilities.facility_id = ?)", facilityID)
+		}
+	}
+	if f.OpenNow {
+		query = query.Where("wisata.id IN ?", f.OpenNowIDs)
+	}
+	if f.MinRating > 0 {
+		query = query.Where("wisata.average_rating >= ?", f.MinRating)
+	}
+	return query
+}
+
+// wisataFacets menghitung jumlah tempat wisata per kota, kategori dan fasilitas beserta rentang harga
+func wisataFacets(query *gorm.DB, filter wisataFilter) (map[string]interface{}, error) {
+	cities := []FacetCount{}
+	if err := filter.apply(query, "city").
+		Select("wisata.kota AS value, COUNT(wisata.id) AS count").
+		Group("wisata.kota").
+		Order("count desc").
+		Scan(&cities).Error; err != nil {
+		return nil, err
+	}
+
+	categories := []CategoryFacetCount{}
+	if err := filter.apply(query, "category").
+		Select("categories.id AS id, categories.category_name AS name, COUNT(wisata.id) AS count").
+		Group("categories.id, categories.category_name").
+		Order("count desc").
+		Scan(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	facilities := []CategoryFacetCount{}
+	if err := filter.apply(query, "facility").
+		Joins("JOIN wisata_facilities ON wisata_facilities.wisata_id = wisata.id").
+		Joins("JOIN facilities ON facilities.id = wisata_facilities.facility_id").
+		Select("facilities.id AS id, facilities.name AS name, COUNT(wisata.id) AS count").
+		Group("facilities.id, facilities.name").
+		Order("count desc, facilities.name asc").
+		Scan(&facilities).Error; err != nil {
+		return nil, err
+	}
+
+	var price struct {
+		Min int `json:"min"`
+		Max int `json:"max"`
+	}
+	if err := filter.apply(query, "price").
+		Select("COALESCE(MIN(wisata.price), 0) AS min, COALESCE(MAX(wisata.price), 0) AS max").
+		Scan(&price).Error; err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"cities":     cities,
+		"categories": categories,
+		"facilities": facilities,
+		"price":      price,
+	}, nil
+}
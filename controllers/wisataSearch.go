@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"log"
+	"myproject/helper"
+	"myproject/middleware"
+	"myproject/model"
+	"myproject/search"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// maxSearchResults membatasi jumlah hasil pencarian yang diteruskan ke query daftar wisata
+const maxSearchResults = 1000
+
+// Interval bawaan pembangunan ulang index pencarian, dapat diubah lewat env SEARCH_INDEX_REFRESH_MINUTES
+const defaultSearchIndexRefreshInterval = 5 * time.Minute
+
+// wisataSearchIndex adalah index pencarian tempat wisata, judul diberi bobot lebih tinggi dari deskripsi.
+// Index ini hanya ada di memori setiap proses: jika aplikasi berjalan di beberapa instance, perubahan wisata
+// langsung terlihat di instance yang menanganinya saja, sedangkan instance lain baru melihatnya setelah
+// pembangunan ulang berkala oleh StartSearchIndexJob.
+var wisataSearchIndex search.Index = search.NewMemoryIndex(map[string]float64{
+	"title":       3,
+	"kota":        2,
+	"category":    1.5,
+	"location":    1,
+	"description": 0.5,
+}, "title")
+
+func wisataSearchDocument(wisata model.Wisata) search.Document {
+	return search.Document{
+		ID: wisata.ID,
+		Fields: map[string]string{
+			"title":       wisata.Title,
+			"kota":        wisata.Kota,
+			"category":    wisata.Category.CategoryName,
+			"location":    wisata.Location,
+			"description": wisata.Description,
+		},
+	}
+}
+
+// indexWisata memperbarui index pencarian, wisata harus sudah memuat Category
+func indexWisata(wisata model.Wisata) {
+	wisataSearchIndex.Upsert(wisataSearchDocument(wisata))
+}
+
+func unindexWisata(wisataID uint) {
+	wisataSearchIndex.Delete(wisataID)
+}
+
+// BuildWisataSearchIndex mengganti isi index pencarian dengan seluruh tempat wisata dari database
+func BuildWisataSearchIndex(db *gorm.DB) error {
+	var wisatas []model.Wisata
+	if err := db.Preload("Category").Find(&wisatas).Error; err != nil {
+		return err
+	}
+
+	docs := make([]search.Document, 0, len(wisatas))
+	for _, wisata := range wisatas {
+		docs = append(docs, wisataSearchDocument(wisata))
+	}
+	wisataSearchIndex.Replace(docs)
+	return nil
+}
+
+func searchIndexRefreshInterval() time.Duration {
+	minutes, err := strconv.Atoi(os.Getenv("SEARCH_INDEX_REFRESH_MINUTES"))
+	if err != nil || minutes <= 0 {
+		return defaultSearchIndexRefreshInterval
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// StartSearchIndexJob membangun index pencarian saat server mulai lalu membangunnya ulang secara berkala,
+// agar perubahan wisata dari instance lain ikut masuk ke index proses ini
+func StartSearchIndexJob(db *gorm.DB) {
+	if err := BuildWisataSearchIndex(db); err != nil {
+		log.Println("Gagal membangun index pencarian wisata:", err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(searchIndexRefreshInterval())
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := BuildWisataSearchIndex(db); err != nil {
+				log.Println("Gagal membangun ulang index pencarian wisata:", err)
+			}
+		}
+	}()
+}
+
+// searchWisataIDs mengembalikan ID tempat wisata yang cocok dengan query, terurut dari yang paling relevan
+func searchWisataIDs(query string) []uint {
+	results := wisataSearchIndex.Search(query, maxSearchResults)
+
+	ids := make([]uint, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, result.ID)
+	}
+	return ids
+}
+
+// Menampilkan saran autocomplete judul tempat wisata
+func GetWisataAutocomplete(db *gorm.DB, secretKey []byte) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		username := middleware.ExtractUsernameFromToken(c, secretKey)
+
+		var user model.User
+		result := db.Where("username = ?", username).First(&user)
+		if result.Error != nil {
+			errorResponse := helper.ErrorResponse{Code: http.StatusInternalServerError, ErrorCode: helper.MsgUserFetchFailed}
+			return c.JSON(http.StatusInternalServerError, errorResponse)
+		}
+
+		limit, err := strconv.Atoi(c.QueryParam("limit"))
+		if err != nil || limit <= 0 || limit > 20 {
+			limit = 10
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"code":        http.StatusOK,
+			"error":       false,
+			"suggestions": wisataSearchIndex.Suggest(c.QueryParam("q"), limit),
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// GeohashPrecision adalah panjang geohash yang disimpan untuk setiap tempat wisata (sel sekitar 150 m)
+const GeohashPrecision = 7
+
+// maxCoverCells membatasi jumlah prefix geohash yang dipakai untuk menyaring satu bounding box
+const maxCoverCells = 16
+
+const earthRadiusKm = 6371.0088
+
+const base32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+var ErrInvalidCoordinate = errors.New("invalid coordinate")
+
+// BoundingBox adalah area persegi dalam derajat lintang dan bujur
+type BoundingBox struct {
+	MinLat  float64
+	MinLong float64
+	MaxLat  float64
+	MaxLong float64
+}
+
+// Encode mengubah koordinat menjadi geohash dengan panjang precision
+func Encode(lat, long float64, precision int) string {
+	latRange := [2]float64{-90, 90}
+	longRange := [2]float64{-180, 180}
+
+	var hash strings.Builder
+	bit, ch, even := 0, 0, true
+	for hash.Len() < precision {
+		if even {
+			mid := (longRange[0] + longRange[1]) / 2
+			if long >= mid {
+				ch |= 1 << (4 - bit)
+				longRange[0] = mid
+			} else {
+				longRange[1] = mid
+			}
+		} else {
+			mid := (latRange[0] + latRange[1]) / 2
+			if lat >= mid {
+				ch |= 1 << (4 - bit)
+				latRange[0] = mid
+			} else {
+				latRange[1] = mid
+			}
+		}
+		even = !even
+
+		if bit < 4 {
+			bit++
+		} else {
+			hash.WriteByte(base32[ch])
+			bit, ch = 0, 0
+		}
+	}
+	return hash.String()
+}
+
+// cellSize mengembalikan tinggi dan lebar (derajat) satu sel geohash dengan panjang precision
+func cellSize(precision int) (float64, float64) {
+	bits := precision * 5
+	longBits := (bits + 1) / 2
+	latBits := bits / 2
+	return 180 / math.Pow(2, float64(latBits)), 360 / math.Pow(2, float64(longBits))
+}
+
+// Cover mengembalikan prefix geohash sepresisi mungkin yang bersama-sama menutupi seluruh bounding box
+func Cover(box BoundingBox) []string {
+	for precision := GeohashPrecision; precision >= 1; precision-- {
+		height, width := cellSize(precision)
+		rows := int(math.Floor(box.MaxLat/height) - math.Floor(box.MinLat/height) + 1)
+		cols := int(math.Floor(box.MaxLong/width) - math.Floor(box.MinLong/width) + 1)
+		if rows*cols > maxCoverCells && precision > 1 {
+			continue
+		}
+
+		seen := make(map[string]bool)
+		var prefixes []string
+		for lat := box.MinLat; ; lat += height {
+			if lat > box.MaxLat {
+				lat = box.MaxLat
+			}
+			for long := box.MinLong; ; long += width {
+				if long > box.MaxLong {
+					long = box.MaxLong
+				}
+				hash := Encode(lat, long, precision)
+				if !seen[hash] {
+					seen[hash] = true
+					prefixes = append(prefixes, hash)
+				}
+				if long == box.MaxLong {
+					break
+				}
+			}
+			if lat == box.MaxLat {
+				break
+			}
+		}
+		return prefixes
+	}
+	return nil
+}
+
+// Around membuat bounding box yang memuat seluruh titik dalam radius (km) dari sebuah koordinat
+func Around(lat, long, radiusKm float64) BoundingBox {
+	deltaLat := radiusKm / earthRadiusKm * 180 / math.Pi
+	box := BoundingBox{
+		MinLat:  math.Max(lat-deltaLat, -90),
+		MaxLat:  math.Min(lat+deltaLat, 90),
+		MinLong: -180,
+		MaxLong: 180,
+	}
+
+	// Di dekat kutub lingkaran radius bisa melingkupi semua bujur
+	cosLat := math.Cos(lat * math.Pi / 180)
+	if box.MinLat > -90 && box.MaxLat < 90 && cosLat > 0 {
+		deltaLong := deltaLat / cosLat
+		if deltaLong < 180 {
+			box.MinLong = math.Max(long-deltaLong, -180)
+			box.MaxLong = math.Min(long+deltaLong, 180)
+		}
+	}
+	return box
+}
+
+// ParsePoint membaca koordinat berformat "lat,long"
+func ParsePoint(value string) (float64, float64, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 2 {
+		return 0, 0, ErrInvalidCoordinate
+	}
+
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return 0, 0, ErrInvalidCoordinate
+	}
+	long, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil || long < -180 || long > 180 {
+		return 0, 0, ErrInvalidCoordinate
+	}
+	return lat, long, nil
+}
+
+// ParseBoundingBox membaca bounding box berformat "min_lat,min_long,max_lat,max_long"
+func ParseBoundingBox(value string) (BoundingBox, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 4 {
+		return BoundingBox{}, ErrInvalidCoordinate
+	}
+
+	minLat, minLong, err := ParsePoint(parts[0] + "," + parts[1])
+	if err != nil {
+		return BoundingBox{}, err
+	}
+	maxLat, maxLong, err := ParsePoint(parts[2] + "," + parts[3])
+	if err != nil {
+		return BoundingBox{}, err
+	}
+	if minLat > maxLat || minLong > maxLong {
+		return BoundingBox{}, ErrInvalidCoordinate
+	}
+
+	return BoundingBox{MinLat: minLat, MinLong: minLong, MaxLat: maxLat, MaxLong: maxLong}, nil
+}
@@ -5,14 +5,14 @@ import (
 	"myproject/model"
 )
 
-func GetCooperationEmailBody(message model.CooperationMessage) string {
+func GetCooperationEmailBody(locales []string, message model.CooperationMessage) string {
 	body := fmt.Sprintf(`
 		<!DOCTYPE html>
-		<html lang="en">
+		<html lang="%s">
 		<head>
 			<meta charset="UTF-8">
 			<meta name="viewport" content="width=device-width, initial-scale=1.0">
-			<title>New Cooperation Message</title>
+			<title>%s</title>
 			<link rel="stylesheet" href="https://stackpath.bootstrapcdn.com/bootstrap/4.5.2/css/bootstrap.min.css">
 			<style>
 				body {
@@ -46,28 +46,33 @@ func GetCooperationEmailBody(message model.CooperationMessage) string {
 		<body>
 			<div class="card">
 				<a href="https://imgbb.com/"><img src="https://i.ibb.co/KXxHL9r/Logo-Destimate.png" alt="Logo-Destimate" class="logo"></a>
-				<h2 class="mt-4">New Cooperation Message</h2>
-				<p><strong>Name:</strong> %s %s</p>
-				<p><strong>Email:</strong> %s</p>
-				<p><strong>Phone Number:</strong> %s</p>
-				<p><strong>Message:</strong> %s</p>
+				<h2 class="mt-4">%s</h2>
+				<p><strong>%s:</strong> %s %s</p>
+				<p><strong>%s:</strong> %s</p>
+				<p><strong>%s:</strong> %s</p>
+				<p><strong>%s:</strong> %s</p>
 			</div>
 		</body>
 		</html>
 	`,
-		message.FirstName, message.LastName, message.Email, message.PhoneNumber, message.Message)
+		MessageLocale(locales), Translate(locales, MsgEmailCooperationAdminSubject),
+		Translate(locales, MsgEmailCooperationAdminSubject),
+		Translate(locales, MsgEmailLabelName), message.FirstName, message.LastName,
+		Translate(locales, MsgEmailLabelEmail), message.Email,
+		Translate(locales, MsgEmailLabelPhone), message.PhoneNumber,
+		Translate(locales, MsgEmailLabelMessage), message.Message)
 
 	return body
 }
 
-func GetUserCooperationEmailBody(message model.CooperationMessage) string {
+func GetUserCooperationEmailBody(locales []string, message model.CooperationMessage) string {
 	body := fmt.Sprintf(`
 		<!DOCTYPE html>
-		<html lang="en">
+		<html lang="%s">
 		<head>
 			<meta charset="UTF-8">
 			<meta name="viewport" content="width=device-width, initial-scale=1.0">
-			<title>Your Cooperation Message</title>
+			<title>%s</title>
 			<link rel="stylesheet" href="https://stackpath.bootstrapcdn.com/bootstrap/4.5.2/css/bootstrap.min.css">
 			<style>
 				body {
@@ -101,19 +106,25 @@ func GetUserCooperationEmailBody(message model.CooperationMessage) string {
 		<body>
 			<div class="card">
 				<a href="https://imgbb.com/"><img src="https://i.ibb.co/KXxHL9r/Logo-Destimate.png" alt="Logo-Destimate" class="logo"></a>
-				<h2 class="mt-4">Thank you for your Cooperation Message</h2>
-				<p>We will get back to you as soon as possible.</p>
+				<h2 class="mt-4">%s</h2>
+				<p>%s</p>
 
-				<h3 class="mt-4">Your Message:</h3>
-				<p><strong>Name:</strong> %s %s</p>
-				<p><strong>Email:</strong> %s</p>
-				<p><strong>Phone Number:</strong> %s</p>
-				<p><strong>Message:</strong> %s</p>
+				<h3 class="mt-4">%s</h3>
+				<p><strong>%s:</strong> %s %s</p>
+				<p><strong>%s:</strong> %s</p>
+				<p><strong>%s:</strong> %s</p>
+				<p><strong>%s:</strong> %s</p>
 			</div>
 		</body>
 		</html>
 	`,
-		message.FirstName, message.LastName, message.Email, message.PhoneNumber, message.Message)
+		MessageLocale(locales), Translate(locales, MsgEmailCooperationUserSubject),
+		Translate(locales, MsgEmailCooperationThanks), Translate(locales, MsgEmailCooperationReply),
+		Translate(locales, MsgEmailCooperationYourMessage),
+		Translate(locales, MsgEmailLabelName), message.FirstName, message.LastName,
+		Translate(locales, MsgEmailLabelEmail), message.Email,
+		Translate(locales, MsgEmailLabelPhone), message.PhoneNumber,
+		Translate(locales, MsgEmailLabelMessage), message.Message)
 
 	return body
 }
@@ -0,0 +1,108 @@
+package helper
+
+import "time"
+
+const (
+	CrowdQuiet    = "quiet"
+	CrowdModerate = "moderate"
+	CrowdBusy     = "busy"
+)
+
+// Batas rasio perkiraan pengunjung terhadap rata-rata harian untuk setiap tingkat keramaian
+const (
+	crowdQuietRatio = 0.75
+	crowdBusyRatio  = 1.5
+)
+
+// Pengali pola hari libur jika riwayat belum memiliki data hari libur
+const defaultHolidayBoost = 1.5
+
+// CrowdForecast adalah perkiraan tingkat keramaian tempat wisata pada suatu tanggal
+type CrowdForecast struct {
+	Date             string  `json:"date"`
+	Level            string  `json:"level"`
+	ExpectedVisitors float64 `json:"expected_visitors"`
+	BookedVisitors   int     `json:"booked_visitors"`
+	IsHoliday        bool    `json:"is_holiday"`
+	HolidayName      string  `json:"holiday_name,omitempty"`
+}
+
+// CrowdHistory berisi jumlah pengunjung harian pada rentang tanggal historis [From, To)
+type CrowdHistory struct {
+	From     time.Time
+	To       time.Time
+	Visitors map[string]int    // key: tanggal YYYY-MM-DD
+	Holidays map[string]string // key: tanggal YYYY-MM-DD, value: nama hari libur
+}
+
+type crowdBaseline struct {
+	total int
+	days  int
+}
+
+func (b crowdBaseline) average() (float64, bool) {
+	if b.days == 0 {
+		return 0, false
+	}
+	return float64(b.total) / float64(b.days), true
+}
+
+// ForecastCrowd memperkirakan keramaian dari tiket yang sudah dipesan pada tanggal tersebut, pola hari dalam seminggu dan kalender libur
+func ForecastCrowd(date time.Time, booked int, history CrowdHistory) CrowdForecast {
+	key := date.Format("2006-01-02")
+	holidayName, isHoliday := history.Holidays[key]
+
+	var overall, holiday crowdBaseline
+	var weekdays [7]crowdBaseline
+	for day := history.From; day.Before(history.To); day = day.AddDate(0, 0, 1) {
+		dayKey := day.Format("2006-01-02")
+		visitors := history.Visitors[dayKey]
+		overall.total += visitors
+		overall.days++
+		if _, ok := history.Holidays[dayKey]; ok {
+			holiday.total += visitors
+			holiday.days++
+			continue
+		}
+		weekdays[day.Weekday()].total += visitors
+		weekdays[day.Weekday()].days++
+	}
+
+	mean, _ := overall.average()
+	expected, ok := weekdays[date.Weekday()].average()
+	if !ok {
+		expected = mean
+	}
+	if isHoliday {
+		if holidayAverage, ok := holiday.average(); ok {
+			expected = holidayAverage
+		} else {
+			expected *= defaultHolidayBoost
+		}
+	}
+	// Tiket yang sudah dipesan adalah batas bawah jumlah pengunjung
+	if float64(booked) > expected {
+		expected = float64(booked)
+	}
+
+	level := CrowdModerate
+	reference := mean
+	if reference < 1 {
+		reference = 1
+	}
+	switch ratio := expected / reference; {
+	case expected == 0 || ratio < crowdQuietRatio:
+		level = CrowdQuiet
+	case ratio >= crowdBusyRatio:
+		level = CrowdBusy
+	}
+
+	return CrowdForecast{
+		Date:             key,
+		Level:            level,
+		ExpectedVisitors: expected,
+		BookedVisitors:   booked,
+		IsHoliday:        isHoliday,
+		HolidayName:      holidayName,
+	}
+}
@@ -5,12 +5,12 @@ import (
 	"myproject/model"
 )
 
-func GetEmailSubject(ticket model.Ticket) string {
-	return "Pembelian Tiket Wisata Berhasil - Invoice No: " + ticket.InvoiceNumber
+func GetEmailSubject(locales []string, ticket model.Ticket) string {
+	return Translate(locales, MsgEmailInvoiceSubject, ticket.InvoiceNumber)
 }
 
-func GetEmailBody(ticket model.Ticket, totalCost int, wisataName, kodeVoucher string, pointsEarned, usedPoints int, carbonFootprint float64) string {
-	emailBody := "<html><head><style>"
+func GetEmailBody(locales []string, ticket model.Ticket, totalCost int, wisataName, kodeVoucher string, pointsEarned, usedPoints int, carbonFootprint float64) string {
+	emailBody := "<html lang='" + MessageLocale(locales) + "'><head><style>"
 	emailBody += "body {font-family: Arial, sans-serif;}"
 	emailBody += ".container {max-width: 600px; margin: 0 auto; padding: 20px;}"
 	emailBody += ".header {background-color: #1E90FF; color: #fff; padding: 20px; border-bottom: 1px solid #ddd; position: relative;}"
@@ -26,14 +26,14 @@ func GetEmailBody(ticket model.Ticket, totalCost int, wisataName, kodeVoucher st
 	emailBody += "<div class='container'>"
 	emailBody += "<div class='header'>"
 	emailBody += "<img src='https://i.ibb.co/KXxHL9r/Logo-Destimate.png' alt='Destimate Logo'>"
-	emailBody += "<h1>Successful Ticket Purchase</h1>"
+	emailBody += "<h1>" + Translate(locales, MsgEmailInvoiceHeading) + "</h1>"
 	emailBody += "</div>"
 	emailBody += "<div class='invoice-details'>"
-	emailBody += "<p><strong>Invoice Number:</strong> " + ticket.InvoiceNumber + "</p>"
-	emailBody += "<p><strong>Destination:</strong> " + wisataName + "</p>"
-	emailBody += "<p><strong>Quantity:</strong> " + fmt.Sprintf("%d", ticket.Quantity) + "</p>"
-	emailBody += "<p><strong>Check-in Date:</strong> " + ticket.CheckinBooking.Format("2006-01-02") + "</p>"
-	emailBody += "<p><strong>Total Price:</strong> Rp. " + fmt.Sprintf("%d", totalCost) + "</p>"
+	emailBody += "<p><strong>" + Translate(locales, MsgEmailInvoiceNumber) + ":</strong> " + ticket.InvoiceNumber + "</p>"
+	emailBody += "<p><strong>" + Translate(locales, MsgEmailInvoiceDestination) + ":</strong> " + wisataName + "</p>"
+	emailBody += "<p><strong>" + Translate(locales, MsgEmailInvoiceQuantity) + ":</strong> " + fmt.Sprintf("%d", ticket.Quantity) + "</p>"
+	emailBody += "<p><strong>" + Translate(locales, MsgEmailInvoiceCheckin) + ":</strong> " + ticket.CheckinBooking.Format("2006-01-02") + "</p>"
+	emailBody += "<p><strong>" + Translate(locales, MsgEmailInvoiceTotal) + ":</strong> Rp. " + fmt.Sprintf("%d", totalCost) + "</p>"
 
 	ifStringNotEmpty := func(s, strIfNotEmpty string) string {
 		if s != "" {
@@ -42,21 +42,21 @@ func GetEmailBody(ticket model.Ticket, totalCost int, wisataName, kodeVoucher st
 		return ""
 	}
 
-	emailBody += ifStringNotEmpty(kodeVoucher, "<p><strong>Voucher Code:</strong> "+kodeVoucher+"</p>")
-	emailBody += "<p><strong>Points Earned:</strong> " + fmt.Sprintf("%d", pointsEarned) + "</p>"
-	emailBody += "<p><strong>Used Points:</strong> " + fmt.Sprintf("%d", usedPoints) + "</p>"
-	emailBody += "<p><strong>Carbon Footprint:</strong> " + fmt.Sprintf("%.2f", carbonFootprint) + " grams CO2</p>"
+	emailBody += ifStringNotEmpty(kodeVoucher, "<p><strong>"+Translate(locales, MsgEmailInvoiceVoucher)+":</strong> "+kodeVoucher+"</p>")
+	emailBody += "<p><strong>" + Translate(locales, MsgEmailInvoicePointsEarned) + ":</strong> " + fmt.Sprintf("%d", pointsEarned) + "</p>"
+	emailBody += "<p><strong>" + Translate(locales, MsgEmailInvoicePointsUsed) + ":</strong> " + fmt.Sprintf("%d", usedPoints) + "</p>"
+	emailBody += "<p><strong>" + Translate(locales, MsgEmailInvoiceCarbon) + ":</strong> " + Translate(locales, MsgEmailInvoiceCarbonValue, carbonFootprint) + "</p>"
 	emailBody += "</div>"
 	emailBody += "<hr>"
-	emailBody += "<p>Thank you for your purchase! We hope you have a wonderful experience using our platform.</p>"
+	emailBody += "<p>" + Translate(locales, MsgEmailInvoiceThanks) + "</p>"
 	emailBody += "<div style='text-align: left; font-size: 14px; margin-top: 20px;'>"
-	emailBody += "<p style='font-weight: bold; margin: 0;'>Best Regards</p>"
+	emailBody += "<p style='font-weight: bold; margin: 0;'>" + Translate(locales, MsgEmailRegards) + "</p>"
 	emailBody += "<p style='font-size: 12px; margin: 0;'>Muhammad Aimar Rizki Utama 💙</p>"
 	emailBody += "<p style='font-size: 12px; margin: 0;'>CEO Destimate Indonesia</p>"
 	emailBody += "</div>"
 	emailBody += "</div>"
 	emailBody += "<div class='footer'>"
-	emailBody += "<p>&copy; 2023 Destimate. All rights reserved. | <a href='https://destimate-dev.netlify.app/' target='_blank'>Destimate</a></p>"
+	emailBody += "<p>&copy; 2023 Destimate. " + Translate(locales, MsgEmailRightsReserved) + " | <a href='https://destimate-dev.netlify.app/' target='_blank'>Destimate</a></p>"
 	emailBody += "</div>"
 	emailBody += "</body></html>"
 
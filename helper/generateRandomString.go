@@ -1,6 +1,8 @@
 package helper
 
 import (
+	cryptorand "crypto/rand"
+	"encoding/hex"
 	"math/rand"
 )
 
@@ -13,3 +15,12 @@ func GenerateRandomString(length int) string {
 	}
 	return string(result)
 }
+
+// GenerateSecureToken menghasilkan token acak kriptografis dalam bentuk hex untuk link publik yang tidak boleh ditebak
+func GenerateSecureToken(byteLength int) (string, error) {
+	buffer := make([]byte, byteLength)
+	if _, err := cryptorand.Read(buffer); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buffer), nil
+}
@@ -0,0 +1,112 @@
+package helper
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Panjang maksimal baris ICS dalam oktet sebelum dilipat (RFC 5545 bagian 3.1)
+const icsLineLimit = 75
+
+// ICSContentType adalah content type untuk respons file kalender
+const ICSContentType = "text/calendar; charset=utf-8"
+
+// Status acara ICS
+const (
+	ICSStatusConfirmed = "CONFIRMED"
+	ICSStatusTentative = "TENTATIVE"
+	ICSStatusCancelled = "CANCELLED"
+)
+
+// ICSEvent adalah satu acara pada file kalender iCalendar
+type ICSEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool // Hanya tanggal Start dan End yang dipakai, End bersifat eksklusif
+	Status      string
+	// Sequence dan LastModified membuat aplikasi kalender memperbarui acara dengan UID yang sama
+	Sequence     int
+	LastModified time.Time
+}
+
+// BuildICS menyusun file kalender iCalendar dari daftar acara
+func BuildICS(calendarName string, events []ICSEvent) string {
+	var builder strings.Builder
+	writeICSLine(&builder, "BEGIN:VCALENDAR")
+	writeICSLine(&builder, "VERSION:2.0")
+	writeICSLine(&builder, "PRODID:-//Destimate//Destimate//ID")
+	writeICSLine(&builder, "CALSCALE:GREGORIAN")
+	writeICSLine(&builder, "METHOD:PUBLISH")
+	writeICSLine(&builder, "X-WR-CALNAME:"+escapeICSText(calendarName))
+
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	for _, event := range events {
+		writeICSLine(&builder, "BEGIN:VEVENT")
+		writeICSLine(&builder, "UID:"+event.UID)
+		writeICSLine(&builder, "DTSTAMP:"+stamp)
+		if !event.LastModified.IsZero() {
+			writeICSLine(&builder, "LAST-MODIFIED:"+event.LastModified.UTC().Format("20060102T150405Z"))
+		}
+		if event.Sequence > 0 {
+			writeICSLine(&builder, "SEQUENCE:"+strconv.Itoa(event.Sequence))
+		}
+		if event.AllDay {
+			writeICSLine(&builder, "DTSTART;VALUE=DATE:"+event.Start.Format("20060102"))
+			writeICSLine(&builder, "DTEND;VALUE=DATE:"+event.End.Format("20060102"))
+		} else {
+			writeICSLine(&builder, "DTSTART:"+event.Start.UTC().Format("20060102T150405Z"))
+			writeICSLine(&builder, "DTEND:"+event.End.UTC().Format("20060102T150405Z"))
+		}
+		writeICSLine(&builder, "SUMMARY:"+escapeICSText(event.Summary))
+		if event.Description != "" {
+			writeICSLine(&builder, "DESCRIPTION:"+escapeICSText(event.Description))
+		}
+		if event.Location != "" {
+			writeICSLine(&builder, "LOCATION:"+escapeICSText(event.Location))
+		}
+		if event.URL != "" {
+			writeICSLine(&builder, "URL:"+event.URL)
+		}
+		if event.Status != "" {
+			writeICSLine(&builder, "STATUS:"+event.Status)
+		}
+		writeICSLine(&builder, "END:VEVENT")
+	}
+
+	writeICSLine(&builder, "END:VCALENDAR")
+	return builder.String()
+}
+
+// escapeICSText meng-escape karakter khusus pada nilai teks ICS
+func escapeICSText(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return replacer.Replace(value)
+}
+
+// writeICSLine menulis satu baris ICS dengan CRLF dan melipat baris panjang tanpa memotong karakter UTF-8
+func writeICSLine(builder *strings.Builder, line string) {
+	limit := icsLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isUTF8Start(line[cut]) {
+			cut--
+		}
+		builder.WriteString(line[:cut])
+		builder.WriteString("\r\n ")
+		line = line[cut:]
+		// Baris lanjutan diawali satu spasi sehingga sisa kapasitasnya berkurang satu
+		limit = icsLineLimit - 1
+	}
+	builder.WriteString(line)
+	builder.WriteString("\r\n")
+}
+
+func isUTF8Start(b byte) bool {
+	return b&0xC0 != 0x80
+}
@@ -0,0 +1,88 @@
+package helper
+
+import (
+	"github.com/labstack/echo/v4"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale adalah locale konten asli yang tersimpan di tabel utama
+const DefaultLocale = "id"
+
+// LocalesContextKey menyimpan urutan locale request yang sudah ditentukan middleware
+const LocalesContextKey = "locales"
+
+// Format tag bahasa yang diterima, misalnya "en", "ja" atau "zh-cn"
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})?$`)
+
+// NormalizeLocale mengubah tag bahasa menjadi huruf kecil dengan pemisah "-" dan memvalidasi formatnya
+func NormalizeLocale(value string) (string, bool) {
+	locale := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(value), "_", "-"))
+	return locale, localePattern.MatchString(locale)
+}
+
+// PreferredLocales mengurutkan locale dari header Accept-Language berdasarkan bobot q.
+// Locale dengan region juga diikuti bahasa dasarnya (en-us lalu en), dan daftar selalu diakhiri DefaultLocale sebagai fallback.
+func PreferredLocales(acceptLanguage string) []string {
+	type weightedLocale struct {
+		locale string
+		weight float64
+	}
+
+	var weighted []weightedLocale
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		locale, ok := NormalizeLocale(tag)
+		if !ok {
+			continue
+		}
+		weight := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+		if weight <= 0 {
+			continue
+		}
+		weighted = append(weighted, weightedLocale{locale: locale, weight: weight})
+	}
+	sort.SliceStable(weighted, func(i, j int) bool { return weighted[i].weight > weighted[j].weight })
+
+	seen := make(map[string]bool)
+	var locales []string
+	add := func(locale string) {
+		if !seen[locale] {
+			seen[locale] = true
+			locales = append(locales, locale)
+		}
+	}
+	for _, item := range weighted {
+		add(item.locale)
+		if base, _, found := strings.Cut(item.locale, "-"); found {
+			add(base)
+		}
+	}
+	add(DefaultLocale)
+	return locales
+}
+
+// UserLocales mengutamakan locale yang disimpan user, jika belum diatur memakai fallback
+func UserLocales(savedLocale string, fallback []string) []string {
+	if savedLocale == "" {
+		return fallback
+	}
+	return PreferredLocales(savedLocale)
+}
+
+// RequestLocales mengembalikan urutan locale request dari middleware, atau dari header Accept-Language
+func RequestLocales(c echo.Context) []string {
+	if locales, ok := c.Get(LocalesContextKey).([]string); ok {
+		return locales
+	}
+	return PreferredLocales(c.Request().Header.Get("Accept-Language"))
+}
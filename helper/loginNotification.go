@@ -6,7 +6,7 @@ import (
 	"strconv"
 )
 
-func SendLoginNotification(userEmail string, name string) error {
+func SendLoginNotification(locales []string, userEmail string, name string) error {
 	// Mengambil nilai dari environment variables
 	smtpServer := os.Getenv("SMTP_SERVER")
 	smtpPortStr := os.Getenv("SMTP_PORT")
@@ -16,14 +16,14 @@ func SendLoginNotification(userEmail string, name string) error {
 	// Konfigurasi pengiriman email
 	sender := smtpUsername
 	recipient := userEmail
-	subject := "Successful Login Notification"
+	subject := Translate(locales, MsgEmailLoginSubject)
 	emailBody := `
 	<!DOCTYPE html>
-<html lang="en">
+<html lang="` + MessageLocale(locales) + `">
 <head>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Login Notification</title>
+    <title>` + subject + `</title>
     <style>
         body {
             font-family: 'Arial', sans-serif;
@@ -97,18 +97,18 @@ func SendLoginNotification(userEmail string, name string) error {
 <body>
     <div class="container">
         <div class="header">
-            <h1>Login Successful</h1>
+            <h1>` + Translate(locales, MsgEmailLoginHeading) + `</h1>
         </div>
         <div class="logo">
             <img src="https://i.ibb.co/KXxHL9r/Logo-Destimate.png" alt="Destimate Logo">
         </div>
         <div class="message">
-            <p>Hello, <strong>` + name + `</strong>,</p>
-            <p>Your login was successful. If this wasn't you, please contact our support team immediately. Thank you.</p>
-            <p><strong>Support Team:</strong> <a href="mailto:hidestimate@gmail.com">hidestimate@gmail.com@gmail.com</a></p>
+            <p>` + Translate(locales, MsgEmailGreeting, "<strong>"+name+"</strong>") + `</p>
+            <p>` + Translate(locales, MsgEmailLoginBody) + `</p>
+            <p><strong>` + Translate(locales, MsgEmailSupportTeam) + `</strong> <a href="mailto:hidestimate@gmail.com">hidestimate@gmail.com@gmail.com</a></p>
         </div>
         <div class="footer">
-            <p>&copy; 2023 Destimate. All rights reserved. | <a href="https://destimate-dev.netlify.app/" target="_blank">Destimate</a></p>
+            <p>&copy; 2023 Destimate. ` + Translate(locales, MsgEmailRightsReserved) + ` | <a href="https://destimate-dev.netlify.app/" target="_blank">Destimate</a></p>
         </div>
     </div>
 </body>
@@ -0,0 +1,716 @@
+package helper
+
+// Kode pesan stabil yang dikirim ke client sebagai error_code, teksnya diambil dari messageCatalog
+const (
+	// Error API
+	MsgAccessDenied                    MessageCode = "ACCESS_DENIED"
+	MsgAccountArchived                 MessageCode = "ACCOUNT_ARCHIVED"
+	MsgAccountNotVerified              MessageCode = "ACCOUNT_NOT_VERIFIED"
+	MsgAdminEmailFailed                MessageCode = "ADMIN_EMAIL_FAILED"
+	MsgAdminEndpointForbidden          MessageCode = "ADMIN_ENDPOINT_FORBIDDEN"
+	MsgAdminNotFound                   MessageCode = "ADMIN_NOT_FOUND"
+	MsgAlsoBookedFetchFailed           MessageCode = "ALSO_BOOKED_FETCH_FAILED"
+	MsgAppleWalletUnavailable          MessageCode = "APPLE_WALLET_UNAVAILABLE"
+	MsgArchivedPromoNotFound           MessageCode = "ARCHIVED_PROMO_NOT_FOUND"
+	MsgArchivedTicketNotFound          MessageCode = "ARCHIVED_TICKET_NOT_FOUND"
+	MsgArchivedUserNotFound            MessageCode = "ARCHIVED_USER_NOT_FOUND"
+	MsgArchivedWisataNotFound          MessageCode = "ARCHIVED_WISATA_NOT_FOUND"
+	MsgAuthTokenMissing                MessageCode = "AUTH_TOKEN_MISSING"
+	MsgAvailableTicketsPositive        MessageCode = "AVAILABLE_TICKETS_POSITIVE"
+	MsgAvailableTicketsUpdateFailed    MessageCode = "AVAILABLE_TICKETS_UPDATE_FAILED"
+	MsgCalendarBuildFailed             MessageCode = "CALENDAR_BUILD_FAILED"
+	MsgCalendarFeedFailed              MessageCode = "CALENDAR_FEED_FAILED"
+	MsgCalendarFeedNotFound            MessageCode = "CALENDAR_FEED_NOT_FOUND"
+	MsgCarbonFootprintFailed           MessageCode = "CARBON_FOOTPRINT_FAILED"
+	MsgCarbonOffsetsFetchFailed        MessageCode = "CARBON_OFFSETS_FETCH_FAILED"
+	MsgCarbonOffsetCancelFailed        MessageCode = "CARBON_OFFSET_CANCEL_FAILED"
+	MsgCarbonOffsetCreateFailed        MessageCode = "CARBON_OFFSET_CREATE_FAILED"
+	MsgCarbonOffsetPriceUpdateFailed   MessageCode = "CARBON_OFFSET_PRICE_UPDATE_FAILED"
+	MsgCarbonOffsetStatusUpdateFailed  MessageCode = "CARBON_OFFSET_STATUS_UPDATE_FAILED"
+	MsgCarbonOffsetSummaryFailed       MessageCode = "CARBON_OFFSET_SUMMARY_FAILED"
+	MsgCategoryCreateFailed            MessageCode = "CATEGORY_CREATE_FAILED"
+	MsgCategoryDeleteFailed            MessageCode = "CATEGORY_DELETE_FAILED"
+	MsgCategoryFetchFailed             MessageCode = "CATEGORY_FETCH_FAILED"
+	MsgCategoryIconInvalid             MessageCode = "CATEGORY_ICON_INVALID"
+	MsgCategoryMergeFailed             MessageCode = "CATEGORY_MERGE_FAILED"
+	MsgCategoryMergeTargetInvalid      MessageCode = "CATEGORY_MERGE_TARGET_INVALID"
+	MsgCategoryNameExists              MessageCode = "CATEGORY_NAME_EXISTS"
+	MsgCategoryNameTooLong             MessageCode = "CATEGORY_NAME_TOO_LONG"
+	MsgCategoryNameTooShort            MessageCode = "CATEGORY_NAME_TOO_SHORT"
+	MsgCategoryNotFound                MessageCode = "CATEGORY_NOT_FOUND"
+	MsgCategoryParentInvalid           MessageCode = "CATEGORY_PARENT_INVALID"
+	MsgCategoryThemeInvalid            MessageCode = "CATEGORY_THEME_INVALID"
+	MsgCategoryUpdateFailed            MessageCode = "CATEGORY_UPDATE_FAILED"
+	MsgCheckinDatePast                 MessageCode = "CHECKIN_DATE_PAST"
+	MsgCitiesFetchFailed               MessageCode = "CITIES_FETCH_FAILED"
+	MsgCityRequired                    MessageCode = "CITY_REQUIRED"
+	MsgCityTooLong                     MessageCode = "CITY_TOO_LONG"
+	MsgCityTooShort                    MessageCode = "CITY_TOO_SHORT"
+	MsgClosureDeleteFailed             MessageCode = "CLOSURE_DELETE_FAILED"
+	MsgClosureNotFound                 MessageCode = "CLOSURE_NOT_FOUND"
+	MsgClosureSaveFailed               MessageCode = "CLOSURE_SAVE_FAILED"
+	MsgCodeExists                      MessageCode = "CODE_EXISTS"
+	MsgCodeTooLong                     MessageCode = "CODE_TOO_LONG"
+	MsgCodeTooShort                    MessageCode = "CODE_TOO_SHORT"
+	MsgCoverNotPhoto                   MessageCode = "COVER_NOT_PHOTO"
+	MsgCrowdForecastFailed             MessageCode = "CROWD_FORECAST_FAILED"
+	MsgCurrentPasswordIncorrect        MessageCode = "CURRENT_PASSWORD_INCORRECT"
+	MsgDashboardMonthlyRevenueFailed   MessageCode = "DASHBOARD_MONTHLY_REVENUE_FAILED"
+	MsgDashboardOrdersFailed           MessageCode = "DASHBOARD_ORDERS_FAILED"
+	MsgDashboardTotalRevenueFailed     MessageCode = "DASHBOARD_TOTAL_REVENUE_FAILED"
+	MsgDashboardTotalUsersFailed       MessageCode = "DASHBOARD_TOTAL_USERS_FAILED"
+	MsgDashboardVisitorsFailed         MessageCode = "DASHBOARD_VISITORS_FAILED"
+	MsgDashboardWisataFailed           MessageCode = "DASHBOARD_WISATA_FAILED"
+	MsgDefaultLocaleTranslation        MessageCode = "DEFAULT_LOCALE_TRANSLATION"
+	MsgDescriptionIsOpenRequired       MessageCode = "DESCRIPTION_IS_OPEN_REQUIRED"
+	MsgDescriptionIsOpenTooLong        MessageCode = "DESCRIPTION_IS_OPEN_TOO_LONG"
+	MsgDescriptionIsOpenTooShort       MessageCode = "DESCRIPTION_IS_OPEN_TOO_SHORT"
+	MsgDescriptionRequired             MessageCode = "DESCRIPTION_REQUIRED"
+	MsgDescriptionTooLong              MessageCode = "DESCRIPTION_TOO_LONG"
+	MsgDescriptionTooShort             MessageCode = "DESCRIPTION_TOO_SHORT"
+	MsgDiscountPercentPositive         MessageCode = "DISCOUNT_PERCENT_POSITIVE"
+	MsgDiscountPercentTooHigh          MessageCode = "DISCOUNT_PERCENT_TOO_HIGH"
+	MsgDistanceSortNeedsLocation       MessageCode = "DISTANCE_SORT_NEEDS_LOCATION"
+	MsgEcoBadgeAwardFailed             MessageCode = "ECO_BADGE_AWARD_FAILED"
+	MsgEmailCheckFailed                MessageCode = "EMAIL_CHECK_FAILED"
+	MsgEmailExists                     MessageCode = "EMAIL_EXISTS"
+	MsgEventDataFetchFailed            MessageCode = "EVENT_DATA_FETCH_FAILED"
+	MsgExpiryDatePast                  MessageCode = "EXPIRY_DATE_PAST"
+	MsgFacetsFailed                    MessageCode = "FACETS_FAILED"
+	MsgFacilitiesFetchFailed           MessageCode = "FACILITIES_FETCH_FAILED"
+	MsgFacilitiesRequired              MessageCode = "FACILITIES_REQUIRED"
+	MsgFacilityCreateFailed            MessageCode = "FACILITY_CREATE_FAILED"
+	MsgFacilityDeleteFailed            MessageCode = "FACILITY_DELETE_FAILED"
+	MsgFacilityExists                  MessageCode = "FACILITY_EXISTS"
+	MsgFacilityNameInvalid             MessageCode = "FACILITY_NAME_INVALID"
+	MsgFacilityNotFound                MessageCode = "FACILITY_NOT_FOUND"
+	MsgFacilityUpdateFailed            MessageCode = "FACILITY_UPDATE_FAILED"
+	MsgFavoritesFetchFailed            MessageCode = "FAVORITES_FETCH_FAILED"
+	MsgFavoriteAddFailed               MessageCode = "FAVORITE_ADD_FAILED"
+	MsgFavoriteExists                  MessageCode = "FAVORITE_EXISTS"
+	MsgFavoriteNotFound                MessageCode = "FAVORITE_NOT_FOUND"
+	MsgFavoriteRemoveFailed            MessageCode = "FAVORITE_REMOVE_FAILED"
+	MsgFileTooLarge                    MessageCode = "FILE_TOO_LARGE"
+	MsgFirstNameTooShort               MessageCode = "FIRST_NAME_TOO_SHORT"
+	MsgFormParseFailed                 MessageCode = "FORM_PARSE_FAILED"
+	MsgGoogleTokenExchangeFailed       MessageCode = "GOOGLE_TOKEN_EXCHANGE_FAILED"
+	MsgGoogleUserInfoFailed            MessageCode = "GOOGLE_USER_INFO_FAILED"
+	MsgGoogleWalletUnavailable         MessageCode = "GOOGLE_WALLET_UNAVAILABLE"
+	MsgHolidaysFetchFailed             MessageCode = "HOLIDAYS_FETCH_FAILED"
+	MsgHolidayDeleteFailed             MessageCode = "HOLIDAY_DELETE_FAILED"
+	MsgHolidayNameRequired             MessageCode = "HOLIDAY_NAME_REQUIRED"
+	MsgHolidayNotFound                 MessageCode = "HOLIDAY_NOT_FOUND"
+	MsgHolidaySaveFailed               MessageCode = "HOLIDAY_SAVE_FAILED"
+	MsgImageFileRequired               MessageCode = "IMAGE_FILE_REQUIRED"
+	MsgImageOpenFailed                 MessageCode = "IMAGE_OPEN_FAILED"
+	MsgImageReadFailed                 MessageCode = "IMAGE_READ_FAILED"
+	MsgImageRequired                   MessageCode = "IMAGE_REQUIRED"
+	MsgImageUploadFailed               MessageCode = "IMAGE_UPLOAD_FAILED"
+	MsgInternalError                   MessageCode = "INTERNAL_ERROR"
+	MsgInvalidAvailableTickets         MessageCode = "INVALID_AVAILABLE_TICKETS"
+	MsgInvalidBbox                     MessageCode = "INVALID_BBOX"
+	MsgInvalidCategoryID               MessageCode = "INVALID_CATEGORY_ID"
+	MsgInvalidCheckinDate              MessageCode = "INVALID_CHECKIN_DATE"
+	MsgInvalidCloseTime                MessageCode = "INVALID_CLOSE_TIME"
+	MsgInvalidClosureID                MessageCode = "INVALID_CLOSURE_ID"
+	MsgInvalidDateFormat               MessageCode = "INVALID_DATE_FORMAT"
+	MsgInvalidDays                     MessageCode = "INVALID_DAYS"
+	MsgInvalidDayOfWeek                MessageCode = "INVALID_DAY_OF_WEEK"
+	MsgInvalidDiscountPercent          MessageCode = "INVALID_DISCOUNT_PERCENT"
+	MsgInvalidEmailFormat              MessageCode = "INVALID_EMAIL_FORMAT"
+	MsgInvalidEndDate                  MessageCode = "INVALID_END_DATE"
+	MsgInvalidExpiryDateFormat         MessageCode = "INVALID_EXPIRY_DATE_FORMAT"
+	MsgInvalidExportFormat             MessageCode = "INVALID_EXPORT_FORMAT"
+	MsgInvalidFilter                   MessageCode = "INVALID_FILTER"
+	MsgInvalidFromDate                 MessageCode = "INVALID_FROM_DATE"
+	MsgInvalidID                       MessageCode = "INVALID_ID"
+	MsgInvalidImageFileType            MessageCode = "INVALID_IMAGE_FILE_TYPE"
+	MsgInvalidImageFormat              MessageCode = "INVALID_IMAGE_FORMAT"
+	MsgInvalidIsOpen                   MessageCode = "INVALID_IS_OPEN"
+	MsgInvalidK                        MessageCode = "INVALID_K"
+	MsgInvalidLatitude                 MessageCode = "INVALID_LATITUDE"
+	MsgInvalidLocale                   MessageCode = "INVALID_LOCALE"
+	MsgInvalidLongitude                MessageCode = "INVALID_LONGITUDE"
+	MsgInvalidMediaOrder               MessageCode = "INVALID_MEDIA_ORDER"
+	MsgInvalidMediaType                MessageCode = "INVALID_MEDIA_TYPE"
+	MsgInvalidNear                     MessageCode = "INVALID_NEAR"
+	MsgInvalidOpenTime                 MessageCode = "INVALID_OPEN_TIME"
+	MsgInvalidPassword                 MessageCode = "INVALID_PASSWORD"
+	MsgInvalidPhoneFormat              MessageCode = "INVALID_PHONE_FORMAT"
+	MsgInvalidPhoneNumber              MessageCode = "INVALID_PHONE_NUMBER"
+	MsgInvalidPrice                    MessageCode = "INVALID_PRICE"
+	MsgInvalidPromoID                  MessageCode = "INVALID_PROMO_ID"
+	MsgInvalidRadius                   MessageCode = "INVALID_RADIUS"
+	MsgInvalidRating                   MessageCode = "INVALID_RATING"
+	MsgInvalidRequestBody              MessageCode = "INVALID_REQUEST_BODY"
+	MsgInvalidReviewID                 MessageCode = "INVALID_REVIEW_ID"
+	MsgInvalidStartDate                MessageCode = "INVALID_START_DATE"
+	MsgInvalidStatusAktif              MessageCode = "INVALID_STATUS_AKTIF"
+	MsgInvalidTimeZone                 MessageCode = "INVALID_TIME_ZONE"
+	MsgInvalidToken                    MessageCode = "INVALID_TOKEN"
+	MsgInvalidTokenFormat              MessageCode = "INVALID_TOKEN_FORMAT"
+	MsgInvalidTranslationType          MessageCode = "INVALID_TRANSLATION_TYPE"
+	MsgInvalidTransportMode            MessageCode = "INVALID_TRANSPORT_MODE"
+	MsgInvalidUsername                 MessageCode = "INVALID_USERNAME"
+	MsgInvalidUserID                   MessageCode = "INVALID_USER_ID"
+	MsgInvalidVoucherCode              MessageCode = "INVALID_VOUCHER_CODE"
+	MsgInvalidWisataID                 MessageCode = "INVALID_WISATA_ID"
+	MsgInvalidYear                     MessageCode = "INVALID_YEAR"
+	MsgIsOpenRequired                  MessageCode = "IS_OPEN_REQUIRED"
+	MsgItinerariesFetchFailed          MessageCode = "ITINERARIES_FETCH_FAILED"
+	MsgItineraryCreateFailed           MessageCode = "ITINERARY_CREATE_FAILED"
+	MsgItineraryDeleteFailed           MessageCode = "ITINERARY_DELETE_FAILED"
+	MsgItineraryNotFound               MessageCode = "ITINERARY_NOT_FOUND"
+	MsgItineraryUpdateFailed           MessageCode = "ITINERARY_UPDATE_FAILED"
+	MsgLeaderboardFailed               MessageCode = "LEADERBOARD_FAILED"
+	MsgLeaderboardPrivacyUpdateFailed  MessageCode = "LEADERBOARD_PRIVACY_UPDATE_FAILED"
+	MsgLocationRequired                MessageCode = "LOCATION_REQUIRED"
+	MsgLocationTooLong                 MessageCode = "LOCATION_TOO_LONG"
+	MsgLocationTooShort                MessageCode = "LOCATION_TOO_SHORT"
+	MsgMapsLinkRequired                MessageCode = "MAPS_LINK_REQUIRED"
+	MsgMapsLinkTooLong                 MessageCode = "MAPS_LINK_TOO_LONG"
+	MsgMapsLinkTooShort                MessageCode = "MAPS_LINK_TOO_SHORT"
+	MsgMediaDeleteFailed               MessageCode = "MEDIA_DELETE_FAILED"
+	MsgMediaNotFound                   MessageCode = "MEDIA_NOT_FOUND"
+	MsgMediaReorderFailed              MessageCode = "MEDIA_REORDER_FAILED"
+	MsgMediaSaveFailed                 MessageCode = "MEDIA_SAVE_FAILED"
+	MsgMediaUpdateFailed               MessageCode = "MEDIA_UPDATE_FAILED"
+	MsgMessageTooShort                 MessageCode = "MESSAGE_TOO_SHORT"
+	MsgNameMin3                        MessageCode = "NAME_MIN_3"
+	MsgNameMin5                        MessageCode = "NAME_MIN_5"
+	MsgNameTooLong                     MessageCode = "NAME_TOO_LONG"
+	MsgNewPasswordConfirmationMismatch MessageCode = "NEW_PASSWORD_CONFIRMATION_MISMATCH"
+	MsgNewPasswordSame                 MessageCode = "NEW_PASSWORD_SAME"
+	MsgNotificationNotFound            MessageCode = "NOTIFICATION_NOT_FOUND"
+	MsgNotAdmin                        MessageCode = "NOT_ADMIN"
+	MsgOpeningHoursUpdateFailed        MessageCode = "OPENING_HOURS_UPDATE_FAILED"
+	MsgPasswordConfirmationMismatch    MessageCode = "PASSWORD_CONFIRMATION_MISMATCH"
+	MsgPasswordHashFailed              MessageCode = "PASSWORD_HASH_FAILED"
+	MsgPasswordRequired                MessageCode = "PASSWORD_REQUIRED"
+	MsgPhoneCheckFailed                MessageCode = "PHONE_CHECK_FAILED"
+	MsgPhoneExists                     MessageCode = "PHONE_EXISTS"
+	MsgPhoneNotNumeric                 MessageCode = "PHONE_NOT_NUMERIC"
+	MsgPhoneTooShort                   MessageCode = "PHONE_TOO_SHORT"
+	MsgPhotoDeleteForbidden            MessageCode = "PHOTO_DELETE_FORBIDDEN"
+	MsgPointsHistoryFetchFailed        MessageCode = "POINTS_HISTORY_FETCH_FAILED"
+	MsgPointsNotEnough                 MessageCode = "POINTS_NOT_ENOUGH"
+	MsgPricePerTonnePositive           MessageCode = "PRICE_PER_TONNE_POSITIVE"
+	MsgPricePositive                   MessageCode = "PRICE_POSITIVE"
+	MsgProfilePhotoDeleteFailed        MessageCode = "PROFILE_PHOTO_DELETE_FAILED"
+	MsgPromoCreateFailed               MessageCode = "PROMO_CREATE_FAILED"
+	MsgPromoNameExists                 MessageCode = "PROMO_NAME_EXISTS"
+	MsgPromoNameTooLong                MessageCode = "PROMO_NAME_TOO_LONG"
+	MsgPromoNameTooShort               MessageCode = "PROMO_NAME_TOO_SHORT"
+	MsgPromoNotFound                   MessageCode = "PROMO_NOT_FOUND"
+	MsgPromoTitleExists                MessageCode = "PROMO_TITLE_EXISTS"
+	MsgPromoUpdateFailed               MessageCode = "PROMO_UPDATE_FAILED"
+	MsgPromoVoucherExists              MessageCode = "PROMO_VOUCHER_EXISTS"
+	MsgQuantityPositive                MessageCode = "QUANTITY_POSITIVE"
+	MsgQuizBudgetInvalid               MessageCode = "QUIZ_BUDGET_INVALID"
+	MsgQuizSaveFailed                  MessageCode = "QUIZ_SAVE_FAILED"
+	MsgQuizTravelStyleInvalid          MessageCode = "QUIZ_TRAVEL_STYLE_INVALID"
+	MsgRecommendationsFetchFailed      MessageCode = "RECOMMENDATIONS_FETCH_FAILED"
+	MsgReferralCodeGenerateFailed      MessageCode = "REFERRAL_CODE_GENERATE_FAILED"
+	MsgReferralRewardFailed            MessageCode = "REFERRAL_REWARD_FAILED"
+	MsgReferralSaveFailed              MessageCode = "REFERRAL_SAVE_FAILED"
+	MsgRestoreFailed                   MessageCode = "RESTORE_FAILED"
+	MsgReviewModerateFailed            MessageCode = "REVIEW_MODERATE_FAILED"
+	MsgReviewNotAllowed                MessageCode = "REVIEW_NOT_ALLOWED"
+	MsgReviewNotFound                  MessageCode = "REVIEW_NOT_FOUND"
+	MsgReviewSaveFailed                MessageCode = "REVIEW_SAVE_FAILED"
+	MsgRulesTooLong                    MessageCode = "RULES_TOO_LONG"
+	MsgRulesTooShort                   MessageCode = "RULES_TOO_SHORT"
+	MsgShareLinkFailed                 MessageCode = "SHARE_LINK_FAILED"
+	MsgShareLinkRevokeFailed           MessageCode = "SHARE_LINK_REVOKE_FAILED"
+	MsgSimilarWisataFetchFailed        MessageCode = "SIMILAR_WISATA_FETCH_FAILED"
+	MsgTermConditionExists             MessageCode = "TERM_CONDITION_EXISTS"
+	MsgTermConditionNotFound           MessageCode = "TERM_CONDITION_NOT_FOUND"
+	MsgTicketsFetchFailed              MessageCode = "TICKETS_FETCH_FAILED"
+	MsgTicketsNotEnough                MessageCode = "TICKETS_NOT_ENOUGH"
+	MsgTicketAlreadyCanceled           MessageCode = "TICKET_ALREADY_CANCELED"
+	MsgTicketCanceledPaidStatus        MessageCode = "TICKET_CANCELED_PAID_STATUS"
+	MsgTicketCancelFailed              MessageCode = "TICKET_CANCEL_FAILED"
+	MsgTicketCreateFailed              MessageCode = "TICKET_CREATE_FAILED"
+	MsgTicketNotCancellable            MessageCode = "TICKET_NOT_CANCELLABLE"
+	MsgTicketNotFound                  MessageCode = "TICKET_NOT_FOUND"
+	MsgTicketStatusUpdateFailed        MessageCode = "TICKET_STATUS_UPDATE_FAILED"
+	MsgTitleExists                     MessageCode = "TITLE_EXISTS"
+	MsgTitleMin5                       MessageCode = "TITLE_MIN_5"
+	MsgTitleMin8                       MessageCode = "TITLE_MIN_8"
+	MsgTitleRequired                   MessageCode = "TITLE_REQUIRED"
+	MsgTitleTooLong                    MessageCode = "TITLE_TOO_LONG"
+	MsgTokenGenerateFailed             MessageCode = "TOKEN_GENERATE_FAILED"
+	MsgTokenParseFailed                MessageCode = "TOKEN_PARSE_FAILED"
+	MsgTopReferrersFetchFailed         MessageCode = "TOP_REFERRERS_FETCH_FAILED"
+	MsgTotalCarbonFootprintFailed      MessageCode = "TOTAL_CARBON_FOOTPRINT_FAILED"
+	MsgTotalCarbonOffsetFailed         MessageCode = "TOTAL_CARBON_OFFSET_FAILED"
+	MsgTransactionHistoryFetchFailed   MessageCode = "TRANSACTION_HISTORY_FETCH_FAILED"
+	MsgTranslationsFetchFailed         MessageCode = "TRANSLATIONS_FETCH_FAILED"
+	MsgTranslationDeleteFailed         MessageCode = "TRANSLATION_DELETE_FAILED"
+	MsgTranslationNotFound             MessageCode = "TRANSLATION_NOT_FOUND"
+	MsgTranslationSaveFailed           MessageCode = "TRANSLATION_SAVE_FAILED"
+	MsgUsernameCheckFailed             MessageCode = "USERNAME_CHECK_FAILED"
+	MsgUsernameExists                  MessageCode = "USERNAME_EXISTS"
+	MsgUsernameRequired                MessageCode = "USERNAME_REQUIRED"
+	MsgUsernameTooShort                MessageCode = "USERNAME_TOO_SHORT"
+	MsgUserCreateFailed                MessageCode = "USER_CREATE_FAILED"
+	MsgUserEditForbidden               MessageCode = "USER_EDIT_FORBIDDEN"
+	MsgUserEmailFailed                 MessageCode = "USER_EMAIL_FAILED"
+	MsgUserFetchFailed                 MessageCode = "USER_FETCH_FAILED"
+	MsgUserLocationUpdateFailed        MessageCode = "USER_LOCATION_UPDATE_FAILED"
+	MsgUserNotFound                    MessageCode = "USER_NOT_FOUND"
+	MsgUserPointsUpdateFailed          MessageCode = "USER_POINTS_UPDATE_FAILED"
+	MsgUserTicketsFetchFailed          MessageCode = "USER_TICKETS_FETCH_FAILED"
+	MsgUserUpdateFailed                MessageCode = "USER_UPDATE_FAILED"
+	MsgVideoURLRequired                MessageCode = "VIDEO_URL_REQUIRED"
+	MsgVoucherAlreadyUsed              MessageCode = "VOUCHER_ALREADY_USED"
+	MsgVoucherCodeTooLong              MessageCode = "VOUCHER_CODE_TOO_LONG"
+	MsgVoucherCodeTooShort             MessageCode = "VOUCHER_CODE_TOO_SHORT"
+	MsgVoucherExpired                  MessageCode = "VOUCHER_EXPIRED"
+	MsgVoucherInactive                 MessageCode = "VOUCHER_INACTIVE"
+	MsgWalletPassFailed                MessageCode = "WALLET_PASS_FAILED"
+	MsgWalletTicketUnpaid              MessageCode = "WALLET_TICKET_UNPAID"
+	MsgWeakNewPassword                 MessageCode = "WEAK_NEW_PASSWORD"
+	MsgWeakPassword                    MessageCode = "WEAK_PASSWORD"
+	MsgWelcomeEmailFailed              MessageCode = "WELCOME_EMAIL_FAILED"
+	MsgWisataClosedOnDate              MessageCode = "WISATA_CLOSED_ON_DATE"
+	MsgWisataDeleteFailed              MessageCode = "WISATA_DELETE_FAILED"
+	MsgWisataFetchFailed               MessageCode = "WISATA_FETCH_FAILED"
+	MsgWisataIDRequired                MessageCode = "WISATA_ID_REQUIRED"
+	MsgWisataNotFound                  MessageCode = "WISATA_NOT_FOUND"
+	MsgWisataSaveFailed                MessageCode = "WISATA_SAVE_FAILED"
+
+	// Error API dengan argumen
+	MsgCategoryInUse             MessageCode = "CATEGORY_IN_USE"
+	MsgFieldNotString            MessageCode = "FIELD_NOT_STRING"
+	MsgInvalidLeaderboardPeriod  MessageCode = "INVALID_LEADERBOARD_PERIOD"
+	MsgInvalidStartTime          MessageCode = "INVALID_START_TIME"
+	MsgItineraryDaysRange        MessageCode = "ITINERARY_DAYS_RANGE"
+	MsgItineraryNameInvalid      MessageCode = "ITINERARY_NAME_INVALID"
+	MsgItineraryStopDayRange     MessageCode = "ITINERARY_STOP_DAY_RANGE"
+	MsgItineraryTicketNotFound   MessageCode = "ITINERARY_TICKET_NOT_FOUND"
+	MsgItineraryTooManyStops     MessageCode = "ITINERARY_TOO_MANY_STOPS"
+	MsgLocaleUpdateFailed        MessageCode = "LOCALE_UPDATE_FAILED"
+	MsgNegativeDuration          MessageCode = "NEGATIVE_DURATION"
+	MsgQuizInterestRange         MessageCode = "QUIZ_INTEREST_RANGE"
+	MsgReferralCodeInvalid       MessageCode = "REFERRAL_CODE_INVALID"
+	MsgReferralDeviceLimit       MessageCode = "REFERRAL_DEVICE_LIMIT"
+	MsgReferralEmailLimit        MessageCode = "REFERRAL_EMAIL_LIMIT"
+	MsgReferralPhoneLimit        MessageCode = "REFERRAL_PHONE_LIMIT"
+	MsgReferrerLimit             MessageCode = "REFERRER_LIMIT"
+	MsgReviewTooManyPhotos       MessageCode = "REVIEW_TOO_MANY_PHOTOS"
+	MsgSelfReferral              MessageCode = "SELF_REFERRAL"
+	MsgTranslationFieldsRequired MessageCode = "TRANSLATION_FIELDS_REQUIRED"
+
+	// Notifikasi
+	MsgNotificationBackInStock         MessageCode = "NOTIFICATION_BACK_IN_STOCK"
+	MsgNotificationBackInStockTitle    MessageCode = "NOTIFICATION_BACK_IN_STOCK_TITLE"
+	MsgNotificationBadge               MessageCode = "NOTIFICATION_BADGE"
+	MsgNotificationBadgeTitle          MessageCode = "NOTIFICATION_BADGE_TITLE"
+	MsgNotificationFavoritePromo       MessageCode = "NOTIFICATION_FAVORITE_PROMO"
+	MsgNotificationPaymentSuccess      MessageCode = "NOTIFICATION_PAYMENT_SUCCESS"
+	MsgNotificationPaymentSuccessTitle MessageCode = "NOTIFICATION_PAYMENT_SUCCESS_TITLE"
+	MsgNotificationPriceDrop           MessageCode = "NOTIFICATION_PRICE_DROP"
+	MsgNotificationPriceDropTitle      MessageCode = "NOTIFICATION_PRICE_DROP_TITLE"
+	MsgNotificationPromoTitle          MessageCode = "NOTIFICATION_PROMO_TITLE"
+	MsgNotificationReferralPoints      MessageCode = "NOTIFICATION_REFERRAL_POINTS"
+	MsgNotificationReferralTitle       MessageCode = "NOTIFICATION_REFERRAL_TITLE"
+	MsgNotificationReferralVoucher     MessageCode = "NOTIFICATION_REFERRAL_VOUCHER"
+
+	// Email
+	MsgEmailCooperationAdminSubject MessageCode = "EMAIL_COOPERATION_ADMIN_SUBJECT"
+	MsgEmailCooperationReply        MessageCode = "EMAIL_COOPERATION_REPLY"
+	MsgEmailCooperationThanks       MessageCode = "EMAIL_COOPERATION_THANKS"
+	MsgEmailCooperationUserSubject  MessageCode = "EMAIL_COOPERATION_USER_SUBJECT"
+	MsgEmailCooperationYourMessage  MessageCode = "EMAIL_COOPERATION_YOUR_MESSAGE"
+	MsgEmailGreeting                MessageCode = "EMAIL_GREETING"
+	MsgEmailInvoiceCarbon           MessageCode = "EMAIL_INVOICE_CARBON"
+	MsgEmailInvoiceCarbonValue      MessageCode = "EMAIL_INVOICE_CARBON_VALUE"
+	MsgEmailInvoiceCheckin          MessageCode = "EMAIL_INVOICE_CHECKIN"
+	MsgEmailInvoiceDestination      MessageCode = "EMAIL_INVOICE_DESTINATION"
+	MsgEmailInvoiceHeading          MessageCode = "EMAIL_INVOICE_HEADING"
+	MsgEmailInvoiceNumber           MessageCode = "EMAIL_INVOICE_NUMBER"
+	MsgEmailInvoicePointsEarned     MessageCode = "EMAIL_INVOICE_POINTS_EARNED"
+	MsgEmailInvoicePointsUsed       MessageCode = "EMAIL_INVOICE_POINTS_USED"
+	MsgEmailInvoiceQuantity         MessageCode = "EMAIL_INVOICE_QUANTITY"
+	MsgEmailInvoiceSubject          MessageCode = "EMAIL_INVOICE_SUBJECT"
+	MsgEmailInvoiceThanks           MessageCode = "EMAIL_INVOICE_THANKS"
+	MsgEmailInvoiceTotal            MessageCode = "EMAIL_INVOICE_TOTAL"
+	MsgEmailInvoiceVoucher          MessageCode = "EMAIL_INVOICE_VOUCHER"
+	MsgEmailLabelEmail              MessageCode = "EMAIL_LABEL_EMAIL"
+	MsgEmailLabelMessage            MessageCode = "EMAIL_LABEL_MESSAGE"
+	MsgEmailLabelName               MessageCode = "EMAIL_LABEL_NAME"
+	MsgEmailLabelPhone              MessageCode = "EMAIL_LABEL_PHONE"
+	MsgEmailLoginBody               MessageCode = "EMAIL_LOGIN_BODY"
+	MsgEmailLoginHeading            MessageCode = "EMAIL_LOGIN_HEADING"
+	MsgEmailLoginSubject            MessageCode = "EMAIL_LOGIN_SUBJECT"
+	MsgEmailRegards                 MessageCode = "EMAIL_REGARDS"
+	MsgEmailRightsReserved          MessageCode = "EMAIL_RIGHTS_RESERVED"
+	MsgEmailSupportTeam             MessageCode = "EMAIL_SUPPORT_TEAM"
+	MsgEmailVerifyButton            MessageCode = "EMAIL_VERIFY_BUTTON"
+	MsgEmailWelcomeBody             MessageCode = "EMAIL_WELCOME_BODY"
+	MsgEmailWelcomeHeading          MessageCode = "EMAIL_WELCOME_HEADING"
+	MsgEmailWelcomeHelp             MessageCode = "EMAIL_WELCOME_HELP"
+	MsgEmailWelcomeSubject          MessageCode = "EMAIL_WELCOME_SUBJECT"
+
+	// Kuis preferensi
+	MsgQuizBudgetQuestion      MessageCode = "QUIZ_BUDGET_QUESTION"
+	MsgQuizCultureQuestion     MessageCode = "QUIZ_CULTURE_QUESTION"
+	MsgQuizNatureQuestion      MessageCode = "QUIZ_NATURE_QUESTION"
+	MsgQuizTravelStyleQuestion MessageCode = "QUIZ_TRAVEL_STYLE_QUESTION"
+)
+
+// messageCatalog memetakan kode pesan ke teks per locale
+var messageCatalog = map[MessageCode]map[string]string{
+	MsgAccessDenied:                    {"id": "Akses ditolak", "en": "Access denied"},
+	MsgAccountArchived:                 {"id": "Akun telah diarsipkan. Hubungi admin untuk memulihkannya.", "en": "Account has been archived. Contact an admin to restore it."},
+	MsgAccountNotVerified:              {"id": "Akun belum terverifikasi. Silakan verifikasi email sebelum masuk.", "en": "Account not verified. Please verify your email before logging in."},
+	MsgAdminEmailFailed:                {"id": "Gagal mengirim email ke admin", "en": "Failed to send email to admin"},
+	MsgAdminEndpointForbidden:          {"id": "Akses ditolak. Admin tidak dapat menggunakan endpoint ini.", "en": "Access denied. Admin cannot use this endpoint."},
+	MsgAdminNotFound:                   {"id": "Admin tidak ditemukan", "en": "Admin not found"},
+	MsgAlsoBookedFetchFailed:           {"id": "Gagal mengambil wisata yang sering dipesan bersamaan", "en": "Failed to fetch also booked wisatas"},
+	MsgAppleWalletUnavailable:          {"id": "Apple Wallet belum tersedia", "en": "Apple Wallet is not available"},
+	MsgArchivedPromoNotFound:           {"id": "Promo terarsip tidak ditemukan", "en": "Archived promo not found"},
+	MsgArchivedTicketNotFound:          {"id": "Tiket terarsip tidak ditemukan", "en": "Archived ticket not found"},
+	MsgArchivedUserNotFound:            {"id": "Akun terarsip tidak ditemukan", "en": "Archived account not found"},
+	MsgArchivedWisataNotFound:          {"id": "Tempat wisata terarsip tidak ditemukan", "en": "Archived tourist attraction not found"},
+	MsgAuthTokenMissing:                {"id": "Token otorisasi tidak ditemukan", "en": "Authorization token is missing"},
+	MsgAvailableTicketsPositive:        {"id": "Available Tickets harus lebih dari 0", "en": "Available tickets must be greater than 0"},
+	MsgAvailableTicketsUpdateFailed:    {"id": "Gagal memperbarui jumlah tiket tersedia", "en": "Failed to update available tickets"},
+	MsgCalendarBuildFailed:             {"id": "Gagal membuat kalender", "en": "Failed to build calendar"},
+	MsgCalendarFeedFailed:              {"id": "Gagal membuat feed kalender", "en": "Failed to generate calendar feed"},
+	MsgCalendarFeedNotFound:            {"id": "Feed kalender tidak ditemukan", "en": "Calendar feed not found"},
+	MsgCarbonFootprintFailed:           {"id": "Gagal menghitung jejak karbon", "en": "Failed to calculate carbon footprint"},
+	MsgCarbonOffsetsFetchFailed:        {"id": "Gagal mengambil data offset karbon", "en": "Failed to fetch carbon offsets"},
+	MsgCarbonOffsetCancelFailed:        {"id": "Gagal membatalkan offset karbon", "en": "Failed to cancel carbon offset"},
+	MsgCarbonOffsetCreateFailed:        {"id": "Gagal membuat offset karbon", "en": "Failed to create carbon offset"},
+	MsgCarbonOffsetPriceUpdateFailed:   {"id": "Gagal memperbarui harga offset karbon", "en": "Failed to update carbon offset price"},
+	MsgCarbonOffsetStatusUpdateFailed:  {"id": "Gagal memperbarui status offset karbon", "en": "Failed to update carbon offset status"},
+	MsgCarbonOffsetSummaryFailed:       {"id": "Gagal merangkum offset karbon", "en": "Failed to summarize carbon offsets"},
+	MsgCategoryCreateFailed:            {"id": "Gagal membuat kategori", "en": "Failed to create category"},
+	MsgCategoryDeleteFailed:            {"id": "Gagal menghapus kategori", "en": "Failed to delete category"},
+	MsgCategoryFetchFailed:             {"id": "Gagal memuat data kategori", "en": "Failed to load category data"},
+	MsgCategoryIconInvalid:             {"id": "Ikon kategori harus berupa URL http atau https", "en": "Category icon must be an http or https URL"},
+	MsgCategoryMergeFailed:             {"id": "Gagal menggabungkan kategori", "en": "Failed to merge categories"},
+	MsgCategoryMergeTargetInvalid:      {"id": "Kategori tujuan harus berbeda dan bukan subkategori dari kategori yang digabungkan", "en": "Target category must be different and not a subcategory of the merged category"},
+	MsgCategoryNameExists:              {"id": "Kategori dengan nama ini sudah ada", "en": "Category with this name already exists"},
+	MsgCategoryNameTooLong:             {"id": "Nama kategori maksimal 30 karakter", "en": "Category name cannot exceed 30 characters"},
+	MsgCategoryNameTooShort:            {"id": "Nama kategori minimal 5 karakter", "en": "Category name must be at least 5 characters"},
+	MsgCategoryNotFound:                {"id": "Kategori tidak ditemukan", "en": "Category not found"},
+	MsgCategoryParentInvalid:           {"id": "Kategori induk tidak valid", "en": "Invalid parent category"},
+	MsgCategoryThemeInvalid:            {"id": "Tema kategori tidak valid", "en": "Invalid category theme"},
+	MsgCategoryUpdateFailed:            {"id": "Gagal memperbarui kategori", "en": "Failed to update category"},
+	MsgCheckinDatePast:                 {"id": "Tanggal check-in minimal hari ini", "en": "Checkin date must be today or later"},
+	MsgCitiesFetchFailed:               {"id": "Gagal mengambil daftar kota", "en": "Failed to fetch cities"},
+	MsgCityRequired:                    {"id": "Kota harus diisi", "en": "City is required"},
+	MsgCityTooLong:                     {"id": "Kota harus maksimal 30 karakter", "en": "City cannot exceed 30 characters"},
+	MsgCityTooShort:                    {"id": "Kota harus minimal 4 huruf", "en": "City must be at least 4 characters"},
+	MsgClosureDeleteFailed:             {"id": "Gagal menghapus jadwal tutup", "en": "Failed to delete closure"},
+	MsgClosureNotFound:                 {"id": "Jadwal tutup tidak ditemukan", "en": "Closure not found"},
+	MsgClosureSaveFailed:               {"id": "Gagal menyimpan jadwal tutup", "en": "Failed to save closure"},
+	MsgCodeExists:                      {"id": "Kode sudah digunakan", "en": "Code is already used"},
+	MsgCodeTooLong:                     {"id": "Kode harus maksimal 5 karakter", "en": "Code cannot exceed 5 characters"},
+	MsgCodeTooShort:                    {"id": "Kode harus minimal 3 huruf", "en": "Code must be at least 3 characters"},
+	MsgCoverNotPhoto:                   {"id": "Hanya foto yang dapat dijadikan sampul", "en": "Only photos can be used as cover"},
+	MsgCrowdForecastFailed:             {"id": "Gagal memperkirakan tingkat keramaian", "en": "Failed to forecast crowd level"},
+	MsgCurrentPasswordIncorrect:        {"id": "Password saat ini salah", "en": "Incorrect current password"},
+	MsgDashboardMonthlyRevenueFailed:   {"id": "Gagal dalam menghitung pendapatan bulanan", "en": "Failed to calculate monthly revenue"},
+	MsgDashboardOrdersFailed:           {"id": "Gagal dalam menghitung order penjualan", "en": "Failed to count sales orders"},
+	MsgDashboardTotalRevenueFailed:     {"id": "Gagal dalam menghitung total pendapatan", "en": "Failed to calculate total revenue"},
+	MsgDashboardTotalUsersFailed:       {"id": "Gagal dalam menghitung jumlah total user", "en": "Failed to count total users"},
+	MsgDashboardVisitorsFailed:         {"id": "Gagal dalam menghitung pengunjung", "en": "Failed to count visitors"},
+	MsgDashboardWisataFailed:           {"id": "Gagal dalam menghitung tempat wisata yang tersedia", "en": "Failed to count available tourism attractions"},
+	MsgDefaultLocaleTranslation:        {"id": "Konten locale bawaan diubah melalui endpoint utama", "en": "Default locale content is edited through the main endpoint"},
+	MsgDescriptionIsOpenRequired:       {"id": "Anda harus memasukan description_is_open, keterangan buka jam berapa sampai jam berapa", "en": "description_is_open is required, describe the opening hours"},
+	MsgDescriptionIsOpenTooLong:        {"id": "Description Is Open harus maksimal 40 karakter", "en": "Description is open cannot exceed 40 characters"},
+	MsgDescriptionIsOpenTooShort:       {"id": "Description Is Open harus minimal 5 huruf", "en": "Description is open must be at least 5 characters"},
+	MsgDescriptionRequired:             {"id": "Deskripsi harus diisi", "en": "Description is required"},
+	MsgDescriptionTooLong:              {"id": "Deskripsi harus maksimal 2000 karakter", "en": "Description cannot exceed 2000 characters"},
+	MsgDescriptionTooShort:             {"id": "Deskripsi harus minimal 10 huruf", "en": "Description must be at least 10 characters"},
+	MsgDiscountPercentPositive:         {"id": "jumlah_potongan_persen harus lebih dari 0", "en": "jumlah_potongan_persen must be greater than 0"},
+	MsgDiscountPercentTooHigh:          {"id": "jumlah_potongan_persen tidak boleh lebih dari 100", "en": "Invalid jumlah_potongan_persen. It must not exceed 100"},
+	MsgDistanceSortNeedsLocation:       {"id": "Urutan berdasarkan jarak membutuhkan parameter near atau lokasi pengguna yang tersimpan", "en": "Sorting by distance requires near parameter or a saved user location"},
+	MsgEcoBadgeAwardFailed:             {"id": "Gagal memberikan badge ramah lingkungan", "en": "Failed to award eco badges"},
+	MsgEmailCheckFailed:                {"id": "Gagal memeriksa email", "en": "Failed to check email"},
+	MsgEmailExists:                     {"id": "Email sudah terdaftar", "en": "Email already exists"},
+	MsgEventDataFetchFailed:            {"id": "Gagal mengambil data event", "en": "Failed to fetch event data"},
+	MsgExpiryDatePast:                  {"id": "tanggal_kadaluarsa harus tanggal yang akan datang", "en": "Invalid tanggal_kadaluarsa. It must be a future date"},
+	MsgFacetsFailed:                    {"id": "Gagal menghitung facet pencarian", "en": "Failed to calculate facets"},
+	MsgFacilitiesFetchFailed:           {"id": "Gagal mengambil data fasilitas", "en": "Failed to fetch facilities"},
+	MsgFacilitiesRequired:              {"id": "Fasilitas harus diisi minimal satu", "en": "At least one facility is required"},
+	MsgFacilityCreateFailed:            {"id": "Gagal membuat fasilitas", "en": "Failed to create facility"},
+	MsgFacilityDeleteFailed:            {"id": "Gagal menghapus fasilitas", "en": "Failed to delete facility"},
+	MsgFacilityExists:                  {"id": "Fasilitas sudah ada", "en": "Facility already exists"},
+	MsgFacilityNameInvalid:             {"id": "Nama fasilitas harus diisi dan maksimal 100 karakter", "en": "Facility name is required and cannot exceed 100 characters"},
+	MsgFacilityNotFound:                {"id": "Fasilitas tidak ditemukan", "en": "Facility not found"},
+	MsgFacilityUpdateFailed:            {"id": "Gagal memperbarui fasilitas", "en": "Failed to update facility"},
+	MsgFavoritesFetchFailed:            {"id": "Gagal mengambil daftar favorit", "en": "Failed to fetch favorites"},
+	MsgFavoriteAddFailed:               {"id": "Gagal menambahkan favorit", "en": "Failed to add favorite"},
+	MsgFavoriteExists:                  {"id": "Wisata sudah ada di daftar favorit", "en": "Wisata already in favorites"},
+	MsgFavoriteNotFound:                {"id": "Favorit tidak ditemukan", "en": "Favorite not found"},
+	MsgFavoriteRemoveFailed:            {"id": "Gagal menghapus favorit", "en": "Failed to remove favorite"},
+	MsgFileTooLarge:                    {"id": "Ukuran file melebihi batas yang diizinkan (5MB).", "en": "File size exceeds the allowed limit (5MB)."},
+	MsgFirstNameTooShort:               {"id": "Nama depan harus minimal 3 huruf", "en": "First name must be at least 3 characters"},
+	MsgFormParseFailed:                 {"id": "Gagal membaca data form", "en": "Failed to parse form data"},
+	MsgGoogleTokenExchangeFailed:       {"id": "Gagal menukar kode otorisasi Google dengan token", "en": "Failed to exchange code for token"},
+	MsgGoogleUserInfoFailed:            {"id": "Gagal mengambil informasi pengguna dari Google", "en": "Failed to get user info from Google"},
+	MsgGoogleWalletUnavailable:         {"id": "Google Wallet belum tersedia", "en": "Google Wallet is not available"},
+	MsgHolidaysFetchFailed:             {"id": "Gagal mengambil daftar hari libur", "en": "Failed to fetch holidays"},
+	MsgHolidayDeleteFailed:             {"id": "Gagal menghapus hari libur", "en": "Failed to delete holiday"},
+	MsgHolidayNameRequired:             {"id": "Nama hari libur harus diisi", "en": "Holiday name is required"},
+	MsgHolidayNotFound:                 {"id": "Hari libur tidak ditemukan", "en": "Holiday not found"},
+	MsgHolidaySaveFailed:               {"id": "Gagal menyimpan hari libur", "en": "Failed to save holiday"},
+	MsgImageFileRequired:               {"id": "File gambar diperlukan.", "en": "An image file is required."},
+	MsgImageOpenFailed:                 {"id": "Gagal membuka file gambar", "en": "Failed to open image file"},
+	MsgImageReadFailed:                 {"id": "Gagal membaca data gambar", "en": "Failed to read image data"},
+	MsgImageRequired:                   {"id": "Setidaknya satu gambar diperlukan.", "en": "At least one image is required."},
+	MsgImageUploadFailed:               {"id": "Gagal mengunggah gambar", "en": "Failed to upload image"},
+	MsgInternalError:                   {"id": "Terjadi kesalahan pada server", "en": "Internal Server Error"},
+	MsgInvalidAvailableTickets:         {"id": "Nilai available_tickets tidak valid", "en": "Invalid available_tickets value"},
+	MsgInvalidBbox:                     {"id": "Parameter bbox tidak valid, gunakan bbox=min_lat,min_long,max_lat,max_long", "en": "Invalid bbox parameter, use bbox=min_lat,min_long,max_lat,max_long"},
+	MsgInvalidCategoryID:               {"id": "ID kategori tidak valid", "en": "Invalid category ID"},
+	MsgInvalidCheckinDate:              {"id": "Format tanggal checkin_booking tidak valid", "en": "Invalid checkin_booking date format"},
+	MsgInvalidCloseTime:                {"id": "close_time tidak valid, gunakan HH:MM yang berbeda dari open_time", "en": "Invalid close_time, use HH:MM different from open_time"},
+	MsgInvalidClosureID:                {"id": "ID jadwal tutup tidak valid", "en": "Invalid closure ID"},
+	MsgInvalidDateFormat:               {"id": "Format tanggal tidak valid, gunakan YYYY-MM-DD", "en": "Invalid date format, use YYYY-MM-DD"},
+	MsgInvalidDays:                     {"id": "Parameter days tidak valid", "en": "Invalid days"},
+	MsgInvalidDayOfWeek:                {"id": "day_of_week harus antara 0 (Minggu) dan 6 (Sabtu)", "en": "day_of_week must be between 0 (Sunday) and 6 (Saturday)"},
+	MsgInvalidDiscountPercent:          {"id": "jumlah_potongan_persen tidak valid", "en": "Invalid jumlah_potongan_persen"},
+	MsgInvalidEmailFormat:              {"id": "Format email tidak valid", "en": "Invalid email format"},
+	MsgInvalidEndDate:                  {"id": "Kesalahan format end date. Gunakan YYYY-MM-DD", "en": "Invalid end date format. Use YYYY-MM-DD"},
+	MsgInvalidExpiryDateFormat:         {"id": "Format tanggal_kadaluarsa tidak valid. Gunakan YYYY-MM-DD", "en": "Invalid tanggal_kadaluarsa format. Use YYYY-MM-DD"},
+	MsgInvalidExportFormat:             {"id": "Format tidak valid. Gunakan json atau pdf", "en": "Invalid format. Use json or pdf"},
+	MsgInvalidFilter:                   {"id": "Parameter filter tidak valid", "en": "Invalid filter parameter"},
+	MsgInvalidFromDate:                 {"id": "Format tanggal from tidak valid, gunakan YYYY-MM-DD", "en": "Invalid from date format, use YYYY-MM-DD"},
+	MsgInvalidID:                       {"id": "ID tidak valid", "en": "Invalid ID"},
+	MsgInvalidImageFileType:            {"id": "Jenis file tidak valid. Hanya file gambar yang diperbolehkan.", "en": "Invalid file type. Only image files are allowed."},
+	MsgInvalidImageFormat:              {"id": "Format gambar tidak valid", "en": "Invalid image format"},
+	MsgInvalidIsOpen:                   {"id": "Nilai is_open tidak valid", "en": "Invalid is_open value"},
+	MsgInvalidK:                        {"id": "Parameter k tidak valid", "en": "Invalid k"},
+	MsgInvalidLatitude:                 {"id": "Nilai latitude tidak valid", "en": "Invalid latitude value"},
+	MsgInvalidLocale:                   {"id": "Locale tidak valid", "en": "Invalid locale"},
+	MsgInvalidLongitude:                {"id": "Nilai longitude tidak valid", "en": "Invalid longitude value"},
+	MsgInvalidMediaOrder:               {"id": "media_ids harus memuat setiap media wisata ini tepat satu kali", "en": "media_ids must list each media of this wisata exactly once"},
+	MsgInvalidMediaType:                {"id": "Jenis media tidak valid, gunakan photo atau video", "en": "Invalid media type, use photo or video"},
+	MsgInvalidNear:                     {"id": "Parameter near tidak valid, gunakan near=lat,long", "en": "Invalid near parameter, use near=lat,long"},
+	MsgInvalidOpenTime:                 {"id": "open_time tidak valid, gunakan HH:MM", "en": "Invalid open_time, use HH:MM"},
+	MsgInvalidPassword:                 {"id": "Password salah", "en": "Invalid password"},
+	MsgInvalidPhoneFormat:              {"id": "Format nomor telepon tidak valid", "en": "Invalid phone number format"},
+	MsgInvalidPhoneNumber:              {"id": "Nomor telepon tidak valid, minimal 10 digit dan hanya berisi angka", "en": "Invalid phone number must have minimum 10 digits of numbers only"},
+	MsgInvalidPrice:                    {"id": "Nilai harga tidak valid", "en": "Invalid price value"},
+	MsgInvalidPromoID:                  {"id": "ID promo tidak valid", "en": "Invalid promo ID"},
+	MsgInvalidRadius:                   {"id": "Parameter radius_km tidak valid", "en": "Invalid radius_km parameter"},
+	MsgInvalidRating:                   {"id": "Rating harus antara 1 dan 5", "en": "Rating must be between 1 and 5"},
+	MsgInvalidRequestBody:              {"id": "Body request tidak valid", "en": "Invalid request body"},
+	MsgInvalidReviewID:                 {"id": "ID ulasan tidak valid", "en": "Invalid review ID"},
+	MsgInvalidStartDate:                {"id": "Kesalahan format start date. Gunakan YYYY-MM-DD", "en": "Invalid start date format. Use YYYY-MM-DD"},
+	MsgInvalidStatusAktif:              {"id": "status_aktif tidak valid", "en": "Invalid status_aktif"},
+	MsgInvalidTimeZone:                 {"id": "time_zone tidak valid, gunakan nama IANA seperti Asia/Jakarta", "en": "Invalid time_zone, use an IANA name such as Asia/Jakarta"},
+	MsgInvalidToken:                    {"id": "Token tidak valid", "en": "Invalid token"},
+	MsgInvalidTokenFormat:              {"id": "Format token tidak valid", "en": "Invalid token format"},
+	MsgInvalidTranslationType:          {"id": "Jenis terjemahan tidak valid. Gunakan tourism-attractions, promos, categories atau terms-and-conditions", "en": "Invalid translation type. Use tourism-attractions, promos, categories or terms-and-conditions"},
+	MsgInvalidTransportMode:            {"id": "transport_mode tidak valid. Gunakan car, motorbike, bus, train, flight atau ferry", "en": "Invalid transport_mode. Use car, motorbike, bus, train, flight or ferry"},
+	MsgInvalidUsername:                 {"id": "Username tidak valid", "en": "Invalid username"},
+	MsgInvalidUserID:                   {"id": "ID pengguna tidak valid", "en": "Invalid user ID"},
+	MsgInvalidVoucherCode:              {"id": "Kode voucher tidak valid", "en": "Invalid kode voucher"},
+	MsgInvalidWisataID:                 {"id": "ID wisata tidak valid", "en": "Invalid wisata ID"},
+	MsgInvalidYear:                     {"id": "Tahun tidak valid", "en": "Invalid year"},
+	MsgIsOpenRequired:                  {"id": "Field 'is_open' harus diisi", "en": "Field 'is_open' is required"},
+	MsgItinerariesFetchFailed:          {"id": "Gagal mengambil daftar itinerary", "en": "Failed to fetch itineraries"},
+	MsgItineraryCreateFailed:           {"id": "Gagal membuat itinerary", "en": "Failed to create itinerary"},
+	MsgItineraryDeleteFailed:           {"id": "Gagal menghapus itinerary", "en": "Failed to delete itinerary"},
+	MsgItineraryNotFound:               {"id": "Itinerary tidak ditemukan", "en": "Itinerary not found"},
+	MsgItineraryUpdateFailed:           {"id": "Gagal memperbarui itinerary", "en": "Failed to update itinerary"},
+	MsgLeaderboardFailed:               {"id": "Gagal menghitung leaderboard", "en": "Failed to compute leaderboard"},
+	MsgLeaderboardPrivacyUpdateFailed:  {"id": "Gagal memperbarui privasi leaderboard", "en": "Failed to update leaderboard privacy"},
+	MsgLocationRequired:                {"id": "Lokasi harus diisi", "en": "Location is required"},
+	MsgLocationTooLong:                 {"id": "Lokasi harus maksimal 200 karakter", "en": "Location cannot exceed 200 characters"},
+	MsgLocationTooShort:                {"id": "Lokasi harus minimal 8 huruf", "en": "Location must be at least 8 characters"},
+	MsgMapsLinkRequired:                {"id": "Anda harus memasukan link maps_link", "en": "maps_link is required"},
+	MsgMapsLinkTooLong:                 {"id": "Link maps harus maksimal 200 karakter", "en": "Maps link cannot exceed 200 characters"},
+	MsgMapsLinkTooShort:                {"id": "Link maps harus minimal 5 huruf", "en": "Maps link must be at least 5 characters"},
+	MsgMediaDeleteFailed:               {"id": "Gagal menghapus media", "en": "Failed to delete media"},
+	MsgMediaNotFound:                   {"id": "Media tidak ditemukan", "en": "Media not found"},
+	MsgMediaReorderFailed:              {"id": "Gagal mengubah urutan media", "en": "Failed to reorder media"},
+	MsgMediaSaveFailed:                 {"id": "Gagal menyimpan media", "en": "Failed to save media"},
+	MsgMediaUpdateFailed:               {"id": "Gagal memperbarui media", "en": "Failed to update media"},
+	MsgMessageTooShort:                 {"id": "Pesan harus minimal 10 huruf", "en": "Message must be at least 10 characters"},
+	MsgNameMin3:                        {"id": "Nama minimal 3 karakter", "en": "Name must be at least 3 characters"},
+	MsgNameMin5:                        {"id": "Nama minimal 5 karakter", "en": "Name must be at least 5 characters"},
+	MsgNameTooLong:                     {"id": "Nama maksimal 100 karakter", "en": "Name cannot exceed 100 characters"},
+	MsgNewPasswordConfirmationMismatch: {"id": "Konfirmasi password tidak sama dengan password baru", "en": "Confirmation password does not match the new password"},
+	MsgNewPasswordSame:                 {"id": "Password baru harus berbeda dari password saat ini", "en": "New password must be different from the current password"},
+	MsgNotificationNotFound:            {"id": "Notifikasi tidak ditemukan", "en": "Notification not found"},
+	MsgNotAdmin:                        {"id": "Akses ditolak. Anda bukan admin.", "en": "Access denied. User is not an admin."},
+	MsgOpeningHoursUpdateFailed:        {"id": "Gagal memperbarui jam buka", "en": "Failed to update opening hours"},
+	MsgPasswordConfirmationMismatch:    {"id": "Konfirmasi password tidak sama", "en": "Password confirmation does not match"},
+	MsgPasswordHashFailed:              {"id": "Gagal memproses password", "en": "Failed to hash password"},
+	MsgPasswordRequired:                {"id": "Password harus diisi", "en": "Password is required"},
+	MsgPhoneCheckFailed:                {"id": "Gagal memeriksa nomor telepon", "en": "Failed to check phone number"},
+	MsgPhoneExists:                     {"id": "Nomor telepon sudah terdaftar", "en": "Phone number already exists"},
+	MsgPhoneNotNumeric:                 {"id": "Nomor telepon harus berisi angka saja", "en": "Phone number must contain digits only"},
+	MsgPhoneTooShort:                   {"id": "Nomor telepon minimal 10 digit", "en": "Phone number should be at least 10 digits"},
+	MsgPhotoDeleteForbidden:            {"id": "Tidak diizinkan menghapus foto pengguna ini", "en": "Unauthorized to delete photo for this user"},
+	MsgPointsHistoryFetchFailed:        {"id": "Gagal mengambil riwayat poin", "en": "Failed to fetch user's points history"},
+	MsgPointsNotEnough:                 {"id": "Poin tidak mencukupi", "en": "Not enough points to use"},
+	MsgPricePerTonnePositive:           {"id": "Harga per ton harus lebih dari 0", "en": "Price per tonne must be greater than 0"},
+	MsgPricePositive:                   {"id": "Harga harus lebih dari 0", "en": "Price must be greater than 0"},
+	MsgProfilePhotoDeleteFailed:        {"id": "Gagal menghapus foto profil", "en": "Failed to delete user profile photo"},
+	MsgPromoCreateFailed:               {"id": "Gagal membuat promo", "en": "Failed to create promo"},
+	MsgPromoNameExists:                 {"id": "Promo dengan nama_promo ini sudah ada", "en": "Promo with this nama_promo already exists"},
+	MsgPromoNameTooLong:                {"id": "Nama promo maksimal 100 karakter", "en": "Nama promo cannot exceed 100 characters"},
+	MsgPromoNameTooShort:               {"id": "Nama promo minimal 5 karakter", "en": "Nama promo must be at least 5 characters"},
+	MsgPromoNotFound:                   {"id": "Promo tidak ditemukan", "en": "Promo not found"},
+	MsgPromoTitleExists:                {"id": "Promo dengan judul ini sudah ada", "en": "Promo with this title already exists"},
+	MsgPromoUpdateFailed:               {"id": "Gagal memperbarui promo", "en": "Failed to update promo"},
+	MsgPromoVoucherExists:              {"id": "Promo dengan kode_voucher ini sudah ada", "en": "Promo with this kode_voucher already exists"},
+	MsgQuantityPositive:                {"id": "Jumlah tiket harus lebih dari 0", "en": "Quantity must be greater than 0"},
+	MsgQuizBudgetInvalid:               {"id": "Pilihan budget tidak valid", "en": "Invalid budget option"},
+	MsgQuizSaveFailed:                  {"id": "Gagal menyimpan jawaban kuis preferensi", "en": "Failed to save preference quiz answers"},
+	MsgQuizTravelStyleInvalid:          {"id": "Pilihan gaya perjalanan tidak valid", "en": "Invalid travel style option"},
+	MsgRecommendationsFetchFailed:      {"id": "Gagal mengambil rekomendasi", "en": "Failed to fetch recommendations"},
+	MsgReferralCodeGenerateFailed:      {"id": "Gagal membuat kode referral", "en": "Failed to generate referral code"},
+	MsgReferralRewardFailed:            {"id": "Gagal memberikan hadiah referral", "en": "Failed to reward referral"},
+	MsgReferralSaveFailed:              {"id": "Gagal menyimpan referral", "en": "Failed to save referral"},
+	MsgRestoreFailed:                   {"id": "Gagal memulihkan data", "en": "Failed to restore data"},
+	MsgReviewModerateFailed:            {"id": "Gagal memoderasi ulasan", "en": "Failed to moderate review"},
+	MsgReviewNotAllowed:                {"id": "Hanya pengunjung dengan tiket sukses yang dapat mengulas tempat wisata ini", "en": "Only visitors with a successful ticket can review this tourism attraction"},
+	MsgReviewNotFound:                  {"id": "Ulasan tidak ditemukan", "en": "Review not found"},
+	MsgReviewSaveFailed:                {"id": "Gagal menyimpan ulasan", "en": "Failed to save review"},
+	MsgRulesTooLong:                    {"id": "Peraturan maksimal 2000 karakter", "en": "Peraturan cannot exceed 2000 characters"},
+	MsgRulesTooShort:                   {"id": "Peraturan minimal 10 karakter", "en": "Peraturan must be at least 10 characters"},
+	MsgShareLinkFailed:                 {"id": "Gagal membuat tautan berbagi", "en": "Failed to generate share link"},
+	MsgShareLinkRevokeFailed:           {"id": "Gagal mencabut tautan berbagi", "en": "Failed to revoke share link"},
+	MsgSimilarWisataFetchFailed:        {"id": "Gagal mengambil wisata serupa", "en": "Failed to fetch similar wisatas"},
+	MsgTermConditionExists:             {"id": "Syarat dan ketentuan dengan nama yang sama sudah ada", "en": "Term and condition with the same name already exists"},
+	MsgTermConditionNotFound:           {"id": "Syarat dan ketentuan tidak ditemukan", "en": "Term and condition not found"},
+	MsgTicketsFetchFailed:              {"id": "Gagal mengambil data tiket", "en": "Failed to fetch tickets"},
+	MsgTicketsNotEnough:                {"id": "Tiket yang tersedia tidak mencukupi", "en": "Not enough available tickets"},
+	MsgTicketAlreadyCanceled:           {"id": "Tiket sudah dibatalkan", "en": "Ticket has already been canceled"},
+	MsgTicketCanceledPaidStatus:        {"id": "Status pembayaran tiket yang dibatalkan tidak dapat diubah", "en": "Cannot update paid status for canceled ticket"},
+	MsgTicketCancelFailed:              {"id": "Gagal membatalkan tiket", "en": "Failed to cancel ticket"},
+	MsgTicketCreateFailed:              {"id": "Gagal membuat tiket", "en": "Failed to create ticket"},
+	MsgTicketNotCancellable:            {"id": "Tiket dengan status saat ini tidak dapat dibatalkan", "en": "Cannot cancel ticket with current status"},
+	MsgTicketNotFound:                  {"id": "Tiket tidak ditemukan", "en": "Ticket not found"},
+	MsgTicketStatusUpdateFailed:        {"id": "Gagal memperbarui status tiket", "en": "Failed to update ticket status"},
+	MsgTitleExists:                     {"id": "Judul sudah digunakan", "en": "Title is already used"},
+	MsgTitleMin5:                       {"id": "Judul harus minimal 5 huruf", "en": "Title must be at least 5 characters"},
+	MsgTitleMin8:                       {"id": "Judul harus minimal 8 huruf", "en": "Title must be at least 8 characters"},
+	MsgTitleRequired:                   {"id": "Judul harus diisi", "en": "Title is required"},
+	MsgTitleTooLong:                    {"id": "Judul harus maksimal 100 karakter", "en": "Title cannot exceed 100 characters"},
+	MsgTokenGenerateFailed:             {"id": "Gagal membuat token", "en": "Failed to generate token"},
+	MsgTokenParseFailed:                {"id": "Gagal membaca token", "en": "Failed to parse token"},
+	MsgTopReferrersFetchFailed:         {"id": "Gagal mengambil daftar referrer teratas", "en": "Failed to fetch top referrers"},
+	MsgTotalCarbonFootprintFailed:      {"id": "Gagal menghitung total jejak karbon", "en": "Failed to calculate total carbon footprint"},
+	MsgTotalCarbonOffsetFailed:         {"id": "Gagal menghitung total offset karbon", "en": "Failed to calculate total carbon offset"},
+	MsgTransactionHistoryFetchFailed:   {"id": "Gagal mengambil riwayat transaksi", "en": "Failed to fetch transaction history"},
+	MsgTranslationsFetchFailed:         {"id": "Gagal mengambil terjemahan", "en": "Failed to fetch translations"},
+	MsgTranslationDeleteFailed:         {"id": "Gagal menghapus terjemahan", "en": "Failed to delete translation"},
+	MsgTranslationNotFound:             {"id": "Terjemahan tidak ditemukan", "en": "Translation not found"},
+	MsgTranslationSaveFailed:           {"id": "Gagal menyimpan terjemahan", "en": "Failed to save translation"},
+	MsgUsernameCheckFailed:             {"id": "Gagal memeriksa username", "en": "Failed to check username"},
+	MsgUsernameExists:                  {"id": "Username sudah digunakan", "en": "Username already exists"},
+	MsgUsernameRequired:                {"id": "Username harus diisi", "en": "Username is required"},
+	MsgUsernameTooShort:                {"id": "Username minimal 5 karakter", "en": "Username must be at least 5 characters"},
+	MsgUserCreateFailed:                {"id": "Gagal membuat pengguna", "en": "Failed to create user"},
+	MsgUserEditForbidden:               {"id": "Tidak diizinkan mengubah pengguna ini", "en": "Unauthorized to edit this user"},
+	MsgUserEmailFailed:                 {"id": "Gagal mengirim email ke pengguna", "en": "Failed to send email to user"},
+	MsgUserFetchFailed:                 {"id": "Gagal mengambil data pengguna", "en": "Failed to fetch user data"},
+	MsgUserLocationUpdateFailed:        {"id": "Gagal memperbarui lokasi pengguna", "en": "Failed to update user location"},
+	MsgUserNotFound:                    {"id": "Pengguna tidak ditemukan", "en": "User not found"},
+	MsgUserPointsUpdateFailed:          {"id": "Gagal memperbarui poin pengguna", "en": "Failed to update user points"},
+	MsgUserTicketsFetchFailed:          {"id": "Gagal mengambil tiket pengguna", "en": "Failed to fetch user's tickets"},
+	MsgUserUpdateFailed:                {"id": "Gagal memperbarui pengguna", "en": "Failed to update user"},
+	MsgVideoURLRequired:                {"id": "URL video diperlukan.", "en": "A video URL is required."},
+	MsgVoucherAlreadyUsed:              {"id": "Voucher sudah digunakan", "en": "Voucher has already been used"},
+	MsgVoucherCodeTooLong:              {"id": "Kode voucher maksimal 40 karakter", "en": "Kode voucher cannot exceed 40 characters"},
+	MsgVoucherCodeTooShort:             {"id": "Kode voucher minimal 5 karakter", "en": "Kode voucher must be at least 5 characters"},
+	MsgVoucherExpired:                  {"id": "Voucher sudah kedaluwarsa", "en": "Voucher has expired"},
+	MsgVoucherInactive:                 {"id": "Voucher belum aktif", "en": "Voucher is not active yet"},
+	MsgWalletPassFailed:                {"id": "Gagal membuat wallet pass", "en": "Failed to generate wallet pass"},
+	MsgWalletTicketUnpaid:              {"id": "Hanya tiket yang sudah dibayar yang dapat ditambahkan ke wallet", "en": "Only paid tickets can be added to wallet"},
+	MsgWeakNewPassword:                 {"id": "Password baru minimal 8 karakter dan berisi kombinasi huruf dan angka", "en": "New password must be at least 8 characters and contain a combination of letters and numbers"},
+	MsgWeakPassword:                    {"id": "Password minimal 8 karakter dan berisi kombinasi huruf dan angka", "en": "Password must be at least 8 characters and contain a combination of letters and numbers"},
+	MsgWelcomeEmailFailed:              {"id": "Gagal mengirim email selamat datang", "en": "Failed to send welcome email"},
+	MsgWisataClosedOnDate:              {"id": "Tempat wisata tutup pada tanggal check-in yang dipilih", "en": "Tourism attraction is closed on the selected checkin date"},
+	MsgWisataDeleteFailed:              {"id": "Gagal menghapus wisata", "en": "Failed to delete wisata"},
+	MsgWisataFetchFailed:               {"id": "Gagal mengambil data wisata", "en": "Failed to fetch wisata"},
+	MsgWisataIDRequired:                {"id": "ID wisata harus diisi", "en": "Wisata ID is required"},
+	MsgWisataNotFound:                  {"id": "Wisata tidak ditemukan", "en": "Wisata not found"},
+	MsgWisataSaveFailed:                {"id": "Gagal menyimpan wisata", "en": "Failed to save wisata"},
+
+	MsgCategoryInUse:             {"id": "Kategori masih digunakan oleh %d subkategori, %d tempat wisata dan %d user. Pindahkan atau gabungkan ke kategori lain terlebih dahulu", "en": "Category is still used by %d subcategories, %d tourist attractions and %d users. Reassign or merge them into another category first"},
+	MsgFieldNotString:            {"id": "Field %s harus berupa string", "en": "Field %s must be a string"},
+	MsgInvalidLeaderboardPeriod:  {"id": "Periode tidak valid. Gunakan weekly, monthly atau all", "en": "Invalid period. Use weekly, monthly or all"},
+	MsgInvalidStartTime:          {"id": "start_time tidak valid, gunakan HH:MM", "en": "Invalid start_time, use HH:MM"},
+	MsgItineraryDaysRange:        {"id": "Jumlah hari harus antara 1 dan %d", "en": "Days must be between 1 and %d"},
+	MsgItineraryNameInvalid:      {"id": "Nama itinerary harus diisi dan maksimal 100 karakter", "en": "Itinerary name is required and cannot exceed 100 characters"},
+	MsgItineraryStopDayRange:     {"id": "Hari tujuan harus berada dalam rentang hari itinerary", "en": "Stop day must be within the itinerary days"},
+	MsgItineraryTicketNotFound:   {"id": "Tiket untuk wisata ini tidak ditemukan", "en": "Ticket not found for this wisata"},
+	MsgItineraryTooManyStops:     {"id": "Itinerary tidak boleh memiliki lebih dari %d tujuan", "en": "Itinerary cannot have more than %d stops"},
+	MsgLocaleUpdateFailed:        {"id": "Gagal memperbarui bahasa pengguna", "en": "Failed to update user locale"},
+	MsgNegativeDuration:          {"id": "Durasi tidak boleh negatif", "en": "Duration cannot be negative"},
+	MsgQuizInterestRange:         {"id": "Jawaban minat harus antara 0 dan %d", "en": "Interest answers must be between 0 and %d"},
+	MsgReferralCodeInvalid:       {"id": "Kode referral tidak valid", "en": "Invalid referral code"},
+	MsgReferralDeviceLimit:       {"id": "Batas referral untuk perangkat ini sudah tercapai", "en": "Referral limit reached for this device"},
+	MsgReferralEmailLimit:        {"id": "Batas referral untuk email ini sudah tercapai", "en": "Referral limit reached for this email"},
+	MsgReferralPhoneLimit:        {"id": "Batas referral untuk nomor telepon ini sudah tercapai", "en": "Referral limit reached for this phone number"},
+	MsgReferrerLimit:             {"id": "Kode referral ini sudah mencapai batas pemakaian", "en": "This referral code has reached its usage limit"},
+	MsgReviewTooManyPhotos:       {"id": "Maksimal %d foto untuk setiap review.", "en": "A review can have at most %d photos."},
+	MsgSelfReferral:              {"id": "Kamu tidak dapat memakai kode referral milikmu sendiri", "en": "You cannot use your own referral code"},
+	MsgTranslationFieldsRequired: {"id": "Isi minimal satu field: %s", "en": "Fill in at least one field: %s"},
+
+	MsgNotificationBackInStock:         {"id": "Tiket %s sudah tersedia kembali, pesan sekarang sebelum kehabisan", "en": "Tickets for %s are available again, book now before they sell out"},
+	MsgNotificationBackInStockTitle:    {"id": "Tiket Tersedia Kembali", "en": "Tickets Available Again"},
+	MsgNotificationBadge:               {"id": "Selamat! Kamu mendapatkan badge %s: %s", "en": "Congratulations! You earned the %s badge: %s"},
+	MsgNotificationBadgeTitle:          {"id": "Badge Baru", "en": "New Badge"},
+	MsgNotificationFavoritePromo:       {"id": "Diskon %d%% bisa kamu pakai untuk %s di daftar favoritmu", "en": "Use your %d%% discount for %s from your favorites"},
+	MsgNotificationPaymentSuccess:      {"id": "Tiket untuk wisata %s berhasil dibayar. Selamat liburan!", "en": "Your ticket for %s has been paid. Enjoy your trip!"},
+	MsgNotificationPaymentSuccessTitle: {"id": "Transaksi Sukses", "en": "Transaction Successful"},
+	MsgNotificationPriceDrop:           {"id": "Harga tiket %s turun dari Rp. %d menjadi Rp. %d", "en": "Ticket price for %s dropped from Rp. %d to Rp. %d"},
+	MsgNotificationPriceDropTitle:      {"id": "Harga Turun", "en": "Price Drop"},
+	MsgNotificationPromoTitle:          {"id": "promo %s", "en": "promo %s"},
+	MsgNotificationReferralPoints:      {"id": "Selamat! Kamu mendapatkan %d poin dari program referral", "en": "Congratulations! You earned %d points from the referral program"},
+	MsgNotificationReferralTitle:       {"id": "Bonus Referral", "en": "Referral Bonus"},
+	MsgNotificationReferralVoucher:     {"id": "Selamat! Kamu mendapatkan voucher %s diskon %d%% dari program referral", "en": "Congratulations! You got voucher %s with a %d%% discount from the referral program"},
+
+	MsgEmailCooperationAdminSubject: {"id": "Pesan Kerja Sama Baru", "en": "New Cooperation Message"},
+	MsgEmailCooperationReply:        {"id": "Kami akan segera menghubungi kamu kembali.", "en": "We will get back to you as soon as possible."},
+	MsgEmailCooperationThanks:       {"id": "Terima kasih atas pesan kerja sama kamu", "en": "Thank you for your Cooperation Message"},
+	MsgEmailCooperationUserSubject:  {"id": "Pesan Kerja Sama Kamu", "en": "Your Cooperation Message"},
+	MsgEmailCooperationYourMessage:  {"id": "Pesan Kamu:", "en": "Your Message:"},
+	MsgEmailGreeting:                {"id": "Halo, %s,", "en": "Hello, %s,"},
+	MsgEmailInvoiceCarbon:           {"id": "Jejak Karbon", "en": "Carbon Footprint"},
+	MsgEmailInvoiceCarbonValue:      {"id": "%.2f gram CO2", "en": "%.2f grams CO2"},
+	MsgEmailInvoiceCheckin:          {"id": "Tanggal Check-in", "en": "Check-in Date"},
+	MsgEmailInvoiceDestination:      {"id": "Destinasi", "en": "Destination"},
+	MsgEmailInvoiceHeading:          {"id": "Pembelian Tiket Berhasil", "en": "Successful Ticket Purchase"},
+	MsgEmailInvoiceNumber:           {"id": "Nomor Invoice", "en": "Invoice Number"},
+	MsgEmailInvoicePointsEarned:     {"id": "Poin Didapat", "en": "Points Earned"},
+	MsgEmailInvoicePointsUsed:       {"id": "Poin Digunakan", "en": "Used Points"},
+	MsgEmailInvoiceQuantity:         {"id": "Jumlah", "en": "Quantity"},
+	MsgEmailInvoiceSubject:          {"id": "Pembelian Tiket Wisata Berhasil - Invoice No: %s", "en": "Tourism Ticket Purchase Successful - Invoice No: %s"},
+	MsgEmailInvoiceThanks:           {"id": "Terima kasih atas pembelianmu! Semoga kamu mendapatkan pengalaman yang menyenangkan di platform kami.", "en": "Thank you for your purchase! We hope you have a wonderful experience using our platform."},
+	MsgEmailInvoiceTotal:            {"id": "Total Harga", "en": "Total Price"},
+	MsgEmailInvoiceVoucher:          {"id": "Kode Voucher", "en": "Voucher Code"},
+	MsgEmailLabelEmail:              {"id": "Email", "en": "Email"},
+	MsgEmailLabelMessage:            {"id": "Pesan", "en": "Message"},
+	MsgEmailLabelName:               {"id": "Nama", "en": "Name"},
+	MsgEmailLabelPhone:              {"id": "Nomor Telepon", "en": "Phone Number"},
+	MsgEmailLoginBody:               {"id": "Login kamu berhasil. Jika ini bukan kamu, segera hubungi tim dukungan kami. Terima kasih.", "en": "Your login was successful. If this wasn't you, please contact our support team immediately. Thank you."},
+	MsgEmailLoginHeading:            {"id": "Login Berhasil", "en": "Login Successful"},
+	MsgEmailLoginSubject:            {"id": "Notifikasi Login Berhasil", "en": "Successful Login Notification"},
+	MsgEmailRegards:                 {"id": "Salam hangat", "en": "Best Regards"},
+	MsgEmailRightsReserved:          {"id": "Hak cipta dilindungi undang-undang.", "en": "All rights reserved."},
+	MsgEmailSupportTeam:             {"id": "Tim Dukungan:", "en": "Support Team:"},
+	MsgEmailVerifyButton:            {"id": "Verifikasi Email", "en": "Verify Email"},
+	MsgEmailWelcomeBody:             {"id": "Terima kasih telah mendaftar di aplikasi pemesanan Destimate. Sekarang kamu bagian dari komunitas kami!", "en": "Thank you for signing up with our Destimate booking app. You're now part of our community!"},
+	MsgEmailWelcomeHeading:          {"id": "Selamat Datang di Destimate", "en": "Welcome to Destimate"},
+	MsgEmailWelcomeHelp:             {"id": "Jika ada pertanyaan atau butuh bantuan, jangan ragu menghubungi tim dukungan kami.", "en": "If you have any questions or need assistance, please don't hesitate to contact our support team."},
+	MsgEmailWelcomeSubject:          {"id": "Selamat Datang di Aplikasi Pemesanan Wisata Berkelanjutan Destimate", "en": "Welcome to Destimate Sustainable Tourism Booking App"},
+
+	MsgQuizBudgetQuestion:      {"id": "Berapa budget tiket yang biasanya kamu siapkan per tempat wisata?", "en": "How much do you usually budget for a ticket per attraction?"},
+	MsgQuizCultureQuestion:     {"id": "Seberapa suka kamu dengan wisata budaya dan sejarah?", "en": "How much do you enjoy cultural and historical attractions?"},
+	MsgQuizNatureQuestion:      {"id": "Seberapa suka kamu dengan wisata alam?", "en": "How much do you enjoy nature attractions?"},
+	MsgQuizTravelStyleQuestion: {"id": "Gaya perjalanan seperti apa yang paling kamu sukai?", "en": "What travel style do you enjoy most?"},
+}
@@ -0,0 +1,98 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+)
+
+// MessageCode adalah kode pesan yang tidak berubah walaupun teksnya diterjemahkan
+type MessageCode string
+
+// MessageLocale memilih locale pertama yang tersedia di katalog pesan, selain itu DefaultLocale
+func MessageLocale(locales []string) string {
+	for _, locale := range locales {
+		if locale == "id" || locale == "en" {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// Translate mengambil teks pesan sesuai urutan locale lalu mengisi argumennya
+func Translate(locales []string, code MessageCode, args ...interface{}) string {
+	texts, ok := messageCatalog[code]
+	if !ok {
+		return string(code)
+	}
+	text := texts[MessageLocale(locales)]
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// MessageError adalah error domain yang teksnya berasal dari katalog pesan
+type MessageError struct {
+	Code MessageCode
+	Args []interface{}
+}
+
+func NewMessageError(code MessageCode, args ...interface{}) *MessageError {
+	return &MessageError{Code: code, Args: args}
+}
+
+func (e *MessageError) Error() string {
+	return Translate([]string{"en"}, e.Code, e.Args...)
+}
+
+// NewErrorResponse membuat ErrorResponse dari error, MessageError dikirim beserta kodenya
+func NewErrorResponse(code int, err error) ErrorResponse {
+	var messageError *MessageError
+	if errors.As(err, &messageError) {
+		return ErrorResponse{Code: code, ErrorCode: messageError.Code, Args: messageError.Args}
+	}
+	return ErrorResponse{Code: code, Message: err.Error()}
+}
+
+// NewHTTPError membuat echo.HTTPError yang isinya diterjemahkan seperti ErrorResponse
+func NewHTTPError(code int, messageCode MessageCode) *echo.HTTPError {
+	return echo.NewHTTPError(code, ErrorResponse{Code: code, ErrorCode: messageCode})
+}
+
+// LocalizedJSONSerializer menerjemahkan ErrorResponse dan Response sesuai locale request sebelum dikirim
+type LocalizedJSONSerializer struct {
+	echo.DefaultJSONSerializer
+}
+
+func (s LocalizedJSONSerializer) Serialize(c echo.Context, i interface{}, indent string) error {
+	switch response := i.(type) {
+	case ErrorResponse:
+		i = localizeErrorResponse(c, response)
+	case *ErrorResponse:
+		if response != nil {
+			i = localizeErrorResponse(c, *response)
+		}
+	case Response:
+		i = localizeResponse(c, response)
+	case *Response:
+		if response != nil {
+			i = localizeResponse(c, *response)
+		}
+	}
+	return s.DefaultJSONSerializer.Serialize(c, i, indent)
+}
+
+func localizeErrorResponse(c echo.Context, response ErrorResponse) ErrorResponse {
+	if response.ErrorCode != "" {
+		response.Message = Translate(RequestLocales(c), response.ErrorCode, response.Args...)
+	}
+	return response
+}
+
+func localizeResponse(c echo.Context, response Response) Response {
+	if response.ErrorCode != "" {
+		response.Message = Translate(RequestLocales(c), response.ErrorCode)
+	}
+	return response
+}
@@ -0,0 +1,169 @@
+package helper
+
+import (
+	"errors"
+	"myproject/model"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Jumlah hari ke depan yang diperiksa saat mencari jadwal buka berikutnya
+const nextOpeningLookaheadDays = 60
+
+var ErrInvalidClock = errors.New("invalid time, use HH:MM")
+
+// OpeningSchedule menggabungkan jam buka mingguan dan pengecualian tanggal sebuah tempat wisata
+type OpeningSchedule struct {
+	Location   *time.Location
+	Hours      []model.OpeningHour
+	Closures   map[string]model.WisataClosure // key: tanggal YYYY-MM-DD
+	legacyOpen bool
+}
+
+type openRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// LoadTimeZone memuat zona waktu IANA, atau WIB jika nama zona tidak dikenal
+func LoadTimeZone(name string) *time.Location {
+	if name == "" {
+		name = "Asia/Jakarta"
+	}
+	if location, err := time.LoadLocation(name); err == nil {
+		return location
+	}
+	return time.FixedZone("WIB", 7*60*60)
+}
+
+// ParseClock membaca jam berformat HH:MM menjadi menit sejak tengah malam, "24:00" diperbolehkan
+func ParseClock(value string) (int, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 || len(parts[0]) != 2 || len(parts[1]) != 2 {
+		return 0, ErrInvalidClock
+	}
+	hour, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, ErrInvalidClock
+	}
+	minute, err := strconv.Atoi(parts[1])
+	if err != nil || minute < 0 || minute > 59 || hour < 0 || hour > 24 || (hour == 24 && minute != 0) {
+		return 0, ErrInvalidClock
+	}
+	return hour*60 + minute, nil
+}
+
+// NewOpeningSchedule menyusun jadwal wisata, tanpa jam mingguan jadwal mengikuti toggle IsOpen lama (buka sepanjang hari)
+func NewOpeningSchedule(wisata model.Wisata, hours []model.OpeningHour, closures []model.WisataClosure) OpeningSchedule {
+	schedule := OpeningSchedule{
+		Location:   LoadTimeZone(wisata.TimeZone),
+		Hours:      hours,
+		Closures:   make(map[string]model.WisataClosure),
+		legacyOpen: wisata.IsOpen,
+	}
+	for _, closure := range closures {
+		schedule.Closures[closure.Date] = closure
+	}
+	return schedule
+}
+
+// rangesOn mengembalikan rentang jam buka yang dimulai pada tanggal lokal day
+func (s OpeningSchedule) rangesOn(day time.Time) []openRange {
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, s.Location)
+
+	var clocks [][2]string
+	if closure, ok := s.Closures[day.Format("2006-01-02")]; ok {
+		if closure.IsClosed {
+			return nil
+		}
+		clocks = append(clocks, [2]string{closure.OpenTime, closure.CloseTime})
+	} else if len(s.Hours) == 0 {
+		if !s.legacyOpen {
+			return nil
+		}
+		clocks = append(clocks, [2]string{"00:00", "24:00"})
+	} else {
+		for _, hour := range s.Hours {
+			if time.Weekday(hour.DayOfWeek) == day.Weekday() {
+				clocks = append(clocks, [2]string{hour.OpenTime, hour.CloseTime})
+			}
+		}
+	}
+
+	var ranges []openRange
+	for _, clock := range clocks {
+		openMinute, err := ParseClock(clock[0])
+		if err != nil {
+			continue
+		}
+		closeMinute, err := ParseClock(clock[1])
+		if err != nil || openMinute == closeMinute {
+			continue
+		}
+		if closeMinute < openMinute {
+			closeMinute += 24 * 60
+		}
+		ranges = append(ranges, openRange{
+			Start: day.Add(time.Duration(openMinute) * time.Minute),
+			End:   day.Add(time.Duration(closeMinute) * time.Minute),
+		})
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].Start.Before(ranges[j].Start) })
+	return ranges
+}
+
+// currentRange mencari rentang jam buka yang sedang berlangsung, termasuk rentang dari hari sebelumnya yang lewat tengah malam
+func (s OpeningSchedule) currentRange(t time.Time) (openRange, bool) {
+	local := t.In(s.Location)
+	for _, day := range []time.Time{local.AddDate(0, 0, -1), local} {
+		for _, r := range s.rangesOn(day) {
+			if !t.Before(r.Start) && t.Before(r.End) {
+				return r, true
+			}
+		}
+	}
+	return openRange{}, false
+}
+
+// IsOpenAt menentukan apakah tempat wisata buka pada waktu t
+func (s OpeningSchedule) IsOpenAt(t time.Time) bool {
+	_, ok := s.currentRange(t)
+	return ok
+}
+
+// ClosesAt mengembalikan waktu tutup jika tempat wisata sedang buka pada waktu t
+func (s OpeningSchedule) ClosesAt(t time.Time) *time.Time {
+	r, ok := s.currentRange(t)
+	if !ok {
+		return nil
+	}
+	return &r.End
+}
+
+// NextOpening mengembalikan waktu buka berikutnya setelah t pada zona waktu wisata
+func (s OpeningSchedule) NextOpening(t time.Time) *time.Time {
+	local := t.In(s.Location)
+	for i := 0; i <= nextOpeningLookaheadDays; i++ {
+		for _, r := range s.rangesOn(local.AddDate(0, 0, i)) {
+			if r.Start.After(t) {
+				return &r.Start
+			}
+		}
+	}
+	return nil
+}
+
+// IsClosedOn menentukan apakah tempat wisata tutup sepanjang tanggal date (hanya tahun, bulan dan hari yang dipakai)
+func (s OpeningSchedule) IsClosedOn(date time.Time) bool {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, s.Location)
+	if closure, ok := s.Closures[day.Format("2006-01-02")]; ok && closure.IsClosed {
+		return true
+	}
+	// Tanpa jam mingguan, pemesanan tidak dibatasi oleh toggle IsOpen yang hanya menggambarkan kondisi saat ini
+	if len(s.Hours) == 0 {
+		return false
+	}
+	return len(s.rangesOn(day)) == 0
+}
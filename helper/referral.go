@@ -0,0 +1,83 @@
+package helper
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ReferralConfig berisi pengaturan hadiah dan batasan program referral yang dibaca dari environment
+type ReferralConfig struct {
+	RewardType     string // points atau voucher
+	ReferrerPoints int
+	RefereePoints  int
+	VoucherPercent int
+	VoucherDays    int
+	MaxPerDevice   int
+	MaxPerPhone    int
+	MaxPerEmail    int
+	MaxPerReferrer int
+}
+
+func GetReferralConfig() ReferralConfig {
+	rewardType := strings.ToLower(os.Getenv("REFERRAL_REWARD_TYPE"))
+	if rewardType != "voucher" {
+		rewardType = "points"
+	}
+
+	return ReferralConfig{
+		RewardType:     rewardType,
+		ReferrerPoints: GetEnvInt("REFERRAL_REFERRER_POINTS", 50),
+		RefereePoints:  GetEnvInt("REFERRAL_REFEREE_POINTS", 25),
+		VoucherPercent: GetEnvInt("REFERRAL_VOUCHER_PERCENT", 10),
+		VoucherDays:    GetEnvInt("REFERRAL_VOUCHER_DAYS", 30),
+		MaxPerDevice:   GetEnvInt("REFERRAL_MAX_PER_DEVICE", 1),
+		MaxPerPhone:    GetEnvInt("REFERRAL_MAX_PER_PHONE", 1),
+		MaxPerEmail:    GetEnvInt("REFERRAL_MAX_PER_EMAIL", 1),
+		MaxPerReferrer: GetEnvInt("REFERRAL_MAX_PER_REFERRER", 50),
+	}
+}
+
+// GetEnvInt membaca variabel lingkungan bertipe int, atau mengembalikan nilai default
+func GetEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// GenerateReferralCode membuat kode referral dari nama user ditambah karakter acak
+func GenerateReferralCode(name string) string {
+	prefix := strings.ToUpper(strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			return r
+		}
+		return -1
+	}, name))
+	if len(prefix) > 4 {
+		prefix = prefix[:4]
+	}
+	return prefix + strings.ToUpper(GenerateRandomString(6))
+}
+
+// NormalizeEmail menyeragamkan email untuk pemeriksaan anti-fraud referral: huruf kecil, tanpa alias "+..."
+// dan tanpa titik pada alamat Gmail karena semuanya diterima oleh kotak masuk yang sama
+func NormalizeEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return email
+	}
+	local, domain := email[:at], email[at+1:]
+	if plus := strings.Index(local, "+"); plus >= 0 {
+		local = local[:plus]
+	}
+	if domain == "googlemail.com" {
+		domain = "gmail.com"
+	}
+	if domain == "gmail.com" {
+		local = strings.ReplaceAll(local, ".", "")
+	}
+	return local + "@" + domain
+}
@@ -5,9 +5,12 @@ import (
 	"time"
 )
 
+// ErrorResponse dengan ErrorCode diisi Message-nya dari katalog pesan saat dikirim
 type ErrorResponse struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+	Code      int           `json:"code"`
+	ErrorCode MessageCode   `json:"error_code,omitempty"`
+	Message   string        `json:"message"`
+	Args      []interface{} `json:"-"`
 }
 
 //Nambahin foto profil
@@ -24,6 +27,8 @@ type UserResponse struct {
 	CategoryKesukaan string     `json:"category_kesukaan"`
 	CategoryID       uint       `json:"category_id"`
 	CreatedAt        *time.Time `json:"created_at"`
+	Locale           string     `json:"locale,omitempty"`
+	DeletedAt        *time.Time `json:"deleted_at,omitempty"`
 }
 
 //Nambahin foto profil
@@ -52,8 +57,9 @@ type CarbonFootprintResponse struct {
 
 // Response struct untuk menyimpan informasi respons
 type Response struct {
-	Code     int             `json:"code"`
-	Error    bool            `json:"error"`
-	Message  string          `json:"message,omitempty"`
-	Category *model.Category `json:"category,omitempty"`
+	Code      int             `json:"code"`
+	Error     bool            `json:"error"`
+	ErrorCode MessageCode     `json:"error_code,omitempty"`
+	Message   string          `json:"message,omitempty"`
+	Category  *model.Category `json:"category,omitempty"`
 }
@@ -0,0 +1,264 @@
+package helper
+
+import (
+	"bytes"
+	"fmt"
+	"myproject/carbon"
+	"myproject/model"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Satu pohon dewasa menyerap sekitar 21 kg CO2 per tahun
+const treeAbsorptionGramsPerYear = 21000
+
+type ReportTrip struct {
+	InvoiceNumber  string     `json:"invoice_number"`
+	WisataID       uint       `json:"wisata_id"`
+	WisataTitle    string     `json:"wisata_title"`
+	Kota           string     `json:"kota"`
+	CheckinBooking *time.Time `json:"checkin_booking"`
+	TransportMode  string     `json:"transport_mode"`
+	DistanceKm     float64    `json:"distance_km"`
+	CarbonGrams    float64    `json:"carbon_grams"`
+	OffsetGrams    float64    `json:"offset_grams"`
+}
+
+type ReportMonth struct {
+	Month       string  `json:"month"`
+	Trips       int     `json:"trips"`
+	DistanceKm  float64 `json:"distance_km"`
+	CarbonGrams float64 `json:"carbon_grams"`
+}
+
+type ReportDestination struct {
+	WisataID uint   `json:"wisata_id"`
+	Title    string `json:"title"`
+	Kota     string `json:"kota"`
+	Visits   int    `json:"visits"`
+}
+
+type ReportComparisons struct {
+	EquivalentHousePowerHours int     `json:"equivalent_house_power_hours"`
+	EquivalentCarKm           float64 `json:"equivalent_car_km"`
+	TreesToAbsorbInOneYear    float64 `json:"trees_to_absorb_in_one_year"`
+	PlatformAverageTripGrams  float64 `json:"platform_average_trip_grams"`
+	UserAverageTripGrams      float64 `json:"user_average_trip_grams"`
+}
+
+// SustainabilityReport adalah ringkasan tahunan perjalanan dan emisi seorang user
+type SustainabilityReport struct {
+	Year            int                 `json:"year"`
+	UserName        string              `json:"user_name"`
+	TotalTrips      int                 `json:"total_trips"`
+	TotalDistanceKm float64             `json:"total_distance_km"`
+	GrossGrams      float64             `json:"gross_carbon_grams"`
+	OffsetGrams     float64             `json:"offset_carbon_grams"`
+	NetGrams        float64             `json:"net_carbon_grams"`
+	Trips           []ReportTrip        `json:"trips"`
+	Months          []ReportMonth       `json:"months"`
+	Destinations    []ReportDestination `json:"destinations"`
+	Comparisons     ReportComparisons   `json:"comparisons"`
+	GeneratedAt     time.Time           `json:"generated_at"`
+}
+
+// BuildSustainabilityReport menyusun laporan tahunan hanya dari riwayat tiket, data wisata dan ledger offset,
+// sehingga dapat dihitung tanpa koneksi database
+func BuildSustainabilityReport(year int, user model.User, tickets []model.Ticket, wisatas map[uint]model.Wisata, offsets []model.CarbonOffset, platformAverageTripGrams float64) SustainabilityReport {
+	report := SustainabilityReport{
+		Year:         year,
+		UserName:     user.Name,
+		Trips:        []ReportTrip{},
+		Months:       make([]ReportMonth, 12),
+		Destinations: []ReportDestination{},
+		GeneratedAt:  time.Now(),
+	}
+
+	for i := range report.Months {
+		report.Months[i].Month = time.Month(i + 1).String()
+	}
+
+	offsetByTicket := make(map[uint]float64)
+	for _, offset := range offsets {
+		if offset.Status == "paid" {
+			offsetByTicket[offset.TicketID] += offset.OffsetGrams
+		}
+	}
+
+	destinationIndex := make(map[uint]int)
+	for _, ticket := range tickets {
+		if !ticket.PaidStatus || ticket.CheckinBooking == nil || ticket.CheckinBooking.Year() != year {
+			continue
+		}
+
+		wisata := wisatas[ticket.WisataID]
+		trip := ReportTrip{
+			InvoiceNumber:  ticket.InvoiceNumber,
+			WisataID:       ticket.WisataID,
+			WisataTitle:    wisata.Title,
+			Kota:           wisata.Kota,
+			CheckinBooking: ticket.CheckinBooking,
+			TransportMode:  ticket.TransportMode,
+			DistanceKm:     ticket.TravelDistance,
+			CarbonGrams:    ticket.CarbonFootprint,
+			OffsetGrams:    offsetByTicket[ticket.ID],
+		}
+		report.Trips = append(report.Trips, trip)
+
+		report.TotalTrips++
+		report.TotalDistanceKm += trip.DistanceKm
+		report.GrossGrams += trip.CarbonGrams
+		report.OffsetGrams += trip.OffsetGrams
+
+		month := &report.Months[ticket.CheckinBooking.Month()-1]
+		month.Trips++
+		month.DistanceKm += trip.DistanceKm
+		month.CarbonGrams += trip.CarbonGrams
+
+		if idx, ok := destinationIndex[ticket.WisataID]; ok {
+			report.Destinations[idx].Visits++
+		} else {
+			destinationIndex[ticket.WisataID] = len(report.Destinations)
+			report.Destinations = append(report.Destinations, ReportDestination{
+				WisataID: ticket.WisataID,
+				Title:    wisata.Title,
+				Kota:     wisata.Kota,
+				Visits:   1,
+			})
+		}
+	}
+
+	sort.Slice(report.Trips, func(i, j int) bool {
+		return report.Trips[i].CheckinBooking.Before(*report.Trips[j].CheckinBooking)
+	})
+	sort.SliceStable(report.Destinations, func(i, j int) bool {
+		return report.Destinations[i].Visits > report.Destinations[j].Visits
+	})
+
+	report.NetGrams = report.GrossGrams - report.OffsetGrams
+	if report.NetGrams < 0 {
+		report.NetGrams = 0
+	}
+
+	report.Comparisons = ReportComparisons{
+		EquivalentHousePowerHours: int(report.GrossGrams / 1000),
+		EquivalentCarKm:           report.GrossGrams / carbon.DefaultEmissionFactors[carbon.ModeCar],
+		TreesToAbsorbInOneYear:    report.NetGrams / treeAbsorptionGramsPerYear,
+		PlatformAverageTripGrams:  platformAverageTripGrams,
+	}
+	if report.TotalTrips > 0 {
+		report.Comparisons.UserAverageTripGrams = report.GrossGrams / float64(report.TotalTrips)
+	}
+
+	return report
+}
+
+// RenderSustainabilityReportPDF membuat dokumen PDF sederhana (font Helvetica bawaan) dari laporan tahunan
+func RenderSustainabilityReportPDF(report SustainabilityReport) []byte {
+	lines := []string{
+		fmt.Sprintf("Destimate Wrapped %d", report.Year),
+		fmt.Sprintf("Laporan keberlanjutan untuk %s", report.UserName),
+		"",
+		fmt.Sprintf("Total perjalanan     : %d", report.TotalTrips),
+		fmt.Sprintf("Total jarak          : %.1f km", report.TotalDistanceKm),
+		fmt.Sprintf("Emisi bruto          : %.2f kg CO2", report.GrossGrams/1000),
+		fmt.Sprintf("Emisi di-offset      : %.2f kg CO2", report.OffsetGrams/1000),
+		fmt.Sprintf("Emisi bersih         : %.2f kg CO2", report.NetGrams/1000),
+		"",
+		"Perbandingan",
+		fmt.Sprintf("- Setara listrik rumah selama %d jam", report.Comparisons.EquivalentHousePowerHours),
+		fmt.Sprintf("- Setara mengemudi mobil sejauh %.1f km", report.Comparisons.EquivalentCarKm),
+		fmt.Sprintf("- Butuh %.1f pohon selama setahun untuk menyerap emisi bersih", report.Comparisons.TreesToAbsorbInOneYear),
+		fmt.Sprintf("- Rata-rata per perjalanan: %.2f kg (rata-rata Destimate %.2f kg)", report.Comparisons.UserAverageTripGrams/1000, report.Comparisons.PlatformAverageTripGrams/1000),
+		"",
+		"Emisi per bulan",
+	}
+
+	for _, month := range report.Months {
+		if month.Trips == 0 {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("- %-10s %d perjalanan, %.1f km, %.2f kg CO2", month.Month, month.Trips, month.DistanceKm, month.CarbonGrams/1000))
+	}
+
+	lines = append(lines, "", "Destinasi yang dikunjungi")
+	for _, destination := range report.Destinations {
+		lines = append(lines, fmt.Sprintf("- %s, %s (%dx)", destination.Title, destination.Kota, destination.Visits))
+	}
+
+	lines = append(lines, "", "Emisi per perjalanan")
+	for _, trip := range report.Trips {
+		lines = append(lines, fmt.Sprintf("- %s %s (%s) %.1f km, %.2f kg CO2", trip.CheckinBooking.Format("2006-01-02"), trip.WisataTitle, trip.TransportMode, trip.DistanceKm, trip.CarbonGrams/1000))
+	}
+
+	return renderTextPDF(lines)
+}
+
+// renderTextPDF menulis baris-baris teks ke PDF A4 multi-halaman tanpa dependensi eksternal
+func renderTextPDF(lines []string) []byte {
+	const linesPerPage = 48
+
+	var pages [][]string
+	for len(lines) > linesPerPage {
+		pages = append(pages, lines[:linesPerPage])
+		lines = lines[linesPerPage:]
+	}
+	pages = append(pages, lines)
+
+	// Objek 1: catalog, 2: pages, 3: font, lalu pasangan page + content untuk setiap halaman
+	var objects []string
+	objects = append(objects, "<< /Type /Catalog /Pages 2 0 R >>")
+
+	var kids []string
+	for i := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 4+i*2))
+	}
+	objects = append(objects, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	objects = append(objects, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range pages {
+		var content bytes.Buffer
+		content.WriteString("BT\n/F1 11 Tf\n14 TL\n50 792 Td\n")
+		for _, line := range page {
+			content.WriteString("(" + escapePDFText(line) + ") Tj T*\n")
+		}
+		content.WriteString("ET")
+
+		objects = append(objects, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", 5+i*2))
+		objects = append(objects, fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = pdf.Len()
+		fmt.Fprintf(&pdf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xrefOffset := pdf.Len()
+	fmt.Fprintf(&pdf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&pdf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&pdf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xrefOffset)
+
+	return pdf.Bytes()
+}
+
+func escapePDFText(text string) string {
+	var escaped strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			escaped.WriteRune('\\')
+			escaped.WriteRune(r)
+		case r < 32 || r > 126:
+			escaped.WriteRune('?')
+		default:
+			escaped.WriteRune(r)
+		}
+	}
+	return escaped.String()
+}
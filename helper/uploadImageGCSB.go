@@ -5,6 +5,7 @@ import (
 	"cloud.google.com/go/storage"
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"google.golang.org/api/option"
 	"io"
@@ -13,6 +14,14 @@ import (
 	"strings"
 )
 
+const gcsBucketName = "destimate"
+
+var (
+	ErrInvalidImageType = errors.New("invalid image type")
+	ErrImageTooLarge    = errors.New("image exceeds maximum size")
+	ErrReadImage        = errors.New("failed to read image")
+)
+
 func decodeBase64Credential() ([]byte, error) {
 	credentialsBase64 := os.Getenv("CREDENTIALS")
 	credentialsBytes, err := base64.StdEncoding.DecodeString(credentialsBase64)
@@ -36,7 +45,7 @@ func UploadImageToGCS(imageData []byte, imageName string) (string, error) {
 		return "", err
 	}
 
-	bucketName := "destimate"
+	bucketName := gcsBucketName
 
 	object := client.Bucket(bucketName).Object(imageName)
 	wc := object.NewWriter(ctx)
@@ -63,3 +72,57 @@ func IsImageFile(file *multipart.FileHeader) bool {
 func IsFileSizeExceeds(file *multipart.FileHeader, maxSize int64) bool {
 	return file.Size > maxSize
 }
+
+// UploadImageFileToGCS memvalidasi file gambar dari form (jenis dan ukuran maksimal) lalu mengunggahnya ke GCS
+func UploadImageFileToGCS(file *multipart.FileHeader, maxSize int64, imageName string) (string, error) {
+	if !IsImageFile(file) {
+		return "", ErrInvalidImageType
+	}
+	if IsFileSizeExceeds(file, maxSize) {
+		return "", ErrImageTooLarge
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return "", ErrReadImage
+	}
+	defer src.Close()
+
+	imageData, err := io.ReadAll(src)
+	if err != nil {
+		return "", ErrReadImage
+	}
+
+	return UploadImageToGCS(imageData, imageName)
+}
+
+// DeleteObjectFromGCS menghapus objek dari bucket, objek yang sudah tidak ada dianggap berhasil dihapus
+func DeleteObjectFromGCS(objectName string) error {
+	ctx := context.Background()
+
+	credentialsBytes, err := decodeBase64Credential()
+	if err != nil {
+		return err
+	}
+
+	client, err := storage.NewClient(ctx, option.WithCredentialsJSON(credentialsBytes))
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	err = client.Bucket(gcsBucketName).Object(objectName).Delete(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil
+	}
+	return err
+}
+
+// GCSObjectName mengambil nama objek dari URL publik GCS, atau string kosong jika URL bukan milik bucket
+func GCSObjectName(url string) string {
+	prefix := fmt.Sprintf("https://storage.googleapis.com/%s/", gcsBucketName)
+	if !strings.HasPrefix(url, prefix) {
+		return ""
+	}
+	return strings.TrimPrefix(url, prefix)
+}
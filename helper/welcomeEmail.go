@@ -6,7 +6,7 @@ import (
 	"strconv"
 )
 
-func SendWelcomeEmail(userEmail, name, verificationToken string) error {
+func SendWelcomeEmail(locales []string, userEmail, name, verificationToken string) error {
 	smtpServer := os.Getenv("SMTP_SERVER")
 	smtpPortStr := os.Getenv("SMTP_PORT")
 	smtpUsername := os.Getenv("SMTP_USERNAME")
@@ -14,10 +14,10 @@ func SendWelcomeEmail(userEmail, name, verificationToken string) error {
 
 	sender := smtpUsername
 	recipient := userEmail
-	subject := "Welcome to Destimate Sustainable Tourism Booking App"
+	subject := Translate(locales, MsgEmailWelcomeSubject)
 	verificationLink := "http://localhost:8080/verify?token=" + verificationToken
 	emailBody := `
-    <html>
+    <html lang="` + MessageLocale(locales) + `">
     <head>
         <link href="https://maxcdn.bootstrapcdn.com/bootstrap/4.5.2/css/bootstrap.min.css" rel="stylesheet">
         <style>
@@ -90,16 +90,16 @@ func SendWelcomeEmail(userEmail, name, verificationToken string) error {
             <div class="logo">
                 <img src="https://i.ibb.co/KXxHL9r/Logo-Destimate.png" alt="Destimate Logo">
             </div>
-            <h1>Welcome to Destimate</h1>
+            <h1>` + Translate(locales, MsgEmailWelcomeHeading) + `</h1>
             <div class="message">
-                <p>Hello, <strong>` + name + `</strong>,</p>
-                <p>Thank you for signing up with our Destimate booking app. You're now part of our community!</p>
-                <p>If you have any questions or need assistance, please don't hesitate to contact our support team.</p>
-                <p><strong>Support Team:</strong> <a href="mailto:hidestimate@gmail.com">hidestimate@gmail.com</a></p>
-                <a href="` + verificationLink + `" class="btn btn-verify-email">Verify Email</a>
+                <p>` + Translate(locales, MsgEmailGreeting, "<strong>"+name+"</strong>") + `</p>
+                <p>` + Translate(locales, MsgEmailWelcomeBody) + `</p>
+                <p>` + Translate(locales, MsgEmailWelcomeHelp) + `</p>
+                <p><strong>` + Translate(locales, MsgEmailSupportTeam) + `</strong> <a href="mailto:hidestimate@gmail.com">hidestimate@gmail.com</a></p>
+                <a href="` + verificationLink + `" class="btn btn-verify-email">` + Translate(locales, MsgEmailVerifyButton) + `</a>
             </div>
             <div class="footer">
-                <p>&copy; 2023 Destimate. All rights reserved.</p>
+                <p>&copy; 2023 Destimate. ` + Translate(locales, MsgEmailRightsReserved) + `</p>
             </div>
         </div>
     </body>
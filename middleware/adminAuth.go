@@ -3,6 +3,7 @@ package middleware
 import (
 	"github.com/labstack/echo/v4"
 	"gorm.io/gorm"
+	"myproject/helper"
 	"myproject/model"
 	"net/http"
 	"strings"
@@ -11,29 +12,29 @@ import (
 func AuthenticateAndAuthorize(c echo.Context, db *gorm.DB, secretKey []byte) (*model.User, error) {
 	tokenString := c.Request().Header.Get("Authorization")
 	if tokenString == "" {
-		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Authorization token is missing")
+		return nil, helper.NewHTTPError(http.StatusUnauthorized, helper.MsgAuthTokenMissing)
 	}
 
 	authParts := strings.SplitN(tokenString, " ", 2)
 	if len(authParts) != 2 || authParts[0] != "Bearer" {
-		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid token format")
+		return nil, helper.NewHTTPError(http.StatusUnauthorized, helper.MsgInvalidTokenFormat)
 	}
 
 	tokenString = authParts[1]
 
 	username, err := VerifyToken(tokenString, secretKey)
 	if err != nil {
-		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid token")
+		return nil, helper.NewHTTPError(http.StatusUnauthorized, helper.MsgInvalidToken)
 	}
 
 	var user model.User
 	result := db.Where("username = ?", username).First(&user)
 	if result.Error != nil {
-		return nil, echo.NewHTTPError(http.StatusNotFound, "User not found")
+		return nil, helper.NewHTTPError(http.StatusNotFound, helper.MsgUserNotFound)
 	}
 
 	if !user.IsAdmin {
-		return nil, echo.NewHTTPError(http.StatusForbidden, "Access denied")
+		return nil, helper.NewHTTPError(http.StatusForbidden, helper.MsgAccessDenied)
 	}
 
 	return &user, nil
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+	"myproject/helper"
+	"myproject/model"
+)
+
+// Locale menentukan urutan locale request, locale yang disimpan user diutamakan lalu header Accept-Language
+func Locale(db *gorm.DB, secretKey []byte) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			locales := helper.PreferredLocales(c.Request().Header.Get("Accept-Language"))
+
+			if username := ExtractUsernameFromToken(c, secretKey); username != "" {
+				var savedLocales []string
+				db.Model(&model.User{}).Where("username = ?", username).Pluck("locale", &savedLocales)
+				if len(savedLocales) > 0 {
+					locales = helper.UserLocales(savedLocales[0], locales)
+				}
+			}
+
+			c.Set(helper.LocalesContextKey, locales)
+			return next(c)
+		}
+	}
+}
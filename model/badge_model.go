@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// UserBadge adalah catatan badge ramah lingkungan yang sudah diperoleh user
+type UserBadge struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"uniqueIndex:idx_user_badge" json:"user_id"`
+	BadgeCode string     `gorm:"uniqueIndex:idx_user_badge;size:50" json:"badge_code"`
+	AwardedAt *time.Time `json:"awarded_at"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// CarbonOffset adalah ledger pembelian offset karbon per user dan per wisata
+type CarbonOffset struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	UserID        uint       `gorm:"index" json:"user_id"`
+	WisataID      uint       `gorm:"index" json:"wisata_id"`
+	TicketID      uint       `gorm:"index" json:"ticket_id"`
+	InvoiceNumber string     `gorm:"size:255" json:"invoice_number"`
+	OffsetGrams   float64    `json:"offset_grams"`
+	PricePerTonne int        `json:"price_per_tonne"`
+	Amount        int        `json:"amount"`
+	Status        string     `gorm:"default:pending" json:"status"` // pending, paid, dibatalkan
+	CreatedAt     *time.Time `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
@@ -5,5 +5,10 @@ import "time"
 type Category struct {
 	ID           uint      `gorm:"primaryKey" json:"id"`
 	CategoryName string    `gorm:"unique;not null;size:255" json:"category_name"`
+	ParentID     *uint     `gorm:"index" json:"parent_id"`
+	Icon         string    `json:"icon"`
+	SortOrder    int       `gorm:"default:0" json:"sort_order"`
+	IsActive     bool      `gorm:"default:true" json:"is_active"`
+	Theme        string    `gorm:"size:20" json:"theme"` // Tema kuis preferensi, seperti nature atau culture
 	CreatedAt    time.Time `json:"created_at"`
 }
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// CoBooking adalah pasangan tempat wisata yang sama-sama dipesan oleh user yang sama, dihitung ulang oleh job berkala
+type CoBooking struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	WisataID        uint      `gorm:"uniqueIndex:idx_co_booking_pair;index" json:"wisata_id"`
+	RelatedWisataID uint      `gorm:"uniqueIndex:idx_co_booking_pair" json:"related_wisata_id"`
+	UserCount       int       `json:"user_count"` // Jumlah user yang memesan kedua tempat wisata
+	Score           float64   `json:"score"`      // Kemiripan cosine berdasarkan user yang memesan
+	UpdatedAt       time.Time `json:"updated_at"`
+}
@@ -0,0 +1,9 @@
+package model
+
+import "time"
+
+// DataMigration menandai backfill data yang sudah selesai agar tidak diulang setiap server mulai
+type DataMigration struct {
+	Name      string    `gorm:"primaryKey;size:100" json:"name"`
+	AppliedAt time.Time `json:"applied_at"`
+}
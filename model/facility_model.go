@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// Facility adalah master data fasilitas tempat wisata, seperti toilet, mushola atau jalur kursi roda
+type Facility struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	Name            string    `gorm:"uniqueIndex;size:100;not null" json:"name"`
+	Icon            string    `json:"icon"`
+	IsAccessibility bool      `gorm:"default:false" json:"is_accessibility"` // Fasilitas aksesibilitas untuk penyandang disabilitas
+	CreatedAt       time.Time `json:"created_at"`
+}
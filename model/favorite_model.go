@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// Favorite adalah tempat wisata yang disimpan user ke daftar favoritnya
+type Favorite struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"uniqueIndex:idx_favorite_user_wisata" json:"user_id"`
+	WisataID  uint       `gorm:"uniqueIndex:idx_favorite_user_wisata;index" json:"wisata_id"`
+	Wisata    Wisata     `gorm:"foreignKey:WisataID" json:"wisata"`
+	CreatedAt *time.Time `json:"created_at"`
+}
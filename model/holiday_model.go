@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// Holiday adalah hari libur nasional yang dipakai untuk memperkirakan keramaian tempat wisata
+type Holiday struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	Date      string     `gorm:"uniqueIndex;size:10" json:"date"` // Format YYYY-MM-DD
+	Name      string     `json:"name"`
+	CreatedAt *time.Time `json:"created_at"`
+}
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// Itinerary adalah rencana perjalanan beberapa hari milik user
+type Itinerary struct {
+	ID            uint            `gorm:"primaryKey" json:"id"`
+	UserID        uint            `gorm:"index" json:"user_id"`
+	Name          string          `json:"name"`
+	StartDate     string          `gorm:"size:10" json:"start_date"` // Format YYYY-MM-DD
+	Days          int             `json:"days"`
+	TransportMode string          `gorm:"default:car" json:"transport_mode"`
+	ShareToken    *string         `gorm:"uniqueIndex;size:64" json:"share_token,omitempty"` // Kosong jika itinerary tidak dibagikan
+	Stops         []ItineraryStop `gorm:"foreignKey:ItineraryID" json:"stops"`
+	CreatedAt     *time.Time      `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// ItineraryStop adalah satu tempat wisata yang dikunjungi pada hari tertentu dalam itinerary
+type ItineraryStop struct {
+	ID              uint   `gorm:"primaryKey" json:"id"`
+	ItineraryID     uint   `gorm:"index" json:"itinerary_id"`
+	Day             int    `json:"day"`      // Hari ke-, dimulai dari 1
+	Position        int    `json:"position"` // Urutan kunjungan pada hari tersebut
+	WisataID        uint   `json:"wisata_id"`
+	Wisata          Wisata `gorm:"foreignKey:WisataID" json:"wisata"`
+	TicketID        *uint  `json:"ticket_id"`                // Tiket yang sudah dipesan untuk kunjungan ini
+	StartTime       string `gorm:"size:5" json:"start_time"` // Format HH:MM pada zona waktu wisata
+	DurationMinutes int    `json:"duration_minutes"`
+	Notes           string `json:"notes"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// Media adalah foto atau video pada galeri tempat wisata
+type Media struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	WisataID   uint       `gorm:"index" json:"wisata_id"`
+	Type       string     `gorm:"size:10;default:photo" json:"type"` // photo atau video
+	URL        string     `json:"url"`
+	ObjectName string     `json:"-"` // Nama objek di GCS, kosong untuk link video eksternal
+	Caption    string     `json:"caption"`
+	AltText    string     `json:"alt_text"`
+	IsCover    bool       `gorm:"default:false" json:"is_cover"`
+	SortOrder  int        `json:"order"`
+	CreatedAt  *time.Time `json:"created_at"`
+}
@@ -14,4 +14,8 @@ type Notification struct {
 	CreatedAt     *time.Time `json:"created_at"`
 	IsRead        bool       `json:"is_read"`
 	PromoID       uint       `json:"promo_id"` // Add this line
+	WisataID      uint       `json:"wisata_id"`
+
+	// Jenis notifikasi untuk label di aplikasi, kosong pada notifikasi lama
+	Type string `gorm:"size:20" json:"type"`
 }
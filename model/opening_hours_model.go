@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// OpeningHour adalah jam buka mingguan tempat wisata, satu hari boleh memiliki lebih dari satu rentang jam
+type OpeningHour struct {
+	ID        uint   `gorm:"primaryKey" json:"id"`
+	WisataID  uint   `gorm:"index" json:"wisata_id"`
+	DayOfWeek int    `json:"day_of_week"`              // 0 = Minggu sampai 6 = Sabtu
+	OpenTime  string `gorm:"size:5" json:"open_time"`  // Format HH:MM pada zona waktu wisata
+	CloseTime string `gorm:"size:5" json:"close_time"` // Format HH:MM, lebih kecil dari open_time berarti tutup lewat tengah malam
+}
+
+// WisataClosure adalah pengecualian jadwal pada tanggal tertentu seperti hari libur, perawatan atau jam khusus
+type WisataClosure struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	WisataID  uint       `gorm:"index:idx_closure_wisata_date" json:"wisata_id"`
+	Date      string     `gorm:"size:10;index:idx_closure_wisata_date" json:"date"` // Format YYYY-MM-DD pada zona waktu wisata
+	IsClosed  bool       `json:"is_closed"`
+	OpenTime  string     `gorm:"size:5" json:"open_time"` // Jam khusus jika is_closed bernilai false
+	CloseTime string     `gorm:"size:5" json:"close_time"`
+	Reason    string     `json:"reason"`
+	CreatedAt *time.Time `json:"created_at"`
+}
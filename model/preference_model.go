@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// UserCategoryPreference adalah kategori kesukaan user beserta bobotnya, satu user boleh memiliki banyak kategori
+type UserCategoryPreference struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     uint      `gorm:"uniqueIndex:idx_user_category_preference" json:"user_id"`
+	CategoryID uint      `gorm:"uniqueIndex:idx_user_category_preference;index" json:"category_id"`
+	Category   Category  `gorm:"foreignKey:CategoryID" json:"category"`
+	Weight     float64   `json:"weight"`
+	Source     string    `gorm:"size:20" json:"source"` // quiz atau profile
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// UserPreferenceQuiz adalah jawaban kuis preferensi saat onboarding
+type UserPreferenceQuiz struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	UserID      uint      `gorm:"uniqueIndex" json:"user_id"`
+	Nature      int       `json:"nature"`                      // Minat wisata alam, 0..5
+	Culture     int       `json:"culture"`                     // Minat wisata budaya, 0..5
+	Budget      string    `gorm:"size:10" json:"budget"`       // low, medium atau high
+	TravelStyle string    `gorm:"size:20" json:"travel_style"` // adventure, relaxation, family atau culinary
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"gorm.io/gorm"
+	"time"
+)
 
 type Promo struct {
 	ID                   uint      `gorm:"primaryKey" json:"id"`
@@ -14,4 +17,12 @@ type Promo struct {
 	Deskripsi            string    `json:"deskripsi"`
 	Peraturan            string    `json:"peraturan"`
 	CreatedAt            time.Time `json:"created_at"`
+
+	// Terisi saat promo diarsipkan, dihapus permanen oleh job purge
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+
+	// Voucher pribadi seperti hadiah referral hanya dapat dipakai pemiliknya dan tidak tampil di daftar promo publik
+	OwnerUserID *uint      `gorm:"index" json:"owner_user_id,omitempty"`
+	SingleUse   bool       `json:"single_use"`
+	UsedAt      *time.Time `json:"used_at,omitempty"`
 }
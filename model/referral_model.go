@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type Referral struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	ReferrerID   uint       `gorm:"index" json:"referrer_id"`
+	RefereeID    uint       `gorm:"uniqueIndex" json:"referee_id"`
+	ReferralCode string     `gorm:"size:20" json:"referral_code"`
+	RefereePhone string     `gorm:"index;size:255" json:"referee_phone"`
+	RefereeEmail string     `gorm:"index;size:255" json:"referee_email"` // Dinormalisasi, dipakai untuk signup Google yang tidak memiliki nomor telepon asli
+	DeviceID     string     `gorm:"index;size:255" json:"device_id"`
+	Status       string     `gorm:"default:pending" json:"status"` // pending, rewarded
+	RewardType   string     `json:"reward_type"`                   // points atau voucher
+	RewardedAt   *time.Time `json:"rewarded_at"`
+	CreatedAt    *time.Time `json:"created_at"`
+}
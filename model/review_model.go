@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+type Review struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	WisataID   uint       `gorm:"uniqueIndex:idx_review_user_wisata;index" json:"wisata_id"`
+	UserID     uint       `gorm:"uniqueIndex:idx_review_user_wisata" json:"user_id"`
+	TicketID   uint       `json:"ticket_id"` // Tiket yang membuktikan user pernah berkunjung
+	Rating     int        `json:"rating"`
+	Text       string     `gorm:"type:text" json:"text"`
+	Photos     string     `gorm:"type:json" json:"photos"`
+	IsHidden   bool       `gorm:"default:false" json:"is_hidden"`
+	IsFlagged  bool       `gorm:"default:false" json:"is_flagged"`
+	FlagReason string     `json:"flag_reason"`
+	AdminReply string     `gorm:"type:text" json:"admin_reply"`
+	RepliedAt  *time.Time `json:"replied_at"`
+	CreatedAt  *time.Time `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
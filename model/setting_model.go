@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// Setting menyimpan konfigurasi aplikasi yang dapat diubah admin dalam bentuk key-value
+type Setting struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Key       string    `gorm:"uniqueIndex;size:100" json:"key"`
+	Value     string    `json:"value"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
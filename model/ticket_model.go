@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"gorm.io/gorm"
+	"time"
+)
 
 type Ticket struct {
 	ID                       uint       `gorm:"primaryKey" json:"id"`
@@ -17,6 +20,10 @@ type Ticket struct {
 	CreatedAt                *time.Time `json:"created_at"`
 	UpdatedAt                time.Time
 	CarbonFootprint          float64    `json:"carbon_footprint"`
+	TransportMode            string     `gorm:"default:car" json:"transport_mode"`
+	EmissionFactor           float64    `json:"emission_factor"`                  // Faktor emisi (gram CO2/km) yang dipakai saat pemesanan
+	TravelDistance           float64    `json:"travel_distance"`                  // Jarak perjalanan dalam km
+	CarbonOffsetAmount       int        `json:"carbon_offset_amount"`             // Biaya offset karbon yang ditambahkan ke total
 	PaidStatus               bool       `gorm:"default:false" json:"paid_status"` // Tambahkan kolom ini dengan default false
 	StatusOrder              string     `gorm:"default:pending" json:"status_order"`
 	TenggatPembayaran        *time.Time `json:"tenggat_pembayaran"`
@@ -24,4 +31,7 @@ type Ticket struct {
 	TotalPotonganPoints      int        `json:"total_potongan_points"`
 	HargaSebelumDiskon       int        `json:"harga_sebelum_diskon"`
 	UsedPointsOnPurchase     int        `json:"used_points_on_purchase"`
+
+	// Terisi saat tiket diarsipkan, dihapus permanen oleh job purge
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
 }
@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+// Terjemahan konten per locale. Konten asli pada tabel utama dianggap sebagai locale bawaan (id).
+
+type WisataTranslation struct {
+	ID                uint      `gorm:"primaryKey" json:"id"`
+	WisataID          uint      `gorm:"uniqueIndex:idx_wisata_translation_locale" json:"wisata_id"`
+	Locale            string    `gorm:"uniqueIndex:idx_wisata_translation_locale;size:16" json:"locale"`
+	Title             string    `json:"title"`
+	Description       string    `json:"description"`
+	DescriptionIsOpen string    `json:"description_is_open"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+type PromoTranslation struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	PromoID   uint      `gorm:"uniqueIndex:idx_promo_translation_locale" json:"promo_id"`
+	Locale    string    `gorm:"uniqueIndex:idx_promo_translation_locale;size:16" json:"locale"`
+	Title     string    `json:"title"`
+	Deskripsi string    `json:"deskripsi"`
+	Peraturan string    `json:"peraturan"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CategoryTranslation struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	CategoryID   uint      `gorm:"uniqueIndex:idx_category_translation_locale" json:"category_id"`
+	Locale       string    `gorm:"uniqueIndex:idx_category_translation_locale;size:16" json:"locale"`
+	CategoryName string    `json:"category_name"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type TermConditionTranslation struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	TermConditionID uint      `gorm:"uniqueIndex:idx_term_condition_translation_locale" json:"term_condition_id"`
+	Locale          string    `gorm:"uniqueIndex:idx_term_condition_translation_locale;size:16" json:"locale"`
+	Name            string    `json:"tnc_name"`
+	Description     string    `json:"description"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"gorm.io/gorm"
+	"time"
+)
 
 // nambahin foto profil
 
@@ -24,6 +27,15 @@ type User struct {
 	CategoryKesukaan  string     `json:"category_kesukaan"`
 	ConfirmPassword   string     `json:"confirm_password"`
 	StatusCategory    bool       `gorm:"default:false" json:"status_category"`
+	ReferralCode      string     `gorm:"index;size:20" json:"referral_code"` // Kode referral milik user
+	ReferrerCode      string     `gorm:"-" json:"referrer_code"`             // Kode referral teman saat signup
+	DeviceID          string     `gorm:"index;size:255" json:"device_id"`
+	LeaderboardOptOut bool       `gorm:"default:false" json:"leaderboard_opt_out"` // Tidak ditampilkan di leaderboard
+	CalendarToken     *string    `gorm:"uniqueIndex;size:64" json:"-"`             // Token rahasia untuk langganan kalender tiket
+	Locale            string     `gorm:"size:16" json:"locale"`                    // Bahasa pesan dan notifikasi, kosong berarti mengikuti Accept-Language
+
+	// Terisi saat akun diarsipkan, dihapus permanen oleh job purge
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
 }
 
 // Buat struct untuk permintaan perubahan kata sandi
@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"gorm.io/gorm"
+	"time"
+)
 
 // nambahin description is open, is open, dan maps link
 
@@ -12,9 +15,11 @@ type Wisata struct {
 	Kota              string     `json:"kota"`
 	Description       string     `json:"description"`
 	Price             int        `json:"price"`
-	Lat               float64    `json:"lat,omitempty"`  // Tambahkan Lat (Latitude)
-	Long              float64    `json:"long,omitempty"` // Tambahkan Long (Longitude)
-	UserID            uint       `json:"user_id"`        // ID pengguna yang membuat event
+	Lat               float64    `json:"lat,omitempty"`                            // Tambahkan Lat (Latitude)
+	Long              float64    `json:"long,omitempty"`                           // Tambahkan Long (Longitude)
+	Geohash           string     `gorm:"index;size:12" json:"geohash"`             // Geohash dari Lat/Long untuk pencarian lokasi
+	Distance          *float64   `gorm:"->;-:migration" json:"distance,omitempty"` // Jarak (km) dari titik pencarian, hanya terisi saat pencarian lokasi
+	UserID            uint       `json:"user_id"`                                  // ID pengguna yang membuat event
 	AvailableTickets  int        `json:"available_tickets"`
 	PhotoWisata1      string     `json:"photo_wisata1"`
 	PhotoWisata2      string     `json:"photo_wisata2"`
@@ -25,9 +30,17 @@ type Wisata struct {
 	IsOpen            bool       `json:"is_open"`             // Menambahkan isopen boolean
 	DescriptionIsOpen string     `json:"description_is_open"` // menambahkan deskripsi isopen
 	Fasilitas         string     `gorm:"type:json" json:"fasilitas"`
+	Facilities        []Facility `gorm:"many2many:wisata_facilities" json:"facilities,omitempty"`
 	VideoLink         string     `json:"video_link"`
+	AverageRating     float64    `gorm:"index" json:"average_rating"` // Rata-rata rating dari review yang tidak disembunyikan
+	RatingCount       int        `json:"rating_count"`
+	TimeZone          string     `gorm:"size:64;default:Asia/Jakarta" json:"time_zone"` // Zona waktu IANA untuk jam buka
+	Media             []Media    `gorm:"foreignKey:WisataID" json:"media,omitempty"`    // Galeri foto dan video, hanya dimuat di detail wisata
 	CreatedAt         *time.Time `json:"created_at"`
 	UpdatedAt         time.Time
+
+	// Terisi saat wisata diarsipkan, dihapus permanen oleh job purge
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
 }
 
 // Metode untuk mengurangi jumlah tiket yang tersedia
@@ -0,0 +1,127 @@
+package recommend
+
+import (
+	"sort"
+	"time"
+)
+
+// Booking adalah satu pemesanan historis yang dipakai untuk evaluasi
+type Booking struct {
+	UserID   uint
+	WisataID uint
+	BookedAt time.Time
+}
+
+// UserSignals berisi sinyal user di luar riwayat pemesanan
+type UserSignals struct {
+	PreferredCategories map[uint]float64
+	FavoriteIDs         []uint
+	Lat                 float64
+	Long                float64
+	HasLocation         bool
+	MaxPrice            int
+}
+
+// Evaluation adalah hasil evaluasi offline hit-rate@K
+type Evaluation struct {
+	K         int     `json:"k"`
+	Users     int     `json:"users"`
+	Hits      int     `json:"hits"`
+	HitRate   float64 `json:"hit_rate"`
+	ColdStart int     `json:"cold_start_users"`
+	ColdHits  int     `json:"cold_start_hits"`
+}
+
+// Evaluate menghitung hit-rate@K dengan menyisihkan pemesanan terakhir setiap user (leave-last-out).
+// Profil dan popularitas dibangun hanya dari pemesanan sebelumnya, lalu dihitung apakah tempat wisata yang disisihkan masuk K teratas.
+func Evaluate(bookings []Booking, candidates []Candidate, signals map[uint]UserSignals, weights Weights, k int) Evaluation {
+	evaluation := Evaluation{K: k}
+	if k <= 0 {
+		return evaluation
+	}
+
+	catalog := make(map[uint]Candidate, len(candidates))
+	for _, candidate := range candidates {
+		catalog[candidate.ID] = candidate
+	}
+
+	byUser := make(map[uint][]Booking)
+	for _, booking := range bookings {
+		if _, ok := catalog[booking.WisataID]; ok {
+			byUser[booking.UserID] = append(byUser[booking.UserID], booking)
+		}
+	}
+
+	userIDs := make([]uint, 0, len(byUser))
+	for userID := range byUser {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Slice(userIDs, func(i, j int) bool { return userIDs[i] < userIDs[j] })
+
+	// Popularitas dihitung ulang dari pemesanan latih saja, pemesanan yang disisihkan tidak boleh ikut menaikkan skor
+	popularity := make(map[uint]float64, len(catalog))
+	for _, userID := range userIDs {
+		history := byUser[userID]
+		sort.SliceStable(history, func(i, j int) bool { return history[i].BookedAt.Before(history[j].BookedAt) })
+		for _, booking := range history[:len(history)-1] {
+			popularity[booking.WisataID]++
+		}
+	}
+	trainingCandidates := make([]Candidate, len(candidates))
+	for i, candidate := range candidates {
+		candidate.Popularity = popularity[candidate.ID]
+		trainingCandidates[i] = candidate
+	}
+
+	for _, userID := range userIDs {
+		history := byUser[userID]
+		heldOut := history[len(history)-1]
+
+		var bookedIDs []uint
+		for _, booking := range history[:len(history)-1] {
+			bookedIDs = append(bookedIDs, booking.WisataID)
+		}
+
+		// Favorit pada tempat wisata yang disisihkan tidak dipakai agar hasil evaluasi tidak bocor
+		signal := signals[userID]
+		var favoriteIDs []uint
+		for _, id := range signal.FavoriteIDs {
+			if id != heldOut.WisataID {
+				favoriteIDs = append(favoriteIDs, id)
+			}
+		}
+
+		profile := NewProfile(signal.PreferredCategories, bookedIDs, favoriteIDs, catalog)
+		if signal.HasLocation {
+			profile = profile.WithLocation(signal.Lat, signal.Long)
+		}
+		profile = profile.WithBudget(signal.MaxPrice)
+
+		hit := false
+		for i, scored := range Rank(profile, trainingCandidates, weights) {
+			if i >= k {
+				break
+			}
+			if scored.ID == heldOut.WisataID {
+				hit = true
+				break
+			}
+		}
+
+		evaluation.Users++
+		if profile.IsColdStart() {
+			evaluation.ColdStart++
+		}
+		if hit {
+			evaluation.Hits++
+			if profile.IsColdStart() {
+				evaluation.ColdHits++
+			}
+		}
+	}
+
+	if evaluation.Users > 0 {
+		evaluation.HitRate = float64(evaluation.Hits) / float64(evaluation.Users)
+	}
+	return evaluation
+}
@@ -0,0 +1,174 @@
+package recommend
+
+import (
+	"math"
+	"myproject/carbon"
+	"sort"
+)
+
+// Jarak (km) saat skor kedekatan lokasi turun menjadi sekitar 37%
+const distanceDecayKm = 50.0
+
+// Pengali skor untuk tempat wisata yang sudah pernah dipesan agar feed tetap menampilkan tempat baru
+const bookedPenalty = 0.5
+
+// Pengali skor untuk tempat wisata dengan harga di atas anggaran user
+const overBudgetPenalty = 0.6
+
+// Candidate adalah tempat wisata yang bisa direkomendasikan
+type Candidate struct {
+	ID         uint
+	CategoryID uint
+	City       string
+	Lat        float64
+	Long       float64
+	Price      int
+	Popularity float64 // Jumlah tiket terbayar
+}
+
+// Weights mengatur kontribusi setiap sinyal terhadap skor akhir
+type Weights struct {
+	Category   float64
+	History    float64
+	Favorite   float64
+	Distance   float64
+	Popularity float64
+}
+
+// DefaultWeights adalah bobot bawaan untuk feed beranda
+var DefaultWeights = Weights{
+	Category:   0.35,
+	History:    0.15,
+	Favorite:   0.2,
+	Distance:   0.15,
+	Popularity: 0.15,
+}
+
+// ColdStartWeights dipakai untuk user yang belum memiliki preferensi, riwayat pemesanan maupun favorit
+var ColdStartWeights = Weights{
+	Distance:   0.4,
+	Popularity: 0.6,
+}
+
+// Profile berisi sinyal ketertarikan seorang user
+type Profile struct {
+	CategoryWeights map[uint]float64 // Afinitas kategori, 0..1
+	CityAffinity    map[string]float64
+	Booked          map[uint]int
+	Favorites       map[uint]bool
+	Lat             float64
+	Long            float64
+	HasLocation     bool
+	MaxPrice        int // Anggaran maksimal per tiket, 0 berarti tanpa batas
+}
+
+// Scored adalah hasil peringkat satu tempat wisata
+type Scored struct {
+	ID    uint
+	Score float64
+}
+
+// NewProfile menyusun profil dari kategori preferensi, riwayat pemesanan dan favorit user.
+// bookedIDs boleh berisi ID yang sama lebih dari sekali jika user berkunjung berulang kali.
+func NewProfile(preferredCategories map[uint]float64, bookedIDs, favoriteIDs []uint, catalog map[uint]Candidate) Profile {
+	profile := Profile{
+		CategoryWeights: make(map[uint]float64),
+		CityAffinity:    make(map[string]float64),
+		Booked:          make(map[uint]int),
+		Favorites:       make(map[uint]bool),
+	}
+
+	for categoryID, weight := range preferredCategories {
+		profile.CategoryWeights[categoryID] += weight
+	}
+	for _, id := range bookedIDs {
+		profile.Booked[id]++
+		if candidate, ok := catalog[id]; ok {
+			profile.CategoryWeights[candidate.CategoryID] += 0.5
+			profile.CityAffinity[candidate.City] += 1
+		}
+	}
+	for _, id := range favoriteIDs {
+		profile.Favorites[id] = true
+		if candidate, ok := catalog[id]; ok {
+			profile.CategoryWeights[candidate.CategoryID] += 0.75
+			profile.CityAffinity[candidate.City] += 0.5
+		}
+	}
+
+	normalize(profile.CategoryWeights)
+	normalize(profile.CityAffinity)
+	return profile
+}
+
+func normalize[K comparable](values map[K]float64) {
+	var max float64
+	for _, value := range values {
+		max = math.Max(max, value)
+	}
+	if max == 0 {
+		return
+	}
+	for key, value := range values {
+		values[key] = value / max
+	}
+}
+
+// WithLocation menambahkan lokasi user untuk sinyal jarak
+func (p Profile) WithLocation(lat, long float64) Profile {
+	p.Lat, p.Long, p.HasLocation = lat, long, true
+	return p
+}
+
+// WithBudget menambahkan anggaran maksimal per tiket dari kuis preferensi
+func (p Profile) WithBudget(maxPrice int) Profile {
+	p.MaxPrice = maxPrice
+	return p
+}
+
+// IsColdStart menentukan apakah profil belum memiliki sinyal personal
+func (p Profile) IsColdStart() bool {
+	return len(p.CategoryWeights) == 0 && len(p.Booked) == 0 && len(p.Favorites) == 0
+}
+
+// Rank mengurutkan kandidat dari skor tertinggi, profil cold start memakai ColdStartWeights
+func Rank(profile Profile, candidates []Candidate, weights Weights) []Scored {
+	if profile.IsColdStart() {
+		weights = ColdStartWeights
+	}
+
+	var maxPopularity float64
+	for _, candidate := range candidates {
+		maxPopularity = math.Max(maxPopularity, candidate.Popularity)
+	}
+
+	scored := make([]Scored, 0, len(candidates))
+	for _, candidate := range candidates {
+		score := weights.Category*profile.CategoryWeights[candidate.CategoryID] +
+			weights.History*profile.CityAffinity[candidate.City]
+		if profile.Favorites[candidate.ID] {
+			score += weights.Favorite
+		}
+		if profile.HasLocation {
+			score += weights.Distance * math.Exp(-carbon.Distance(profile.Lat, profile.Long, candidate.Lat, candidate.Long)/distanceDecayKm)
+		}
+		if maxPopularity > 0 {
+			score += weights.Popularity * math.Log1p(candidate.Popularity) / math.Log1p(maxPopularity)
+		}
+		if profile.Booked[candidate.ID] > 0 {
+			score *= bookedPenalty
+		}
+		if profile.MaxPrice > 0 && candidate.Price > profile.MaxPrice {
+			score *= overBudgetPenalty
+		}
+		scored = append(scored, Scored{ID: candidate.ID, Score: score})
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].Score != scored[j].Score {
+			return scored[i].Score > scored[j].Score
+		}
+		return scored[i].ID < scored[j].ID
+	})
+	return scored
+}
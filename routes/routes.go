@@ -4,6 +4,8 @@ import (
 	"io/ioutil"
 	"log"
 	"myproject/controllers"
+	"myproject/helper"
+	"myproject/middleware"
 	"net/http"
 	"os"
 
@@ -31,6 +33,19 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	e.Use(Logger())
 	secretKey := []byte(getSecretKeyFromEnv())
 
+	// Pesan error diterjemahkan sesuai locale user atau header Accept-Language
+	e.JSONSerializer = helper.LocalizedJSONSerializer{}
+	e.Use(middleware.Locale(db, secretKey))
+
+	// Membangun index pencarian tempat wisata dari database, lalu membangunnya ulang secara berkala
+	controllers.StartSearchIndexJob(db)
+
+	// Menghitung tempat wisata yang sering dipesan bersamaan secara berkala
+	controllers.StartCoBookingJob(db)
+
+	// Menghapus permanen data terarsip yang sudah melewati masa retensi
+	controllers.StartArchivePurgeJob(db)
+
 	//Integrate with OAuth Google Account
 	e.GET("/auth/google/initiate", controllers.GoogleAuthInitiate)
 	e.GET("/auth/google/callback", controllers.GoogleAuthCallback(db, secretKey))
@@ -41,10 +56,21 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	e.GET("/verify", controllers.VerifyEmail(db))                                                       // Verify email - Email
 	e.GET("/categories", controllers.GetCategories(db, secretKey))                                      // Menampilkan seluruh category yang tersedia
 	e.GET("/tourism-attractions", controllers.GetWisatas(db, secretKey))                                // Menampilkan seluruh tempat wisata yang ada - CMS & Mobile
+	e.GET("/tourism-attractions/autocomplete", controllers.GetWisataAutocomplete(db, secretKey))        // Menampilkan saran autocomplete pencarian tempat wisata - Mobile
 	e.GET("/tourism-attractions/:id", controllers.GetWisataByID(db, secretKey))                         // Menampilkan detail tempat wisata berdasarkan id nya - CMS & Mobile
 	e.GET("/carbonfootprints/:wisata_id", controllers.GetTotalCarbonFootprintByWisataID(db, secretKey)) // Menampilkan total carbon footprint pada detail tempat wisata
 	e.GET("/promos", controllers.GetPromos(db, secretKey))                                              // Menampilkan seluruh promo yang tersedia - CMS & Mobile
 	e.GET("/promos/:id", controllers.GetPromoByID(db, secretKey))                                       // Menampilkan data detail promo yang tersedia - CMS & Mobile
+	e.GET("/tourism-attractions/:id/reviews", controllers.GetWisataReviews(db, secretKey))              // Menampilkan review dan rating tempat wisata - CMS & Mobile
+	e.GET("/tourism-attractions/:id/opening-hours", controllers.GetOpeningHours(db, secretKey))         // Menampilkan jam buka, hari libur dan status buka tempat wisata - CMS & Mobile
+	e.GET("/facilities", controllers.GetFacilities(db, secretKey))                                      // Menampilkan katalog fasilitas beserta jumlah tempat wisata - CMS & Mobile
+	e.GET("/tourism-attractions/:id/similar", controllers.GetSimilarWisata(db, secretKey))              // Menampilkan tempat wisata serupa berdasarkan kategori, kota, fasilitas dan harga - Mobile
+	e.GET("/tourism-attractions/:id/also-booked", controllers.GetAlsoBookedWisata(db, secretKey))       // Menampilkan tempat wisata yang juga dipesan user lain - Mobile
+	e.GET("/tourism-attractions/:id/crowd", controllers.GetCrowdForecast(db, secretKey))                // Menampilkan perkiraan keramaian tempat wisata pada tanggal tertentu - Mobile
+	e.GET("/tourism-attractions/:id/availability", controllers.GetWisataAvailability(db, secretKey))    // Menampilkan ketersediaan tiket, status buka dan perkiraan keramaian per tanggal - Mobile
+	e.GET("/shared/itineraries/:token", controllers.GetSharedItinerary(db))                             // Menampilkan itinerary yang dibagikan melalui link publik
+	e.GET("/shared/itineraries/:token/calendar.ics", controllers.ExportSharedItineraryICS(db))          // Mengunduh itinerary yang dibagikan sebagai file kalender ICS
+	e.GET("/calendar/:token/tickets.ics", controllers.GetCalendarFeedICS(db))                           // Feed kalender check-in mendatang user berdasarkan token rahasia
 
 	//Landing Page
 	e.POST("/cooperations", controllers.CreateCooperationMessage(db)) // Mengirimkan pesan kepada destimate dari landingpage
@@ -65,6 +91,7 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	e.POST("/categories", controllers.CreateCategoryByAdmin(db, secretKey))               // Membuat category baru oleh user - CMS
 	e.PUT("/categories/:id", controllers.UpdateCategoryByAdmin(db, secretKey))            // Mengupdate category yang sudah ada - CMS
 	e.DELETE("/categories/:id", controllers.DeleteCategoryByAdmin(db, secretKey))         // Menghapus category yang sudah ada - CMS
+	e.POST("/categories/:id/merge", controllers.MergeCategoryByAdmin(db, secretKey))      // Menggabungkan category ke category lain beserta wisata dan user-nya - CMS
 	e.POST("/tourism-attractions", controllers.CreateWisata(db, secretKey))               // Menambahkan tempat wisata baru - CMS
 	e.PUT("/tourism-attractions/:id", controllers.UpdateWisata(db, secretKey))            // Mengedit data tempat wisata yang sudah ada - CMS
 	e.DELETE("/tourism-attractions/:id", controllers.DeleteWisata(db, secretKey))         // Menghapus tempat wisata yang sudah ada - CMS
@@ -81,6 +108,51 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	e.GET("/terms-and-conditions/:id", controllers.GetTermConditionByID(db, secretKey))   // Melihat detail  data term and condition - CMS
 	e.DELETE("/terms-and-conditions/:id", controllers.DeleteTermCondition(db, secretKey)) // Menghapus term and condition yang ada - CMS
 	e.GET("/cooperations", controllers.GetCooperationMessagesByAdmin(db, secretKey))      // Mendapatkan pesan yang user kirim dari landing page
+	e.GET("/admins/referrals", controllers.GetReferralStatsByAdmin(db, secretKey))        // Menampilkan statistik program referral - CMS
+
+	//Arsip CMS
+	e.PUT("/admins/users/:id/restore", controllers.RestoreUserByAdmin(db, secretKey))          // Memulihkan akun user atau admin yang diarsipkan - CMS
+	e.PUT("/tourism-attractions/:id/restore", controllers.RestoreWisataByAdmin(db, secretKey)) // Memulihkan tempat wisata yang diarsipkan - CMS
+	e.PUT("/promos/:id/restore", controllers.RestorePromoByAdmin(db, secretKey))               // Memulihkan promo yang diarsipkan - CMS
+	e.PUT("/tickets/:invoice_number/restore", controllers.RestoreTicketByAdmin(db, secretKey)) // Memulihkan ticket transaksi yang diarsipkan - CMS
+
+	//Carbon Offset CMS
+	e.GET("/admins/carbon-offsets", controllers.GetCarbonOffsetsByAdmin(db, secretKey))                  // Menampilkan ledger offset karbon per user dan per wisata - CMS
+	e.GET("/admins/settings/carbon-offset", controllers.GetCarbonOffsetSettingByAdmin(db, secretKey))    // Menampilkan harga offset karbon per ton - CMS
+	e.PUT("/admins/settings/carbon-offset", controllers.UpdateCarbonOffsetSettingByAdmin(db, secretKey)) // Mengubah harga offset karbon per ton - CMS
+
+	//Review CMS
+	e.GET("/admins/reviews", controllers.GetReviewsByAdmin(db, secretKey))         // Menampilkan seluruh review termasuk yang disembunyikan dan ditandai - CMS
+	e.PUT("/admins/reviews/:id", controllers.ModerateReviewByAdmin(db, secretKey)) // Menyembunyikan, menandai atau membalas review - CMS
+
+	//Opening Hours CMS
+	e.PUT("/tourism-attractions/:id/opening-hours", controllers.UpdateOpeningHoursByAdmin(db, secretKey))      // Mengganti jam buka mingguan dan zona waktu tempat wisata - CMS
+	e.POST("/tourism-attractions/:id/closures", controllers.CreateClosureByAdmin(db, secretKey))               // Menambahkan hari libur, penutupan perawatan atau jam khusus - CMS
+	e.DELETE("/tourism-attractions/:id/closures/:closure_id", controllers.DeleteClosureByAdmin(db, secretKey)) // Menghapus pengecualian jadwal - CMS
+
+	//Media CMS
+	e.POST("/tourism-attractions/:id/media", controllers.AddWisataMediaByAdmin(db, secretKey))                // Menambahkan foto atau video ke galeri tempat wisata - CMS
+	e.PUT("/tourism-attractions/:id/media/order", controllers.ReorderWisataMediaByAdmin(db, secretKey))       // Mengatur ulang urutan galeri tempat wisata - CMS
+	e.PUT("/tourism-attractions/:id/media/:media_id", controllers.UpdateWisataMediaByAdmin(db, secretKey))    // Mengubah caption, alt text dan cover media - CMS
+	e.DELETE("/tourism-attractions/:id/media/:media_id", controllers.DeleteWisataMediaByAdmin(db, secretKey)) // Menghapus media dari galeri beserta file di storage - CMS
+
+	//Facility CMS
+	e.POST("/facilities", controllers.CreateFacilityByAdmin(db, secretKey))       // Menambahkan fasilitas baru ke katalog - CMS
+	e.PUT("/facilities/:id", controllers.UpdateFacilityByAdmin(db, secretKey))    // Mengubah nama, ikon dan penanda aksesibilitas fasilitas - CMS
+	e.DELETE("/facilities/:id", controllers.DeleteFacilityByAdmin(db, secretKey)) // Menghapus fasilitas beserta relasinya ke tempat wisata - CMS
+
+	//Recommendation CMS
+	e.GET("/admins/recommendations/evaluation", controllers.GetRecommendationEvaluationByAdmin(db, secretKey)) // Mengukur hit-rate rekomendasi terhadap pemesanan terakhir tiap user - CMS
+
+	//Holiday CMS
+	e.GET("/admins/holidays", controllers.GetHolidaysByAdmin(db, secretKey))          // Menampilkan kalender hari libur - CMS
+	e.POST("/admins/holidays", controllers.CreateHolidayByAdmin(db, secretKey))       // Menambahkan hari libur untuk perkiraan keramaian - CMS
+	e.DELETE("/admins/holidays/:id", controllers.DeleteHolidayByAdmin(db, secretKey)) // Menghapus hari libur dari kalender - CMS
+
+	//Translation CMS
+	e.GET("/admins/translations/:type/:id", controllers.GetTranslationsByAdmin(db, secretKey))              // Menampilkan terjemahan wisata, promo, kategori atau syarat dan ketentuan - CMS
+	e.PUT("/admins/translations/:type/:id/:locale", controllers.UpsertTranslationByAdmin(db, secretKey))    // Menambahkan atau mengubah terjemahan untuk satu locale - CMS
+	e.DELETE("/admins/translations/:type/:id/:locale", controllers.DeleteTranslationByAdmin(db, secretKey)) // Menghapus terjemahan untuk satu locale - CMS
 
 	// Chatbot custom data untuk admin dapat bertanya terkait rekomendasi promo untuk meningkatkan penjualan
 	promoChatbotUsecase := controllers.NewPromoChatbotUsecase() // Inisialisasi use case
@@ -95,18 +167,44 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	e.PUT("/users/change-password/:id", controllers.ChangePassword(db, secretKey))                          // Mengubah password akun user - Mobile
 	e.DELETE("/users/photo/:id", controllers.DeleteUserProfilePhoto(db, secretKey))                         // Menghapus foto profile user - Mobile
 	e.PUT("/users/:id/change-location", controllers.EditUserLocation(db, secretKey))                        // Mengubah lokasi user - Mobile
-	e.GET("/users/preferences", controllers.GetWisataByCategoryKesukaan(db, secretKey))                     // Menampilkan halaman utama user sesuai wisata preferensinya saat match making diawal - Mobile
+	e.GET("/users/preferences", controllers.GetWisataByCategoryKesukaan(db, secretKey))                     // Menampilkan halaman utama user berisi rekomendasi wisata yang dipersonalisasi - Mobile
 	e.GET("/cities", controllers.GetCities(db, secretKey))                                                  // Menampilkan kota yang tersedia dari tempat wisata yang ada - Mobile
 	e.POST("/tourism-attractions/booking", controllers.BuyTicket(db, secretKey))                            // Pemesanan tiket oleh user - Mobile
 	e.POST("/tourism-attractions/booking/check", controllers.CheckTicketPrice(db, secretKey))               // Melakukan pengecekan harga saat transaksi
 	e.DELETE("/tourism-attractions/cancel/:invoice_number", controllers.CancelTicket(db, secretKey))        // Melakukan pembatalan transaksi pada order yang belum dibayar - Mobile
 	e.GET("/user/tickets", controllers.GetTicketsByUser(db, secretKey))                                     // Melihat seluruh history pemesanan yang pernah dilakukan user - Mobile
 	e.GET("/user/tickets/:invoice_number", controllers.GetTransactionHistoryByInvoiceNumber(db, secretKey)) // Menampilkan detail pemesanan sesuai dengan invoice number - Mobile
+	e.GET("/user/tickets/:invoice_number/calendar.ics", controllers.ExportTicketICS(db, secretKey))         // Mengunduh tanggal check-in pemesanan sebagai file kalender ICS - Mobile
+	e.GET("/user/tickets/:invoice_number/wallet/apple", controllers.GetAppleWalletPass(db, secretKey))      // Mengunduh e-ticket sebagai Apple Wallet pass (.pkpass) - Mobile
+	e.GET("/user/tickets/:invoice_number/wallet/google", controllers.GetGoogleWalletPass(db, secretKey))    // Membuat link Save to Google Wallet untuk e-ticket - Mobile
 	e.GET("/points", controllers.GetUserPoints(db, secretKey))                                              // Menampilkan points yang user miliki dari transaksinya
 	e.GET("/points/history", controllers.GetPointsHistory(db, secretKey))                                   // Menampilkan history points yang user miliki
 	e.GET("/user/carbonfootprint/:user_id", controllers.GetTotalCarbonFootprintByUser(db, secretKey))       // Menampilkan total karbon footprint yang user hasilkan dari semua perjalanannya
 	e.GET("/notifications", controllers.GetUserNotifications(db, secretKey))                                // Menampilkan notifikasi yang user miliki (Notifikasi berhasil bayar & Saat ada promo baru)
 	e.PUT("/notifications/:id", controllers.MarkNotificationAsRead(db, secretKey))                          // Menandai notifikasinya sudah dibaca
+	e.GET("/referrals", controllers.GetUserReferral(db, secretKey))                                         // Menampilkan kode referral dan jumlah teman yang diundang user
+	e.GET("/user/carbon-offsets", controllers.GetUserCarbonOffsets(db, secretKey))                          // Menampilkan riwayat offset karbon yang dibeli user
+	e.GET("/leaderboards/emission", controllers.GetEmissionLeaderboard(db, secretKey))                      // Menampilkan leaderboard emisi per perjalanan (mingguan, bulanan, all-time, per kota)
+	e.GET("/users/badges", controllers.GetUserBadges(db, secretKey))                                        // Menampilkan badge ramah lingkungan dan peringkat user
+	e.PUT("/users/leaderboard-privacy", controllers.UpdateLeaderboardPrivacy(db, secretKey))                // Mengatur apakah user tampil di leaderboard
+	e.GET("/user/sustainability-report", controllers.GetSustainabilityReport(db, secretKey))                // Laporan keberlanjutan tahunan user dalam format JSON atau PDF
+	e.POST("/tourism-attractions/:id/reviews", controllers.CreateReview(db, secretKey))                     // Memberikan rating, ulasan dan foto untuk wisata yang pernah dikunjungi - Mobile
+	e.GET("/users/favorites", controllers.GetFavorites(db, secretKey))                                      // Menampilkan daftar tempat wisata favorit user - Mobile
+	e.POST("/users/favorites/:wisata_id", controllers.AddFavorite(db, secretKey))                           // Menyimpan tempat wisata ke favorit user - Mobile
+	e.DELETE("/users/favorites/:wisata_id", controllers.RemoveFavorite(db, secretKey))                      // Menghapus tempat wisata dari favorit user - Mobile
+	e.GET("/itineraries", controllers.GetItineraries(db, secretKey))                                        // Menampilkan daftar itinerary perjalanan user - Mobile
+	e.POST("/itineraries", controllers.CreateItinerary(db, secretKey))                                      // Membuat itinerary perjalanan beberapa hari - Mobile
+	e.GET("/itineraries/:id", controllers.GetItineraryByID(db, secretKey))                                  // Menampilkan detail itinerary beserta estimasi jarak dan emisi karbon - Mobile
+	e.PUT("/itineraries/:id", controllers.UpdateItinerary(db, secretKey))                                   // Mengubah itinerary dan pemberhentiannya - Mobile
+	e.DELETE("/itineraries/:id", controllers.DeleteItinerary(db, secretKey))                                // Menghapus itinerary - Mobile
+	e.POST("/itineraries/:id/share", controllers.ShareItinerary(db, secretKey))                             // Membuat link publik read-only untuk itinerary - Mobile
+	e.DELETE("/itineraries/:id/share", controllers.UnshareItinerary(db, secretKey))                         // Mencabut link publik itinerary - Mobile
+	e.GET("/itineraries/:id/calendar.ics", controllers.ExportItineraryICS(db, secretKey))                   // Mengunduh itinerary sebagai file kalender ICS - Mobile
+	e.GET("/user/calendar-feed", controllers.GetCalendarFeed(db, secretKey))                                // Menampilkan URL langganan kalender check-in user - Mobile
+	e.POST("/user/calendar-feed/rotate", controllers.RotateCalendarFeed(db, secretKey))                     // Mengganti URL langganan kalender check-in user - Mobile
+	e.PUT("/users/locale", controllers.UpdateUserLocale(db, secretKey))                                     // Mengatur bahasa pesan error, notifikasi dan email user - Mobile
+	e.GET("/users/preference-quiz", controllers.GetPreferenceQuiz(db, secretKey))                           // Menampilkan kuis preferensi onboarding beserta jawaban dan kategori kesukaan user - Mobile
+	e.POST("/users/preference-quiz", controllers.SubmitPreferenceQuiz(db, secretKey))                       // Menyimpan jawaban kuis preferensi sebagai kategori kesukaan berbobot - Mobile
 
 	// Chatbot untuk user dapat bertanya dengan Debot rekomendasi tempat wisata
 	wisataUsecase := controllers.NewWisataUsecase()
@@ -0,0 +1,237 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	exactWeight  = 1.0
+	prefixWeight = 0.7
+	fuzzyWeight  = 0.6 // dikurangi lagi untuk setiap salah ketik tambahan
+
+	maxPrefixExpansions = 50
+)
+
+// MemoryIndex adalah inverted index in-memory yang aman dipakai bersamaan oleh banyak goroutine
+type MemoryIndex struct {
+	mu           sync.RWMutex
+	boosts       map[string]float64
+	suggestField string
+	postings     map[string]map[uint]map[string]int // term -> dokumen -> field -> frekuensi
+	docTerms     map[uint][]string
+	suggestions  map[uint]string
+	vocabulary   []string // daftar term terurut untuk pencocokan prefix, dibangun ulang jika dirty
+	dirty        bool
+}
+
+// NewMemoryIndex membuat index dengan bobot per field dan field yang dipakai sebagai teks autocomplete
+func NewMemoryIndex(boosts map[string]float64, suggestField string) *MemoryIndex {
+	return &MemoryIndex{
+		boosts:       boosts,
+		suggestField: suggestField,
+		postings:     make(map[string]map[uint]map[string]int),
+		docTerms:     make(map[uint][]string),
+		suggestions:  make(map[uint]string),
+	}
+}
+
+func (idx *MemoryIndex) Upsert(doc Document) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(doc.ID)
+
+	seen := make(map[string]bool)
+	for field, text := range doc.Fields {
+		for _, token := range Tokenize(text) {
+			docs, ok := idx.postings[token]
+			if !ok {
+				docs = make(map[uint]map[string]int)
+				idx.postings[token] = docs
+				idx.dirty = true
+			}
+			if docs[doc.ID] == nil {
+				docs[doc.ID] = make(map[string]int)
+			}
+			docs[doc.ID][field]++
+
+			if !seen[token] {
+				seen[token] = true
+				idx.docTerms[doc.ID] = append(idx.docTerms[doc.ID], token)
+			}
+		}
+	}
+	idx.suggestions[doc.ID] = doc.Fields[idx.suggestField]
+}
+
+func (idx *MemoryIndex) Delete(id uint) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(id)
+}
+
+// Replace mengganti seluruh isi index dengan dokumen baru, pencarian tetap dilayani dari isi lama selama index baru dibangun
+func (idx *MemoryIndex) Replace(docs []Document) {
+	fresh := NewMemoryIndex(idx.boosts, idx.suggestField)
+	for _, doc := range docs {
+		fresh.Upsert(doc)
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.postings = fresh.postings
+	idx.docTerms = fresh.docTerms
+	idx.suggestions = fresh.suggestions
+	idx.vocabulary = nil
+	idx.dirty = true
+}
+
+// remove menghapus dokumen dari posting list, pemanggil harus memegang lock tulis
+func (idx *MemoryIndex) remove(id uint) {
+	for _, term := range idx.docTerms[id] {
+		delete(idx.postings[term], id)
+		if len(idx.postings[term]) == 0 {
+			delete(idx.postings, term)
+			idx.dirty = true
+		}
+	}
+	delete(idx.docTerms, id)
+	delete(idx.suggestions, id)
+}
+
+func (idx *MemoryIndex) Search(query string, limit int) []Result {
+	tokens := Tokenize(query)
+	if len(tokens) == 0 {
+		return []Result{}
+	}
+
+	idx.prepareVocabulary()
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	totalDocs := float64(len(idx.docTerms))
+	scores := make(map[uint]float64)
+	matchedTokens := make(map[uint]int)
+
+	for _, token := range tokens {
+		tokenScores := make(map[uint]float64)
+		for term, weight := range idx.matchTerms(token) {
+			docs := idx.postings[term]
+			idf := math.Log(1 + totalDocs/float64(len(docs)))
+			for docID, fields := range docs {
+				var fieldScore float64
+				for field, freq := range fields {
+					boost, ok := idx.boosts[field]
+					if !ok {
+						boost = 1
+					}
+					fieldScore += boost * (1 + math.Log(float64(freq)))
+				}
+				// Satu token hanya dihitung dari term terbaik yang cocok di dokumen tersebut
+				tokenScores[docID] = math.Max(tokenScores[docID], weight*idf*fieldScore)
+			}
+		}
+		for docID, score := range tokenScores {
+			scores[docID] += score
+			matchedTokens[docID]++
+		}
+	}
+
+	results := make([]Result, 0, len(scores))
+	for docID, score := range scores {
+		// Dokumen yang cocok dengan lebih banyak token query lebih relevan
+		coverage := float64(matchedTokens[docID]) / float64(len(tokens))
+		results = append(results, Result{ID: docID, Score: score * coverage})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ID < results[j].ID
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+func (idx *MemoryIndex) Suggest(query string, limit int) []Suggestion {
+	results := idx.Search(query, limit)
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	suggestions := make([]Suggestion, 0, len(results))
+	for _, result := range results {
+		suggestions = append(suggestions, Suggestion{ID: result.ID, Text: idx.suggestions[result.ID]})
+	}
+	return suggestions
+}
+
+// matchTerms mengembalikan term di index yang cocok dengan token secara persis, prefix atau fuzzy beserta bobotnya
+func (idx *MemoryIndex) matchTerms(token string) map[string]float64 {
+	matches := make(map[string]float64)
+	if _, ok := idx.postings[token]; ok {
+		matches[token] = exactWeight
+	}
+
+	if len([]rune(token)) >= 2 {
+		start := sort.SearchStrings(idx.vocabulary, token)
+		for i := start; i < len(idx.vocabulary) && i-start < maxPrefixExpansions; i++ {
+			term := idx.vocabulary[i]
+			if !strings.HasPrefix(term, token) {
+				break
+			}
+			if term != token {
+				matches[term] = prefixWeight
+			}
+		}
+	}
+
+	typos := maxTypos(token)
+	if typos == 0 {
+		return matches
+	}
+	tokenLength := len([]rune(token))
+	for _, term := range idx.vocabulary {
+		if _, ok := matches[term]; ok {
+			continue
+		}
+		lengthDiff := len([]rune(term)) - tokenLength
+		if lengthDiff > typos || -lengthDiff > typos {
+			continue
+		}
+		if distance := editDistance(token, term); distance <= typos {
+			matches[term] = fuzzyWeight / float64(distance)
+		}
+	}
+	return matches
+}
+
+// prepareVocabulary membangun ulang daftar term terurut setelah ada term baru atau term yang terhapus
+func (idx *MemoryIndex) prepareVocabulary() {
+	idx.mu.RLock()
+	dirty := idx.dirty
+	idx.mu.RUnlock()
+	if !dirty {
+		return
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	vocabulary := make([]string, 0, len(idx.postings))
+	for term := range idx.postings {
+		vocabulary = append(vocabulary, term)
+	}
+	sort.Strings(vocabulary)
+	idx.vocabulary = vocabulary
+	idx.dirty = false
+}
@@ -0,0 +1,28 @@
+package search
+
+// Document adalah satu entri yang diindeks, berisi teks per field (mis. title, kota, description)
+type Document struct {
+	ID     uint
+	Fields map[string]string
+}
+
+// Result adalah dokumen yang cocok dengan query beserta skor relevansinya
+type Result struct {
+	ID    uint    `json:"id"`
+	Score float64 `json:"score"`
+}
+
+// Suggestion adalah saran autocomplete yang diambil dari field saran sebuah dokumen
+type Suggestion struct {
+	ID   uint   `json:"id"`
+	Text string `json:"text"`
+}
+
+// Index adalah antarmuka mesin pencarian, sehingga index in-memory bawaan dapat diganti dengan layanan eksternal
+type Index interface {
+	Upsert(doc Document)
+	Delete(id uint)
+	Replace(docs []Document)
+	Search(query string, limit int) []Result
+	Suggest(query string, limit int) []Suggestion
+}
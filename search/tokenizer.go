@@ -0,0 +1,73 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// stopwords berisi kata umum bahasa Indonesia yang tidak membantu relevansi
+var stopwords = map[string]bool{
+	"yang": true, "dan": true, "di": true, "ke": true, "dari": true, "ini": true, "itu": true,
+	"untuk": true, "dengan": true, "pada": true, "atau": true, "adalah": true, "juga": true,
+	"dalam": true, "akan": true, "oleh": true, "sebagai": true, "tidak": true, "ada": true,
+	"para": true, "bisa": true, "dapat": true, "serta": true, "karena": true, "sudah": true,
+	"saat": true, "kami": true, "kita": true, "anda": true, "nya": true, "pun": true,
+}
+
+// Tokenize memecah teks menjadi token huruf kecil, membuang stopword dan akhiran kepemilikan "-nya"
+func Tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make([]string, 0, len(words))
+	for _, word := range words {
+		if stopwords[word] {
+			continue
+		}
+		if strings.HasSuffix(word, "nya") && len([]rune(word)) >= 7 {
+			word = strings.TrimSuffix(word, "nya")
+		}
+		tokens = append(tokens, word)
+	}
+	return tokens
+}
+
+// editDistance menghitung jarak Damerau-Levenshtein (optimal string alignment) antara dua kata
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	rows := make([][]int, len(ra)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(rb)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(ra)][len(rb)]
+}
+
+// maxTypos menentukan jumlah salah ketik yang ditoleransi berdasarkan panjang token
+func maxTypos(token string) int {
+	switch length := len([]rune(token)); {
+	case length < 4:
+		return 0
+	case length < 8:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,166 @@
+package wallet
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"os"
+	"strings"
+)
+
+const defaultOrganizationName = "Destimate"
+
+var (
+	ErrNotConfigured   = errors.New("wallet is not configured")
+	ErrInvalidPEM      = errors.New("invalid PEM data")
+	ErrUnsupportedKey  = errors.New("unsupported private key type")
+	ErrInvalidSettings = errors.New("invalid wallet credentials")
+)
+
+// AppleConfig berisi identitas dan sertifikat penandatangan Apple Wallet pass
+type AppleConfig struct {
+	PassTypeID       string
+	TeamID           string
+	OrganizationName string
+	Certificate      *x509.Certificate // Sertifikat Pass Type ID
+	PrivateKey       crypto.Signer     // Kunci privat pasangan Certificate
+	WWDR             *x509.Certificate // Sertifikat perantara Apple WWDR
+}
+
+// GoogleConfig berisi issuer dan service account untuk menandatangani JWT Google Wallet
+type GoogleConfig struct {
+	IssuerID            string
+	OrganizationName    string
+	ServiceAccountEmail string
+	PrivateKey          *rsa.PrivateKey
+	Origins             []string // Domain yang boleh menampilkan tombol Save to Google Wallet
+}
+
+// AppleConfigFromEnv membaca konfigurasi Apple Wallet dari variabel lingkungan.
+// Sertifikat dan kunci boleh berupa PEM langsung atau PEM yang di-encode base64 seperti CREDENTIALS.
+func AppleConfigFromEnv() (AppleConfig, error) {
+	config := AppleConfig{
+		PassTypeID:       os.Getenv("APPLE_PASS_TYPE_ID"),
+		TeamID:           os.Getenv("APPLE_TEAM_ID"),
+		OrganizationName: organizationNameFromEnv(),
+	}
+	certificate := os.Getenv("APPLE_PASS_CERTIFICATE")
+	privateKey := os.Getenv("APPLE_PASS_PRIVATE_KEY")
+	wwdr := os.Getenv("APPLE_WWDR_CERTIFICATE")
+	if config.PassTypeID == "" || config.TeamID == "" || certificate == "" || privateKey == "" || wwdr == "" {
+		return config, ErrNotConfigured
+	}
+
+	var err error
+	if config.Certificate, err = ParseCertificate(decodeSecret(certificate)); err != nil {
+		return config, err
+	}
+	if config.PrivateKey, err = ParsePrivateKey(decodeSecret(privateKey)); err != nil {
+		return config, err
+	}
+	if config.WWDR, err = ParseCertificate(decodeSecret(wwdr)); err != nil {
+		return config, err
+	}
+	return config, nil
+}
+
+// GoogleConfigFromEnv membaca konfigurasi Google Wallet dari variabel lingkungan.
+// Service account diambil dari GOOGLE_WALLET_CREDENTIALS, jika kosong memakai CREDENTIALS yang juga dipakai GCS.
+func GoogleConfigFromEnv() (GoogleConfig, error) {
+	config := GoogleConfig{
+		IssuerID:         os.Getenv("GOOGLE_WALLET_ISSUER_ID"),
+		OrganizationName: organizationNameFromEnv(),
+	}
+	credentials := os.Getenv("GOOGLE_WALLET_CREDENTIALS")
+	if credentials == "" {
+		credentials = os.Getenv("CREDENTIALS")
+	}
+	if config.IssuerID == "" || credentials == "" {
+		return config, ErrNotConfigured
+	}
+	for _, origin := range strings.Split(os.Getenv("GOOGLE_WALLET_ORIGINS"), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			config.Origins = append(config.Origins, origin)
+		}
+	}
+
+	var serviceAccount struct {
+		ClientEmail string `json:"client_email"`
+		PrivateKey  string `json:"private_key"`
+	}
+	if err := json.Unmarshal(decodeSecret(credentials), &serviceAccount); err != nil || serviceAccount.ClientEmail == "" {
+		return config, ErrInvalidSettings
+	}
+	key, err := ParsePrivateKey([]byte(serviceAccount.PrivateKey))
+	if err != nil {
+		return config, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return config, ErrUnsupportedKey
+	}
+	config.ServiceAccountEmail = serviceAccount.ClientEmail
+	config.PrivateKey = rsaKey
+	return config, nil
+}
+
+func organizationNameFromEnv() string {
+	if name := os.Getenv("WALLET_ORGANIZATION_NAME"); name != "" {
+		return name
+	}
+	return defaultOrganizationName
+}
+
+// decodeSecret menerima nilai PEM/JSON langsung atau yang di-encode base64
+func decodeSecret(value string) []byte {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "-----BEGIN") || strings.HasPrefix(value, "{") {
+		return []byte(value)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return []byte(value)
+	}
+	return decoded
+}
+
+// ParseCertificate membaca sertifikat X.509 berformat PEM
+func ParseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, ErrInvalidPEM
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// ParsePrivateKey membaca kunci privat RSA atau ECDSA berformat PEM (PKCS#1, PKCS#8 atau SEC 1)
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidPEM
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		switch key := key.(type) {
+		case *rsa.PrivateKey:
+			return key, nil
+		case *ecdsa.PrivateKey:
+			return key, nil
+		}
+	}
+	return nil, ErrUnsupportedKey
+}
@@ -0,0 +1,96 @@
+package wallet
+
+import (
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	"regexp"
+	"time"
+)
+
+// Prefix URL tombol Save to Google Wallet, JWT ditambahkan di belakangnya
+const googleSaveURLPrefix = "https://pay.google.com/gp/v/save/"
+
+// ID object dan class Google Wallet hanya boleh berisi huruf, angka, '.', '_' dan '-'
+var googleIDSanitizer = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+type googleLocalizedString struct {
+	DefaultValue googleTranslatedString `json:"defaultValue"`
+}
+
+type googleTranslatedString struct {
+	Language string `json:"language"`
+	Value    string `json:"value"`
+}
+
+func localized(value string) googleLocalizedString {
+	return googleLocalizedString{DefaultValue: googleTranslatedString{Language: "id", Value: value}}
+}
+
+// GoogleSaveJWT membuat JWT "savetowallet" berisi class per wisata dan object per tiket beserta URL Save to Google Wallet.
+// JWT ditandatangani secara lokal dengan kunci service account, tanpa memanggil Google Wallet API.
+func GoogleSaveJWT(config GoogleConfig, ticket Ticket) (string, string, error) {
+	if config.IssuerID == "" || config.PrivateKey == nil {
+		return "", "", ErrNotConfigured
+	}
+
+	classID := fmt.Sprintf("%s.wisata_%d", config.IssuerID, ticket.WisataID)
+	objectID := fmt.Sprintf("%s.%s", config.IssuerID, googleIDSanitizer.ReplaceAllString(fmt.Sprintf("ticket_%d_%s", ticket.ID, ticket.SerialNumber), "_"))
+
+	eventClass := map[string]interface{}{
+		"id":           classID,
+		"issuerName":   config.OrganizationName,
+		"eventName":    localized(ticket.Title),
+		"reviewStatus": "UNDER_REVIEW",
+		"venue": map[string]interface{}{
+			"name":    localized(ticket.Title),
+			"address": localized(ticket.Location),
+		},
+	}
+
+	eventObject := map[string]interface{}{
+		"id":               objectID,
+		"classId":          classID,
+		"state":            "ACTIVE",
+		"ticketHolderName": ticket.HolderName,
+		"ticketNumber":     ticket.SerialNumber,
+		"barcode": map[string]interface{}{
+			"type":          "QR_CODE",
+			"value":         ticket.SerialNumber,
+			"alternateText": ticket.SerialNumber,
+		},
+		"textModulesData": []map[string]interface{}{
+			{"id": "quantity", "header": "Jumlah tiket", "body": fmt.Sprint(ticket.Quantity)},
+		},
+	}
+	if !ticket.Date.IsZero() {
+		eventObject["validTimeInterval"] = map[string]interface{}{
+			"start": map[string]string{"date": ticket.Date.Format(time.RFC3339)},
+			"end":   map[string]string{"date": ticket.Date.AddDate(0, 0, 1).Format(time.RFC3339)},
+		}
+	}
+	if ticket.Lat != 0 || ticket.Long != 0 {
+		eventObject["locations"] = []map[string]float64{{"latitude": ticket.Lat, "longitude": ticket.Long}}
+	}
+
+	origins := config.Origins
+	if origins == nil {
+		origins = []string{}
+	}
+	claims := jwt.MapClaims{
+		"iss":     config.ServiceAccountEmail,
+		"aud":     "google",
+		"typ":     "savetowallet",
+		"iat":     time.Now().Unix(),
+		"origins": origins,
+		"payload": map[string]interface{}{
+			"eventTicketClasses": []interface{}{eventClass},
+			"eventTicketObjects": []interface{}{eventObject},
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(config.PrivateKey)
+	if err != nil {
+		return "", "", err
+	}
+	return token, googleSaveURLPrefix + token, nil
+}
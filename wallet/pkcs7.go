@@ -0,0 +1,153 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"sort"
+	"time"
+)
+
+var (
+	oidData             = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidSignedData       = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidContentType      = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidMessageDigest    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidSigningTime      = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 5}
+	oidSHA256           = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidRSAEncryption    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+	oidECDSAWithSHA256  = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+	sha256AlgorithmID   = pkix.AlgorithmIdentifier{Algorithm: oidSHA256, Parameters: asn1.NullRawValue}
+	rsaSignatureAlgID   = pkix.AlgorithmIdentifier{Algorithm: oidRSAEncryption, Parameters: asn1.NullRawValue}
+	ecdsaSignatureAlgID = pkix.AlgorithmIdentifier{Algorithm: oidECDSAWithSHA256}
+)
+
+type issuerAndSerialNumber struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type signerInfo struct {
+	Version                   int
+	IssuerAndSerialNumber     issuerAndSerialNumber
+	DigestAlgorithm           pkix.AlgorithmIdentifier
+	AuthenticatedAttributes   asn1.RawValue
+	DigestEncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedDigest           []byte
+}
+
+type encapsulatedContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+}
+
+type signedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	ContentInfo      encapsulatedContentInfo
+	Certificates     asn1.RawValue
+	SignerInfos      []signerInfo `asn1:"set"`
+}
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue
+}
+
+// signDetached membuat tanda tangan PKCS#7 (CMS SignedData) terpisah dari content dengan SHA-256.
+// Rantai sertifikat penandatangan ikut disertakan seperti yang disyaratkan Apple Wallet.
+func signDetached(content []byte, certificate *x509.Certificate, key crypto.Signer, chain []*x509.Certificate, signingTime time.Time) ([]byte, error) {
+	digest := sha256.Sum256(content)
+
+	contentTypeAttr, err := marshalAttribute(oidContentType, oidData)
+	if err != nil {
+		return nil, err
+	}
+	signingTimeAttr, err := marshalAttribute(oidSigningTime, signingTime.UTC())
+	if err != nil {
+		return nil, err
+	}
+	messageDigestAttr, err := marshalAttribute(oidMessageDigest, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	// DER mengharuskan elemen SET OF terurut berdasarkan encoding-nya
+	attributes := [][]byte{contentTypeAttr, signingTimeAttr, messageDigestAttr}
+	sort.Slice(attributes, func(i, j int) bool { return bytes.Compare(attributes[i], attributes[j]) < 0 })
+	attributesContent := bytes.Join(attributes, nil)
+
+	// Tanda tangan dihitung dari atribut yang di-encode sebagai SET, bukan sebagai [0] IMPLICIT
+	attributesSet, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: attributesContent})
+	if err != nil {
+		return nil, err
+	}
+	attributesDigest := sha256.Sum256(attributesSet)
+
+	var signatureAlgorithm pkix.AlgorithmIdentifier
+	switch key.Public().(type) {
+	case *rsa.PublicKey:
+		signatureAlgorithm = rsaSignatureAlgID
+	case *ecdsa.PublicKey:
+		signatureAlgorithm = ecdsaSignatureAlgID
+	default:
+		return nil, ErrUnsupportedKey
+	}
+	signature, err := key.Sign(rand.Reader, attributesDigest[:], crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+
+	certificates := append([]byte{}, certificate.Raw...)
+	for _, cert := range chain {
+		certificates = append(certificates, cert.Raw...)
+	}
+
+	signed := signedData{
+		Version:          1,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{sha256AlgorithmID},
+		ContentInfo:      encapsulatedContentInfo{ContentType: oidData},
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: certificates},
+		SignerInfos: []signerInfo{{
+			Version: 1,
+			IssuerAndSerialNumber: issuerAndSerialNumber{
+				Issuer:       asn1.RawValue{FullBytes: certificate.RawIssuer},
+				SerialNumber: certificate.SerialNumber,
+			},
+			DigestAlgorithm:           sha256AlgorithmID,
+			AuthenticatedAttributes:   asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: attributesContent},
+			DigestEncryptionAlgorithm: signatureAlgorithm,
+			EncryptedDigest:           signature,
+		}},
+	}
+	signedBytes, err := asn1.Marshal(signed)
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(contentInfo{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: signedBytes},
+	})
+}
+
+// marshalAttribute meng-encode atribut CMS dengan satu nilai
+func marshalAttribute(attributeType asn1.ObjectIdentifier, value interface{}) ([]byte, error) {
+	valueBytes, err := asn1.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(struct {
+		Type  asn1.ObjectIdentifier
+		Value asn1.RawValue
+	}{
+		Type:  attributeType,
+		Value: asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: valueBytes},
+	})
+}
@@ -0,0 +1,195 @@
+package wallet
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"time"
+)
+
+// PKPassContentType adalah content type untuk file Apple Wallet pass
+const PKPassContentType = "application/vnd.apple.pkpass"
+
+// Warna utama pass dan ikon bawaan
+var brandColor = color.RGBA{R: 22, G: 128, B: 96, A: 255}
+
+// Ticket adalah data e-ticket yang ditampilkan pada Apple Wallet maupun Google Wallet
+type Ticket struct {
+	ID           uint
+	SerialNumber string // Nomor invoice, dipakai juga sebagai isi barcode
+	WisataID     uint
+	Title        string
+	Location     string
+	HolderName   string
+	Date         time.Time // Tanggal check-in pada zona waktu wisata
+	Quantity     int
+	Lat          float64
+	Long         float64
+}
+
+type passField struct {
+	Key       string      `json:"key"`
+	Label     string      `json:"label"`
+	Value     interface{} `json:"value"`
+	DateStyle string      `json:"dateStyle,omitempty"`
+	TimeStyle string      `json:"timeStyle,omitempty"`
+}
+
+type passBarcode struct {
+	Format          string `json:"format"`
+	Message         string `json:"message"`
+	MessageEncoding string `json:"messageEncoding"`
+	AltText         string `json:"altText,omitempty"`
+}
+
+type passLocation struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+type passStructure struct {
+	PrimaryFields   []passField `json:"primaryFields"`
+	SecondaryFields []passField `json:"secondaryFields"`
+	AuxiliaryFields []passField `json:"auxiliaryFields"`
+	BackFields      []passField `json:"backFields"`
+}
+
+type passJSON struct {
+	FormatVersion      int            `json:"formatVersion"`
+	PassTypeIdentifier string         `json:"passTypeIdentifier"`
+	SerialNumber       string         `json:"serialNumber"`
+	TeamIdentifier     string         `json:"teamIdentifier"`
+	OrganizationName   string         `json:"organizationName"`
+	Description        string         `json:"description"`
+	LogoText           string         `json:"logoText"`
+	ForegroundColor    string         `json:"foregroundColor"`
+	BackgroundColor    string         `json:"backgroundColor"`
+	LabelColor         string         `json:"labelColor"`
+	RelevantDate       string         `json:"relevantDate,omitempty"`
+	Barcode            passBarcode    `json:"barcode"` // Untuk iOS lama yang belum mengenal barcodes
+	Barcodes           []passBarcode  `json:"barcodes"`
+	Locations          []passLocation `json:"locations,omitempty"`
+	EventTicket        passStructure  `json:"eventTicket"`
+}
+
+// BuildPKPass menyusun dan menandatangani file .pkpass untuk satu e-ticket tanpa memanggil layanan Apple
+func BuildPKPass(config AppleConfig, ticket Ticket) ([]byte, error) {
+	if config.Certificate == nil || config.PrivateKey == nil || config.WWDR == nil {
+		return nil, ErrNotConfigured
+	}
+
+	passData, err := json.MarshalIndent(newPassJSON(config, ticket), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	icon, err := renderIcon(29)
+	if err != nil {
+		return nil, err
+	}
+	icon2x, err := renderIcon(58)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{
+		"pass.json":   passData,
+		"icon.png":    icon,
+		"icon@2x.png": icon2x,
+	}
+	manifest := make(map[string]string, len(files))
+	for name, data := range files {
+		sum := sha1.Sum(data)
+		manifest[name] = hex.EncodeToString(sum[:])
+	}
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signDetached(manifestData, config.Certificate, config.PrivateKey, []*x509.Certificate{config.WWDR}, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	files["manifest.json"] = manifestData
+	files["signature"] = signature
+
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	for _, name := range []string{"pass.json", "icon.png", "icon@2x.png", "manifest.json", "signature"} {
+		writer, err := archive.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := writer.Write(files[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func newPassJSON(config AppleConfig, ticket Ticket) passJSON {
+	barcode := passBarcode{
+		Format:          "PKBarcodeFormatQR",
+		Message:         ticket.SerialNumber,
+		MessageEncoding: "iso-8859-1",
+		AltText:         ticket.SerialNumber,
+	}
+
+	pass := passJSON{
+		FormatVersion:      1,
+		PassTypeIdentifier: config.PassTypeID,
+		SerialNumber:       ticket.SerialNumber,
+		TeamIdentifier:     config.TeamID,
+		OrganizationName:   config.OrganizationName,
+		Description:        "E-ticket " + ticket.Title,
+		LogoText:           config.OrganizationName,
+		ForegroundColor:    "rgb(255, 255, 255)",
+		BackgroundColor:    fmt.Sprintf("rgb(%d, %d, %d)", brandColor.R, brandColor.G, brandColor.B),
+		LabelColor:         "rgb(220, 242, 234)",
+		Barcode:            barcode,
+		Barcodes:           []passBarcode{barcode},
+		EventTicket: passStructure{
+			PrimaryFields: []passField{{Key: "event", Label: "WISATA", Value: ticket.Title}},
+			SecondaryFields: []passField{
+				{Key: "date", Label: "TANGGAL", Value: ticket.Date.Format(time.RFC3339), DateStyle: "PKDateStyleMedium", TimeStyle: "PKDateStyleNone"},
+				{Key: "quantity", Label: "JUMLAH", Value: ticket.Quantity},
+			},
+			AuxiliaryFields: []passField{{Key: "holder", Label: "NAMA", Value: ticket.HolderName}},
+			BackFields: []passField{
+				{Key: "invoice", Label: "Invoice", Value: ticket.SerialNumber},
+				{Key: "location", Label: "Lokasi", Value: ticket.Location},
+			},
+		},
+	}
+	if !ticket.Date.IsZero() {
+		pass.RelevantDate = ticket.Date.Format(time.RFC3339)
+	}
+	if ticket.Lat != 0 || ticket.Long != 0 {
+		pass.Locations = []passLocation{{Latitude: ticket.Lat, Longitude: ticket.Long}}
+	}
+	return pass
+}
+
+// renderIcon membuat ikon polos berwarna brand karena Apple Wallet mewajibkan icon.png
+func renderIcon(size int) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for x := 0; x < size; x++ {
+		for y := 0; y < size; y++ {
+			img.Set(x, y, brandColor)
+		}
+	}
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, img); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
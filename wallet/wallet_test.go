@@ -0,0 +1,253 @@
+package wallet
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/dgrijalva/jwt-go"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Fixture di testdata adalah sertifikat self-signed khusus pengujian:
+// wwdr.pem berperan sebagai CA pengganti Apple WWDR, pass.pem/pass.key ditandatangani olehnya,
+// dan service_account.json berisi kunci RSA pengganti service account Google.
+
+func readFixture(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("membaca fixture %s: %v", name, err)
+	}
+	return string(data)
+}
+
+func fixtureTicket() Ticket {
+	return Ticket{
+		ID:           7,
+		SerialNumber: "INV/2024/0007",
+		WisataID:     3,
+		Title:        "Kawah Putih",
+		Location:     "Bandung",
+		HolderName:   "Budi",
+		Date:         time.Date(2024, 8, 17, 0, 0, 0, 0, time.UTC),
+		Quantity:     2,
+		Lat:          -7.166,
+		Long:         107.402,
+	}
+}
+
+func fixtureAppleConfig(t *testing.T) AppleConfig {
+	t.Helper()
+	t.Setenv("APPLE_PASS_TYPE_ID", "pass.test.destimate")
+	t.Setenv("APPLE_TEAM_ID", "TESTTEAM01")
+	t.Setenv("APPLE_PASS_CERTIFICATE", readFixture(t, "pass.pem"))
+	t.Setenv("APPLE_PASS_PRIVATE_KEY", readFixture(t, "pass.key"))
+	t.Setenv("APPLE_WWDR_CERTIFICATE", readFixture(t, "wwdr.pem"))
+	config, err := AppleConfigFromEnv()
+	if err != nil {
+		t.Fatalf("AppleConfigFromEnv: %v", err)
+	}
+	return config
+}
+
+func fixtureGoogleConfig(t *testing.T) GoogleConfig {
+	t.Helper()
+	t.Setenv("GOOGLE_WALLET_ISSUER_ID", "3388000000000000000")
+	t.Setenv("GOOGLE_WALLET_CREDENTIALS", readFixture(t, "service_account.json"))
+	t.Setenv("GOOGLE_WALLET_ORIGINS", "https://destimate.test, ")
+	config, err := GoogleConfigFromEnv()
+	if err != nil {
+		t.Fatalf("GoogleConfigFromEnv: %v", err)
+	}
+	return config
+}
+
+func TestBuildPKPass(t *testing.T) {
+	config := fixtureAppleConfig(t)
+	data, err := BuildPKPass(config, fixtureTicket())
+	if err != nil {
+		t.Fatalf("BuildPKPass: %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("membaca zip: %v", err)
+	}
+	files := make(map[string][]byte)
+	var names []string
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatalf("membuka %s: %v", file.Name, err)
+		}
+		content, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			t.Fatalf("membaca %s: %v", file.Name, err)
+		}
+		names = append(names, file.Name)
+		files[file.Name] = content
+	}
+	expectedNames := []string{"pass.json", "icon.png", "icon@2x.png", "manifest.json", "signature"}
+	if strings.Join(names, ",") != strings.Join(expectedNames, ",") {
+		t.Fatalf("isi pkpass %v, seharusnya %v", names, expectedNames)
+	}
+
+	var pass passJSON
+	if err := json.Unmarshal(files["pass.json"], &pass); err != nil {
+		t.Fatalf("pass.json tidak valid: %v", err)
+	}
+	if pass.PassTypeIdentifier != "pass.test.destimate" || pass.TeamIdentifier != "TESTTEAM01" || pass.SerialNumber != "INV/2024/0007" {
+		t.Errorf("identitas pass tidak sesuai: %+v", pass)
+	}
+	if pass.Barcodes[0].Message != "INV/2024/0007" {
+		t.Errorf("isi barcode %q", pass.Barcodes[0].Message)
+	}
+
+	// Manifest harus memuat SHA1 setiap file selain manifest dan signature
+	var manifest map[string]string
+	if err := json.Unmarshal(files["manifest.json"], &manifest); err != nil {
+		t.Fatalf("manifest.json tidak valid: %v", err)
+	}
+	if len(manifest) != 3 {
+		t.Errorf("manifest berisi %d file, seharusnya 3", len(manifest))
+	}
+	for _, name := range []string{"pass.json", "icon.png", "icon@2x.png"} {
+		sum := sha1.Sum(files[name])
+		if manifest[name] != hex.EncodeToString(sum[:]) {
+			t.Errorf("SHA1 %s pada manifest %q tidak sesuai", name, manifest[name])
+		}
+	}
+
+	verifySignature(t, files["signature"], files["manifest.json"], config)
+}
+
+// verifySignature memeriksa tanda tangan PKCS#7 terpisah atas manifest dengan sertifikat fixture
+func verifySignature(t *testing.T, signature, manifest []byte, config AppleConfig) {
+	t.Helper()
+
+	var info contentInfo
+	if rest, err := asn1.Unmarshal(signature, &info); err != nil || len(rest) > 0 {
+		t.Fatalf("ContentInfo tidak valid: %v", err)
+	}
+	if !info.ContentType.Equal(oidSignedData) {
+		t.Fatalf("content type %v bukan SignedData", info.ContentType)
+	}
+	var signed signedData
+	if _, err := asn1.Unmarshal(info.Content.Bytes, &signed); err != nil {
+		t.Fatalf("SignedData tidak valid: %v", err)
+	}
+	if len(signed.SignerInfos) != 1 {
+		t.Fatalf("jumlah signer %d", len(signed.SignerInfos))
+	}
+	if !bytes.Equal(signed.Certificates.Bytes, append(append([]byte{}, config.Certificate.Raw...), config.WWDR.Raw...)) {
+		t.Error("rantai sertifikat harus berisi sertifikat pass lalu WWDR")
+	}
+	if err := config.Certificate.CheckSignatureFrom(config.WWDR); err != nil {
+		t.Errorf("sertifikat pass tidak ditandatangani WWDR fixture: %v", err)
+	}
+
+	signer := signed.SignerInfos[0]
+	if signer.IssuerAndSerialNumber.SerialNumber.Cmp(config.Certificate.SerialNumber) != 0 {
+		t.Error("serial number signer tidak sesuai sertifikat pass")
+	}
+
+	// messageDigest pada atribut harus sama dengan SHA-256 manifest
+	manifestDigest := sha256.Sum256(manifest)
+	var foundDigest bool
+	rest := signer.AuthenticatedAttributes.Bytes
+	for len(rest) > 0 {
+		var attribute struct {
+			Type  asn1.ObjectIdentifier
+			Value asn1.RawValue
+		}
+		var err error
+		if rest, err = asn1.Unmarshal(rest, &attribute); err != nil {
+			t.Fatalf("atribut tidak valid: %v", err)
+		}
+		if attribute.Type.Equal(oidMessageDigest) {
+			var digest []byte
+			if _, err := asn1.Unmarshal(attribute.Value.Bytes, &digest); err != nil {
+				t.Fatalf("messageDigest tidak valid: %v", err)
+			}
+			foundDigest = bytes.Equal(digest, manifestDigest[:])
+		}
+	}
+	if !foundDigest {
+		t.Error("messageDigest tidak sama dengan SHA-256 manifest")
+	}
+
+	// Tanda tangan dihitung atas atribut yang di-encode ulang sebagai SET
+	attributesSet, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: signer.AuthenticatedAttributes.Bytes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	attributesDigest := sha256.Sum256(attributesSet)
+	publicKey := config.Certificate.PublicKey.(*rsa.PublicKey)
+	if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, attributesDigest[:], signer.EncryptedDigest); err != nil {
+		t.Errorf("tanda tangan manifest tidak valid: %v", err)
+	}
+}
+
+func TestBuildPKPassNotConfigured(t *testing.T) {
+	if _, err := BuildPKPass(AppleConfig{}, fixtureTicket()); err != ErrNotConfigured {
+		t.Errorf("error %v, seharusnya ErrNotConfigured", err)
+	}
+}
+
+func TestGoogleSaveJWT(t *testing.T) {
+	config := fixtureGoogleConfig(t)
+	token, saveURL, err := GoogleSaveJWT(config, fixtureTicket())
+	if err != nil {
+		t.Fatalf("GoogleSaveJWT: %v", err)
+	}
+	if saveURL != googleSaveURLPrefix+token {
+		t.Errorf("URL save %q tidak memakai JWT", saveURL)
+	}
+
+	parsed, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+		if token.Method != jwt.SigningMethodRS256 {
+			t.Errorf("algoritma %v, seharusnya RS256", token.Header["alg"])
+		}
+		return &config.PrivateKey.PublicKey, nil
+	})
+	if err != nil || !parsed.Valid {
+		t.Fatalf("tanda tangan JWT tidak valid: %v", err)
+	}
+
+	claims := parsed.Claims.(jwt.MapClaims)
+	if claims["iss"] != "wallet@destimate-test.iam.gserviceaccount.com" || claims["aud"] != "google" || claims["typ"] != "savetowallet" {
+		t.Errorf("claim iss/aud/typ tidak sesuai: %v", claims)
+	}
+	if origins, _ := claims["origins"].([]interface{}); len(origins) != 1 || origins[0] != "https://destimate.test" {
+		t.Errorf("origins %v", claims["origins"])
+	}
+
+	payload := claims["payload"].(map[string]interface{})
+	class := payload["eventTicketClasses"].([]interface{})[0].(map[string]interface{})
+	object := payload["eventTicketObjects"].([]interface{})[0].(map[string]interface{})
+	if class["id"] != "3388000000000000000.wisata_3" {
+		t.Errorf("id class %v", class["id"])
+	}
+	if object["classId"] != class["id"] {
+		t.Errorf("classId object %v tidak merujuk class", object["classId"])
+	}
+	// Karakter '/' pada nomor invoice tidak boleh ada di id object
+	if object["id"] != "3388000000000000000.ticket_7_INV_2024_0007" {
+		t.Errorf("id object %v", object["id"])
+	}
+	if barcode := object["barcode"].(map[string]interface{}); barcode["value"] != "INV/2024/0007" {
+		t.Errorf("isi barcode %v", barcode["value"])
+	}
+}